@@ -0,0 +1,284 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/hazim1093/zeta-comms/internal/comms"
+	"github.com/hazim1093/zeta-comms/internal/config"
+	"github.com/hazim1093/zeta-comms/internal/events"
+	"github.com/hazim1093/zeta-comms/internal/storage"
+	"github.com/hazim1093/zeta-comms/pkg/models"
+	"github.com/rs/zerolog"
+)
+
+const stateUsage = "state show [network] | state reset <network> [last-processed-id]"
+
+// command is a subcommand of the zeta-comms binary
+type command struct {
+	usage       string
+	description string
+	minArgs     int
+	maxArgs     int // -1 means unlimited
+
+	// strict commands refuse to run with an invalid configuration
+	strict bool
+
+	run func(cfg *config.Config, log *zerolog.Logger, args []string) error
+}
+
+var commands = map[string]command{
+	"run": {
+		usage:       "run",
+		description: "Run the notification service (default)",
+		strict:      true,
+		run:         runService,
+	},
+	"validate": {
+		usage:       "validate",
+		description: "Validate the configuration and exit",
+	},
+	"test-notify": {
+		usage:       "test-notify <audience>",
+		description: "Send a synthetic notification to every channel of an audience",
+		minArgs:     1,
+		maxArgs:     1,
+		strict:      true,
+		run:         runTestNotify,
+	},
+	"replay": {
+		usage:       "replay <network> <proposal-id>",
+		description: "Send a proposal again to all audiences of a network",
+		minArgs:     2,
+		maxArgs:     2,
+		strict:      true,
+		run:         runReplay,
+	},
+	"list-proposals": {
+		usage:       "list-proposals <network>",
+		description: "List the proposals of a network that match the configured filters",
+		minArgs:     1,
+		maxArgs:     1,
+		run:         runListProposals,
+	},
+	"state": {
+		usage:       stateUsage,
+		description: "Show or reset the stored processing state",
+		minArgs:     1,
+		maxArgs:     3,
+		run:         runState,
+	},
+	"broadcast": {
+		usage:       "broadcast <message>",
+		description: "Broadcast a message to all configured audiences",
+		minArgs:     1,
+		maxArgs:     -1,
+		strict:      true,
+		run:         runBroadcast,
+	},
+}
+
+func printUsage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	fmt.Fprintln(os.Stderr, "Usage: zeta-comms [--config file1,file2] <command> [args]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Commands:")
+
+	w := tabwriter.NewWriter(os.Stderr, 0, 0, 2, ' ', 0)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %s\t%s\n", commands[name].usage, commands[name].description)
+	}
+
+	_ = w.Flush()
+}
+
+// runService runs the daemon until it receives a termination signal
+func runService(cfg *config.Config, log *zerolog.Logger, _ []string) error {
+	log.Debug().Interface("config", cfg).Msg("config loaded")
+
+	// Create a context that can be cancelled
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Set up signal handling for graceful shutdown
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+
+	//----------------------------------------
+	serverDone := startZetaComms(ctx, cfg, log)
+	//----------------------------------------
+
+	// Wait for termination signal
+	sig := <-sigCh
+	log.Info().Msgf("Received signal %v, shutting down...", sig)
+	cancel() // This will propagate cancellation to the polling goroutine
+
+	// Let the HTTP server finish in-flight requests before the process exits
+	if serverDone != nil {
+		<-serverDone
+	}
+
+	log.Info().Msg("Shutdown complete")
+
+	return nil
+}
+
+func runTestNotify(cfg *config.Config, log *zerolog.Logger, args []string) error {
+	deliveries, err := comms.NewCommsEngine(cfg, log).TestNotify(args[0])
+	if err != nil {
+		return err
+	}
+
+	return printDeliveries(deliveries)
+}
+
+func runReplay(cfg *config.Config, log *zerolog.Logger, args []string) error {
+	deliveries, err := comms.NewCommsEngine(cfg, log).ReplayProposal(args[0], args[1])
+	if err != nil {
+		return err
+	}
+
+	return printDeliveries(deliveries)
+}
+
+func runBroadcast(cfg *config.Config, log *zerolog.Logger, args []string) error {
+	username := os.Getenv("USER")
+	if username == "" {
+		username = "cli"
+	}
+
+	deliveries := comms.NewCommsEngine(cfg, log).Broadcast(models.BroadcastMessage{
+		Message:  strings.Join(args, " "),
+		Username: username,
+	})
+
+	return printDeliveries(deliveries)
+}
+
+func runListProposals(cfg *config.Config, log *zerolog.Logger, args []string) error {
+	network := args[0]
+
+	proposals, err := events.NewGovService(cfg, log).ListProposals(network)
+	if err != nil {
+		return err
+	}
+
+	lastProcessedID, err := storage.NewStorageService(cfg, log).GetLastProcessedProposalID(network)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSTATUS\tVOTING ENDS\tPROCESSED\tTITLE")
+
+	for _, proposal := range proposals {
+		processed := lastProcessedID != "" && !comms.IsNewerProposalID(proposal.ProposalId, lastProcessedID)
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%s\n",
+			proposal.ProposalId,
+			proposal.Status,
+			proposal.VotingEndTime.Format("2006-01-02 15:04 MST"),
+			processed,
+			proposal.Title)
+	}
+
+	return w.Flush()
+}
+
+func runState(cfg *config.Config, log *zerolog.Logger, args []string) error {
+	storageService := storage.NewStorageService(cfg, log)
+
+	switch args[0] {
+	case "show":
+		networks, err := storageService.GetNetworks()
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NETWORK\tLAST PROCESSED ID\tTRACKED PROPOSALS")
+
+		names := make([]string, 0, len(networks))
+		for name := range networks {
+			if len(args) < 2 || args[1] == name {
+				names = append(names, name)
+			}
+		}
+
+		sort.Strings(names)
+
+		for _, name := range names {
+			fmt.Fprintf(w, "%s\t%s\t%d\n", name, networks[name].LastProcessedProposalID, len(networks[name].Proposals))
+		}
+
+		return w.Flush()
+	case "reset":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: %s", stateUsage)
+		}
+
+		network := args[1]
+		if _, ok := cfg.Networks[network]; !ok {
+			return fmt.Errorf("%w: %s", comms.ErrUnknownNetwork, network)
+		}
+
+		lastProcessedID := ""
+		if len(args) == 3 {
+			lastProcessedID = args[2]
+		}
+
+		if err := storageService.ResetNetwork(network, lastProcessedID); err != nil {
+			return err
+		}
+
+		if lastProcessedID == "" {
+			fmt.Printf("State of %s cleared, all matching proposals will be notified again on the next run\n", network)
+		} else {
+			fmt.Printf("Last processed proposal of %s set to %s\n", network, lastProcessedID)
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("unknown state subcommand %q, usage: %s", args[0], stateUsage)
+	}
+}
+
+// printDeliveries prints the outcome of each delivery and fails if any of them failed
+func printDeliveries(deliveries []models.Delivery) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "AUDIENCE\tPLATFORM\tCHANNEL\tRESULT")
+
+	failed := 0
+
+	for _, delivery := range deliveries {
+		result := "ok"
+		if !delivery.Succeeded() {
+			result = "FAILED: " + delivery.Error
+			failed++
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", delivery.Audience, delivery.Platform, delivery.Channel, result)
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d deliveries failed", failed, len(deliveries))
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package comms
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/hazim1093/zeta-comms/internal/config"
+	"github.com/hazim1093/zeta-comms/pkg/models"
+)
+
+// raiseAlert notifies the alerts audience about an operational problem. A problem is reported once,
+// identified by key, until resolveAlert is called for it. Should the alert reach no channel, it is
+// sent again the next time the problem is raised.
+func (e *CommsEngine) raiseAlert(key string, network string, title string, details string) {
+	// Held while sending, so that concurrent reports of the problem do not send it twice
+	e.alertMu.Lock()
+	defer e.alertMu.Unlock()
+
+	if e.alerts[key] {
+		return
+	}
+
+	e.log.Warn().Str("network", network).Str("alert", key).Msg(title)
+	e.alerts[key] = e.sendAlert(network, "⚠️ "+title, details)
+}
+
+// resolveAlert notifies the alerts audience that a problem reported with raiseAlert is over
+func (e *CommsEngine) resolveAlert(key string, network string, title string) {
+	e.alertMu.Lock()
+	active := e.alerts[key]
+	delete(e.alerts, key)
+	e.alertMu.Unlock()
+
+	if !active {
+		return
+	}
+
+	e.log.Info().Str("network", network).Str("alert", key).Msg(title)
+	e.sendAlert(network, "✅ "+title, "")
+}
+
+// sendAlert sends an alert to the alerts audience and reports whether it reached at least one
+// channel. Without an alerts audience there is nobody to deliver to, which counts as delivered.
+func (e *CommsEngine) sendAlert(network string, title string, details string) bool {
+	audience := e.config.Alerts.Audience
+	if audience == "" {
+		return true
+	}
+
+	deliveries := e.notificationService.Notify(models.Notification{
+		EventType: models.EventAlert,
+		Network:   network,
+		Severity:  models.SeverityCritical,
+		Title:     title,
+		Summary:   details,
+	}, audience)
+
+	return slices.ContainsFunc(deliveries, models.Delivery.Succeeded)
+}
+
+// endpointsAlert reports or resolves the unavailability of all endpoints of a network for its transport
+func (e *CommsEngine) endpointsAlert(network string, err error) {
+	key := "endpoints/" + network
+
+	transport := "LCD"
+	if e.config.Networks[network].Transport == config.TransportGRPC {
+		transport = "gRPC"
+	}
+
+	if err == nil {
+		e.resolveAlert(key, network, transport+" endpoints are available again")
+
+		return
+	}
+
+	e.raiseAlert(key, network, "All "+transport+" endpoints are unavailable",
+		fmt.Sprintf("Proposals are not polled until an endpoint recovers.\n\n```\n%s\n```", err))
+}
@@ -0,0 +1,319 @@
+package comms
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/hazim1093/zeta-comms/internal/notifications"
+	"github.com/hazim1093/zeta-comms/internal/storage"
+	"github.com/hazim1093/zeta-comms/pkg/models"
+	"github.com/hazim1093/zeta-comms/pkg/zetachain"
+	"github.com/robfig/cron/v3"
+)
+
+// deadlineHorizon is how far ahead deadlines are listed if the next digest is unknown
+const deadlineHorizon = 24 * time.Hour
+
+// StartDigests schedules the digests of all audiences in digest delivery mode until ctx is done
+func (e *CommsEngine) StartDigests(ctx context.Context) {
+	for audience, audienceConfig := range e.config.AudienceConfig {
+		if !audienceConfig.Delivery.Digest() {
+			continue
+		}
+
+		log := e.log.With().Str("audience", audience).Logger()
+
+		schedule, err := cron.ParseStandard(audienceConfig.Delivery.Schedule)
+		if err != nil {
+			log.Error().Err(err).Msg("Invalid digest schedule, no digests will be sent")
+
+			continue
+		}
+
+		location, err := time.LoadLocation(audienceConfig.Timezone)
+		if err != nil {
+			location = time.UTC
+		}
+
+		log.Info().Str("schedule", audienceConfig.Delivery.Schedule).Msg("Scheduling digests")
+
+		go e.runDigests(ctx, audience, schedule, location)
+	}
+}
+
+func (e *CommsEngine) runDigests(ctx context.Context, audience string, schedule cron.Schedule, location *time.Location) {
+	for {
+		next := schedule.Next(time.Now().In(location))
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return
+		case <-timer.C:
+		}
+
+		e.SendDigest(audience, schedule.Next(next))
+	}
+}
+
+// SendDigest sends the events collected for an audience since its previous digest, together with
+// the voting deadlines before nextDigest. Events are kept for the next digest unless every channel
+// received this one, so channels that did receive it get the events again rather than others losing them.
+func (e *CommsEngine) SendDigest(audience string, nextDigest time.Time) []models.Delivery {
+	log := e.log.With().Str("audience", audience).Logger()
+	now := time.Now().UTC()
+
+	pending, err := e.pendingDigest(audience)
+	if err != nil {
+		log.Error().Err(err).Msg("Error loading pending digest")
+
+		return nil
+	}
+
+	if nextDigest.IsZero() {
+		nextDigest = now.Add(deadlineHorizon)
+	}
+
+	digest := buildDigest(pending.Items, e.upcomingDeadlines(audience, now, nextDigest))
+	digest.Since = pending.LastSent
+	digest.Until = now
+
+	if digest.Empty() {
+		log.Debug().Msg("Nothing to report, skipping digest")
+		e.completeDigest(audience, now)
+
+		return nil
+	}
+
+	log.Info().Int("items", len(pending.Items)).Int("deadlines", len(digest.Deadlines)).Msg("Sending digest")
+
+	deliveries := e.notificationService.Notify(notifications.Notification{
+		EventType: models.EventDigest,
+		Digest:    &digest,
+	}, audience)
+
+	failed := len(deliveries) == 0
+	for _, delivery := range deliveries {
+		failed = failed || !delivery.Succeeded()
+	}
+
+	if failed {
+		log.Warn().Msg("Digest could not be delivered to every channel, keeping its events for the next one")
+
+		return deliveries
+	}
+
+	e.completeDigest(audience, now)
+
+	return deliveries
+}
+
+// buildDigest groups collected events into the sections of a digest
+func buildDigest(items []models.DigestItem, deadlines []models.DigestItem) models.Digest {
+	digest := models.Digest{Deadlines: deadlines}
+
+	upgrades := make(map[string]int)
+
+	for _, item := range items {
+		switch item.Kind {
+		case models.DigestNewProposal:
+			digest.NewProposals = append(digest.NewProposals, item)
+		case models.DigestStatusChange:
+			digest.StatusChanges = append(digest.StatusChanges, item)
+		}
+
+		if item.UpgradeName == "" {
+			continue
+		}
+
+		// List each upgrade once, in its latest state
+		key := item.Network + "/" + item.ProposalId
+		if i, ok := upgrades[key]; ok {
+			digest.Upgrades[i] = item
+
+			continue
+		}
+
+		upgrades[key] = len(digest.Upgrades)
+		digest.Upgrades = append(digest.Upgrades, item)
+	}
+
+	return digest
+}
+
+// newDigestItem describes a proposal event for a digest
+func (e *CommsEngine) newDigestItem(kind string, network string, proposal zetachain.Proposal) models.DigestItem {
+	upgradeName, targetHeight := notifications.UpgradePlan(proposal)
+
+	return models.DigestItem{
+		Kind:          kind,
+		Time:          time.Now().UTC(),
+		Network:       network,
+		ProposalId:    proposal.ProposalId,
+		Title:         proposal.Title,
+		Status:        proposal.Status,
+		UpgradeName:   upgradeName,
+		TargetHeight:  targetHeight,
+		VotingEndTime: proposal.VotingEndTime,
+		ProposalURL:   notifications.ProposalLink(e.config.Networks[network].Links.Explorer, proposal.ProposalId),
+	}
+}
+
+// digestAudiences splits the audiences of a network by delivery mode
+func (e *CommsEngine) digestAudiences(network string) (realtime []string, digest []string) {
+	for _, audience := range e.config.Networks[network].Audiences {
+		if e.config.AudienceConfig[audience].Delivery.Digest() {
+			digest = append(digest, audience)
+		} else {
+			realtime = append(realtime, audience)
+		}
+	}
+
+	return realtime, digest
+}
+
+// recordStatusChanges records the new status of known proposals and queues the change for the
+// digest audiences, if any. Status changes are not tracked in dry-run mode, as proposals are not recorded there.
+func (e *CommsEngine) recordStatusChanges(network string, proposals []zetachain.Proposal, digestAudiences []string) {
+	if e.config.DryRun.Enabled {
+		return
+	}
+
+	records, err := e.storageService.GetProposals(network)
+	if err != nil {
+		e.log.Error().Err(err).Str("network", network).Msg("Error loading tracked proposals")
+
+		return
+	}
+
+	statuses := make(map[string]string, len(records))
+	for _, record := range records {
+		statuses[record.ID] = record.Status
+	}
+
+	for _, proposal := range proposals {
+		previous, ok := statuses[proposal.ProposalId]
+		if !ok || previous == proposal.Status {
+			continue
+		}
+
+		item := e.newDigestItem(models.DigestStatusChange, network, proposal)
+		item.PreviousStatus = previous
+
+		for _, audience := range digestAudiences {
+			e.queueDigestItem(audience, item)
+		}
+
+		if err := e.storageService.RecordProposal(network, proposal.ProposalId, proposal.Title, proposal.Status); err != nil {
+			e.log.Error().Err(err).Msg("Error recording proposal")
+		}
+	}
+}
+
+// observeVoting remembers the proposals of a network that are open for voting, to list their deadlines
+// in digests. A partial update only replaces the given proposals, keeping the others.
+func (e *CommsEngine) observeVoting(network string, proposals []zetachain.Proposal, partial bool) {
+	e.digestMu.Lock()
+	defer e.digestMu.Unlock()
+
+	var voting []models.DigestItem
+
+	if partial {
+		for _, item := range e.voting[network] {
+			updated := slices.ContainsFunc(proposals, func(proposal zetachain.Proposal) bool {
+				return proposal.ProposalId == item.ProposalId
+			})
+
+			if !updated {
+				voting = append(voting, item)
+			}
+		}
+	}
+
+	for _, proposal := range proposals {
+		if proposal.Status == "PROPOSAL_STATUS_VOTING_PERIOD" {
+			voting = append(voting, e.newDigestItem("", network, proposal))
+		}
+	}
+
+	e.voting[network] = voting
+}
+
+// upcomingDeadlines returns the proposals of the audience's networks whose voting ends between now and until
+func (e *CommsEngine) upcomingDeadlines(audience string, now time.Time, until time.Time) []models.DigestItem {
+	e.digestMu.Lock()
+	defer e.digestMu.Unlock()
+
+	var deadlines []models.DigestItem
+
+	for network, networkConfig := range e.config.Networks {
+		if !slices.Contains(networkConfig.Audiences, audience) {
+			continue
+		}
+
+		for _, item := range e.voting[network] {
+			if item.VotingEndTime.After(now) && !item.VotingEndTime.After(until) {
+				deadlines = append(deadlines, item)
+			}
+		}
+	}
+
+	sort.Slice(deadlines, func(i, j int) bool {
+		return deadlines[i].VotingEndTime.Before(deadlines[j].VotingEndTime)
+	})
+
+	return deadlines
+}
+
+// queueDigestItem stores an event for the next digest of an audience, in memory in dry-run mode
+func (e *CommsEngine) queueDigestItem(audience string, item models.DigestItem) {
+	if e.config.DryRun.Enabled {
+		e.digestMu.Lock()
+		defer e.digestMu.Unlock()
+
+		e.dryRunDigests[audience] = append(e.dryRunDigests[audience], item)
+
+		return
+	}
+
+	if err := e.storageService.AddDigestItems(audience, item); err != nil {
+		e.log.Error().Err(err).Str("audience", audience).Msg("Error storing digest item")
+	}
+}
+
+func (e *CommsEngine) pendingDigest(audience string) (storage.DigestData, error) {
+	if e.config.DryRun.Enabled {
+		e.digestMu.Lock()
+		defer e.digestMu.Unlock()
+
+		return storage.DigestData{Items: append([]models.DigestItem(nil), e.dryRunDigests[audience]...)}, nil
+	}
+
+	return e.storageService.GetDigest(audience)
+}
+
+func (e *CommsEngine) completeDigest(audience string, sent time.Time) {
+	if e.config.DryRun.Enabled {
+		e.digestMu.Lock()
+		defer e.digestMu.Unlock()
+
+		pending := e.dryRunDigests[audience][:0]
+		for _, item := range e.dryRunDigests[audience] {
+			if item.Time.After(sent) {
+				pending = append(pending, item)
+			}
+		}
+
+		e.dryRunDigests[audience] = pending
+
+		return
+	}
+
+	if err := e.storageService.CompleteDigest(audience, sent); err != nil {
+		e.log.Error().Err(err).Str("audience", audience).Msg("Error completing digest")
+	}
+}
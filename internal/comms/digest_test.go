@@ -0,0 +1,86 @@
+package comms_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/hazim1093/zeta-comms/internal/comms"
+	"github.com/hazim1093/zeta-comms/internal/config"
+	"github.com/hazim1093/zeta-comms/pkg/models"
+	"github.com/hazim1093/zeta-comms/pkg/zetachain"
+)
+
+var _ = Describe("SendDigest", func() {
+	var (
+		engine  *comms.CommsEngine
+		discord *fakeNotifier
+		slack   *fakeNotifier
+	)
+
+	BeforeEach(func() {
+		cfg := newConfig()
+		audience := cfg.AudienceConfig["operators"]
+		audience.Delivery = config.Delivery{Mode: config.DeliveryDigest, Schedule: "0 9 * * *"}
+		cfg.AudienceConfig["operators"] = audience
+
+		engine, discord, slack = newEngine(cfg, zetachain.NewFakeClient())
+	})
+
+	It("should skip digests without events", func() {
+		Expect(engine.SendDigest("operators", time.Time{})).To(BeEmpty())
+		Expect(discord.sent).To(BeEmpty())
+		Expect(slack.sent).To(BeEmpty())
+	})
+
+	It("should keep the events until every channel received the digest", func() {
+		process(engine, "mainnet", zetachain.Proposal{ProposalId: "7", Title: "Upgrade to v30", Status: "PROPOSAL_STATUS_DEPOSIT_PERIOD"})
+		Expect(discord.sent).To(BeEmpty())
+
+		discord.setErr(errors.New("missing access"))
+
+		deliveries := engine.SendDigest("operators", time.Time{})
+		Expect(deliveries).To(HaveLen(2))
+		Expect(slack.sent).To(HaveLen(1))
+
+		discord.setErr(nil)
+
+		deliveries = engine.SendDigest("operators", time.Time{})
+		Expect(deliveries).To(HaveLen(2))
+		Expect(discord.sent).To(HaveLen(1))
+		Expect(discord.sent[0].EventType).To(Equal(models.EventDigest))
+		Expect(discord.sent[0].Digest.NewProposals).To(ConsistOf(HaveField("ProposalId", "7")))
+
+		// Delivered everywhere, so the events are gone
+		Expect(engine.SendDigest("operators", time.Time{})).To(BeEmpty())
+		Expect(discord.sent).To(HaveLen(1))
+		Expect(slack.sent).To(HaveLen(2))
+	})
+
+	It("should list status changes and each upgrade once in its latest state", func() {
+		upgrade := zetachain.Proposal{ProposalId: "7", Title: "Upgrade to v30", Status: "PROPOSAL_STATUS_DEPOSIT_PERIOD"}
+		upgrade.Messages = []zetachain.Message{{Type: "/cosmos.upgrade.v1beta1.MsgSoftwareUpgrade"}}
+		upgrade.Messages[0].Data.Plan.Name = "v30"
+		upgrade.Messages[0].Data.Plan.Height = "1000"
+
+		process(engine, "mainnet", upgrade)
+
+		upgrade.Status = "PROPOSAL_STATUS_VOTING_PERIOD"
+		process(engine, "mainnet", upgrade)
+
+		Expect(engine.SendDigest("operators", time.Time{})).To(HaveLen(2))
+
+		digest := discord.sent[0].Digest
+		Expect(digest.NewProposals).To(HaveLen(1))
+		Expect(digest.StatusChanges).To(ConsistOf(And(
+			HaveField("PreviousStatus", "PROPOSAL_STATUS_DEPOSIT_PERIOD"),
+			HaveField("Status", "PROPOSAL_STATUS_VOTING_PERIOD"),
+		)))
+		Expect(digest.Upgrades).To(ConsistOf(And(
+			HaveField("UpgradeName", "v30"),
+			HaveField("Status", "PROPOSAL_STATUS_VOTING_PERIOD"),
+		)))
+	})
+})
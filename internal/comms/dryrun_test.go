@@ -0,0 +1,54 @@
+package comms_test
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rs/zerolog"
+
+	"github.com/hazim1093/zeta-comms/internal/comms"
+	"github.com/hazim1093/zeta-comms/internal/events"
+	"github.com/hazim1093/zeta-comms/internal/storage"
+	"github.com/hazim1093/zeta-comms/pkg/models"
+	"github.com/hazim1093/zeta-comms/pkg/zetachain"
+)
+
+// process hands the engine a single poll of a network and waits until it is handled
+func process(engine *comms.CommsEngine, network string, proposals ...zetachain.Proposal) {
+	updateCh := make(chan events.ProposalUpdate, 1)
+	updateCh <- events.ProposalUpdate{Proposals: proposals}
+	close(updateCh)
+
+	engine.ProcessProposalUpdates(network, updateCh)
+}
+
+var _ = Describe("Dry run", func() {
+	It("should send notifications without touching the stored state", func() {
+		cfg := newConfig()
+		cfg.DryRun.Enabled = true
+		cfg.DryRun.OutputDir = GinkgoT().TempDir()
+
+		engine, discord, _ := newEngine(cfg, zetachain.NewFakeClient())
+
+		logger := zerolog.Nop()
+		storageService := storage.NewStorageService(cfg, &logger)
+		Expect(storageService.RecordProposal("mainnet", "6", "Earlier proposal", "PROPOSAL_STATUS_PASSED")).To(Succeed())
+		Expect(storageService.RecordDeliveries("mainnet", "6", []models.Delivery{{Audience: "operators", Platform: "discord", Channel: "123"}})).To(Succeed())
+		Expect(storageService.StoreLastProcessedProposalID("mainnet", "6")).To(Succeed())
+
+		state, err := os.ReadFile(cfg.Storage.Filename)
+		Expect(err).NotTo(HaveOccurred())
+
+		proposals := []zetachain.Proposal{
+			{ProposalId: "7", Title: "Upgrade to v30", Status: "PROPOSAL_STATUS_VOTING_PERIOD"},
+			{ProposalId: "8", Title: "Raise the gas limit", Status: "PROPOSAL_STATUS_DEPOSIT_PERIOD"},
+		}
+
+		process(engine, "mainnet", proposals...)
+		process(engine, "mainnet", proposals...)
+
+		Expect(discord.titles()).To(Equal([]string{"Upgrade to v30", "Raise the gas limit"}))
+		Expect(os.ReadFile(cfg.Storage.Filename)).To(Equal(state))
+	})
+})
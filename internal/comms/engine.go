@@ -1,22 +1,60 @@
 package comms
 
 import (
+	"errors"
+	"fmt"
 	"strconv"
+	"sync"
 
 	"github.com/hazim1093/zeta-comms/internal/config"
 	"github.com/hazim1093/zeta-comms/internal/events"
+	"github.com/hazim1093/zeta-comms/internal/metrics"
 	"github.com/hazim1093/zeta-comms/internal/notifications"
 	"github.com/hazim1093/zeta-comms/internal/storage"
 	"github.com/hazim1093/zeta-comms/pkg/models"
+	"github.com/hazim1093/zeta-comms/pkg/notifiers"
 	"github.com/hazim1093/zeta-comms/pkg/zetachain"
 	"github.com/rs/zerolog"
 )
 
+var (
+	// ErrUnknownNetwork is returned when a network is not configured
+	ErrUnknownNetwork = errors.New("unknown network")
+
+	// ErrUnknownAudience is returned when an audience is not configured
+	ErrUnknownAudience = errors.New("unknown audience")
+)
+
 type CommsEngine struct {
 	config              *config.Config
 	log                 *zerolog.Logger
 	notificationService *notifications.NotificationService
 	storageService      *storage.StorageService
+	client              zetachain.Client
+	mapper              *notifications.Mapper
+	nodeMonitor         *events.NodeMonitor
+	chainMonitor        *events.ChainMonitor
+
+	// Proposals open for voting per network, and pending digests in dry-run mode
+	digestMu      sync.Mutex
+	voting        map[string][]models.DigestItem
+	dryRunDigests map[string][]models.DigestItem
+
+	// Notifications held during quiet hours in dry-run mode
+	heldMu     sync.Mutex
+	dryRunHeld map[string][]storage.HeldNotification
+
+	// Keys of the operational problems reported to the alerts audience
+	alertMu sync.Mutex
+	alerts  map[string]bool
+
+	// Networks whose denomination metadata has been looked up
+	denomMu       sync.Mutex
+	denomResolved map[string]bool
+
+	// In dry-run mode processed proposal IDs are only kept in memory
+	dryRunMu        sync.Mutex
+	dryRunProcessed map[string]string
 }
 
 func NewCommsEngine(cfg *config.Config, log *zerolog.Logger) *CommsEngine {
@@ -25,23 +63,206 @@ func NewCommsEngine(cfg *config.Config, log *zerolog.Logger) *CommsEngine {
 		log:                 log,
 		notificationService: notifications.NewNotificationService(cfg, log),
 		storageService:      storage.NewStorageService(cfg, log),
+		client:              zetachain.NewClient(cfg, log),
+		mapper:              notifications.NewMapper(cfg),
+		dryRunProcessed:     make(map[string]string),
+		denomResolved:       make(map[string]bool),
+		voting:              make(map[string][]models.DigestItem),
+		dryRunDigests:       make(map[string][]models.DigestItem),
+		dryRunHeld:          make(map[string][]storage.HeldNotification),
+		alerts:              make(map[string]bool),
 	}
 }
 
+// SetClient replaces the client used to query the networks, e.g. with a fake in tests
+func (e *CommsEngine) SetClient(client zetachain.Client) {
+	e.client = client
+}
+
+// SetNotifier replaces the notifier of a platform, e.g. with a fake in tests
+func (e *CommsEngine) SetNotifier(notifier notifiers.Notifier) {
+	e.notificationService.SetNotifier(notifier)
+}
+
+// Ready implements the server.ReadinessCheck interface
+func (e *CommsEngine) Ready() error {
+	return e.notificationService.Ready()
+}
+
 func (e *CommsEngine) ProcessBroadcastMessage(msgs <-chan models.BroadcastMessage) {
 	for msg := range msgs {
-		e.log.Info().Msgf("Processing broadcast message from %s: %s", msg.Username, msg.Message)
-
-		// Notify all configured audiences
-		for audience := range e.config.AudienceConfig {
-			e.notificationService.Notify(
-				notifications.Notification{
-					Summary: msg.Message,
-				},
-				audience,
-			)
-		}
+		e.Broadcast(msg)
+	}
+}
+
+// Broadcast sends a message to all configured audiences
+func (e *CommsEngine) Broadcast(msg models.BroadcastMessage) []models.Delivery {
+	e.log.Info().Msgf("Processing broadcast message from %s: %s", msg.Username, msg.Message)
+	metrics.Broadcasts.Inc()
+
+	var deliveries []models.Delivery
+
+	// Notify all configured audiences
+	for audience := range e.config.AudienceConfig {
+		deliveries = append(deliveries, e.notificationService.Notify(
+			notifications.Notification{
+				EventType: models.EventBroadcast,
+				Summary:   msg.Message,
+			},
+			audience,
+		)...)
+	}
+
+	return deliveries
+}
+
+// ListProposals returns the proposals tracked for a network
+func (e *CommsEngine) ListProposals(network string) ([]storage.ProposalRecord, error) {
+	if _, ok := e.config.Networks[network]; !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownNetwork, network)
+	}
+
+	return e.storageService.GetProposals(network)
+}
+
+// GetProposal returns a tracked proposal with its delivery history, or nil if it is not tracked
+func (e *CommsEngine) GetProposal(network string, proposalID string) (*storage.ProposalRecord, error) {
+	if _, ok := e.config.Networks[network]; !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownNetwork, network)
+	}
+
+	return e.storageService.GetProposal(network, proposalID)
+}
+
+// ResendProposal fetches a proposal from the chain and sends it to an audience again,
+// regardless of whether it has been processed before
+func (e *CommsEngine) ResendProposal(network string, proposalID string, audience string) ([]models.Delivery, error) {
+	if _, ok := e.config.AudienceConfig[audience]; !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAudience, audience)
+	}
+
+	return e.resendProposal(network, proposalID, []string{audience})
+}
+
+// ReplayProposal fetches a proposal from the chain and sends it again to all audiences of the network
+func (e *CommsEngine) ReplayProposal(network string, proposalID string) ([]models.Delivery, error) {
+	networkConfig, ok := e.config.Networks[network]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownNetwork, network)
+	}
+
+	return e.resendProposal(network, proposalID, networkConfig.Audiences)
+}
+
+// TestNotify sends a synthetic notification to an audience to verify channel credentials
+func (e *CommsEngine) TestNotify(audience string) ([]models.Delivery, error) {
+	if _, ok := e.config.AudienceConfig[audience]; !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAudience, audience)
+	}
+
+	e.log.Info().Str("audience", audience).Msg("Sending test notification")
+
+	notification := notifications.Notification{
+		EventType:  models.EventProposal,
+		Network:    "test",
+		ProposalId: "0",
+		Title:      "Test notification",
+		Summary:    fmt.Sprintf("This is a test notification from zeta-comms for the %s audience. No action is required.", audience),
+		Status:     "PROPOSAL_STATUS_VOTING_PERIOD",
 	}
+
+	return e.notificationService.Notify(notification, audience), nil
+}
+
+func (e *CommsEngine) resendProposal(network string, proposalID string, audiences []string) ([]models.Delivery, error) {
+	if _, ok := e.config.Networks[network]; !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownNetwork, network)
+	}
+
+	proposal, err := e.client.GetProposal(network, proposalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch proposal %s: %w", proposalID, err)
+	}
+
+	notification := e.mapProposal(network, *proposal)
+
+	var deliveries []models.Delivery
+
+	for _, audience := range audiences {
+		e.log.Info().
+			Str("network", network).
+			Str("proposal_id", proposalID).
+			Str("audience", audience).
+			Msg("Re-sending proposal")
+
+		deliveries = append(deliveries, e.notificationService.Notify(notification, audience)...)
+	}
+
+	for i := range deliveries {
+		deliveries[i].Manual = true
+	}
+
+	e.recordProposal(network, *proposal, deliveries)
+
+	return deliveries, nil
+}
+
+// mapProposal maps a proposal to a notification, including the links configured for the network
+func (e *CommsEngine) mapProposal(network string, proposal zetachain.Proposal) models.Notification {
+	links := e.config.Networks[network].Links
+
+	e.resolveDenom(network)
+
+	notification, err := e.mapper.Map(network, proposal)
+	if err != nil {
+		e.log.Warn().Err(err).Str("network", network).Str("proposal_id", proposal.ProposalId).Msg("Proposal contains invalid amounts or messages")
+	}
+
+	if err := notifications.CompareParams(e.client, network, proposal, notification.Messages); err != nil {
+		e.log.Warn().Err(err).Str("network", network).Str("proposal_id", proposal.ProposalId).Msg("Failed to compare the proposed params with the chain")
+	}
+
+	notification.ProposalURL = notifications.ProposalLink(links.Explorer, proposal.ProposalId)
+	notification.VoteURL = notifications.ProposalLink(links.Governance, proposal.ProposalId)
+
+	return notification
+}
+
+// resolveDenom fetches the denomination metadata of a network from the chain the first time it
+// is needed, if enabled. The configured denomination is kept if the lookup fails, and the lookup
+// is tried again for the next proposal if the chain could not be queried.
+func (e *CommsEngine) resolveDenom(network string) {
+	e.denomMu.Lock()
+	resolved := e.denomResolved[network]
+	e.denomMu.Unlock()
+
+	denom := e.config.Networks[network].Denom.WithDefaults()
+	if resolved || !denom.FetchMetadata {
+		return
+	}
+
+	log := e.log.With().Str("network", network).Str("denom", denom.Base).Logger()
+
+	metadatas, err := e.client.GetDenomsMetadata(network)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to fetch denomination metadata, using the configured denomination")
+
+		return
+	}
+
+	e.denomMu.Lock()
+	e.denomResolved[network] = true
+	e.denomMu.Unlock()
+
+	fetched, err := notifications.DenomFromMetadata(denom, metadatas)
+	if err != nil {
+		log.Warn().Err(err).Msg("Using the configured denomination")
+
+		return
+	}
+
+	log.Info().Str("display", fetched.Display).Int("exponent", fetched.Exponent).Msg("Using denomination metadata from the chain")
+	e.mapper.SetDenom(network, fetched)
 }
 
 // ProcessProposalUpdates handles the proposal updates from the channel
@@ -52,19 +273,35 @@ func (e *CommsEngine) ProcessProposalUpdates(network string, updateCh <-chan eve
 		if update.Error != nil {
 			log.Error().Err(update.Error).Msg("Error fetching proposals")
 
+			if errors.Is(update.Error, zetachain.ErrEndpointsUnavailable) {
+				e.endpointsAlert(network, update.Error)
+			}
+
 			continue
 		}
 
-		e.handleProposals(network, update.Proposals)
+		e.endpointsAlert(network, nil)
+		e.handleProposals(network, update.Proposals, update.Partial)
 	}
 
 	log.Debug().Msg("Proposal update channel closed")
 }
 
-func (e *CommsEngine) handleProposals(network string, proposals []zetachain.Proposal) {
+// handleProposals notifies about new proposals. partial is set if proposals only holds the
+// proposals affected by an event rather than all proposals of the network.
+func (e *CommsEngine) handleProposals(network string, proposals []zetachain.Proposal, partial bool) {
 	log := e.log.With().Str("network", network).Logger()
 	log.Trace().Msgf("Handling %d proposals for network: %s", len(proposals), network)
 
+	e.observeUpgrades(network, proposals)
+
+	realtimeAudiences, digestAudiences := e.digestAudiences(network)
+	if len(digestAudiences) > 0 {
+		e.observeVoting(network, proposals, partial)
+	}
+
+	e.recordStatusChanges(network, proposals, digestAudiences)
+
 	for _, proposal := range proposals {
 		isNew := e.isNewProposal(network, proposal.ProposalId)
 		if !isNew {
@@ -74,20 +311,31 @@ func (e *CommsEngine) handleProposals(network string, proposals []zetachain.Prop
 		}
 
 		log.Info().Str("proposal_id", proposal.ProposalId).Msg("Processing new proposal")
+		metrics.ProposalsSeen.WithLabelValues(network).Inc()
+
+		notification := e.mapProposal(network, proposal)
+		e.classify(network, &notification)
 
-		notification := notifications.MapFromProposal(network, proposal)
+		var deliveries []models.Delivery
 
-		audiences := e.config.Networks[network].Audiences
-		for _, audience := range audiences {
-			e.notificationService.Notify(notification, audience)
+		for _, audience := range realtimeAudiences {
+			deliveries = append(deliveries, e.deliver(notification, audience)...)
 		}
 
+		if len(digestAudiences) > 0 {
+			item := e.newDigestItem(models.DigestNewProposal, network, proposal)
+			for _, audience := range digestAudiences {
+				e.queueDigestItem(audience, item)
+			}
+		}
+
+		e.recordProposal(network, proposal, deliveries)
 		e.storeLastProcessedProposalID(network, proposal.ProposalId)
 	}
 }
 
 func (e *CommsEngine) isNewProposal(network string, proposalId string) bool {
-	lastProcessedID, err := e.storageService.GetLastProcessedProposalID(network)
+	lastProcessedID, err := e.getLastProcessedProposalID(network)
 	if err != nil {
 		e.log.Error().Err(err).Msg("Error getting last processed proposal ID")
 
@@ -99,22 +347,76 @@ func (e *CommsEngine) isNewProposal(network string, proposalId string) bool {
 		return true
 	}
 
+	return IsNewerProposalID(proposalId, lastProcessedID)
+}
+
+// IsNewerProposalID reports whether proposalID comes after lastProcessedID. IDs are compared
+// numerically, falling back to a plain inequality check if they are not numbers.
+func IsNewerProposalID(proposalID string, lastProcessedID string) bool {
 	// Convert strings to integers for comparison
 	lastProcessedInt, err1 := strconv.ParseInt(lastProcessedID, 10, 64)
-	proposalInt, err2 := strconv.ParseInt(proposalId, 10, 64)
+	proposalInt, err2 := strconv.ParseInt(proposalID, 10, 64)
 
 	if err1 != nil || err2 != nil {
 		// If conversion fails, fall back to string comparison
-		e.log.Warn().Msg("Failed to convert proposal IDs to integers, falling back to string comparison")
-
-		return proposalId != lastProcessedID
+		return proposalID != lastProcessedID
 	}
 
 	// Check if proposal ID is greater than last processed
 	return proposalInt > lastProcessedInt
 }
 
+// recordProposal stores the proposal and the outcome of its deliveries for the admin API
+func (e *CommsEngine) recordProposal(network string, proposal zetachain.Proposal, deliveries []models.Delivery) {
+	if e.config.DryRun.Enabled {
+		return
+	}
+
+	if err := e.storageService.RecordProposal(network, proposal.ProposalId, proposal.Title, proposal.Status); err != nil {
+		e.log.Error().Err(err).Msg("Error recording proposal")
+
+		return
+	}
+
+	e.recordDeliveries(network, proposal.ProposalId, deliveries)
+}
+
+// recordDeliveries stores the outcome of deliveries of a tracked proposal
+func (e *CommsEngine) recordDeliveries(network string, proposalID string, deliveries []models.Delivery) {
+	if e.config.DryRun.Enabled || len(deliveries) == 0 {
+		return
+	}
+
+	if err := e.storageService.RecordDeliveries(network, proposalID, deliveries); err != nil {
+		e.log.Error().Err(err).Msg("Error recording deliveries")
+	}
+}
+
+// getLastProcessedProposalID returns the last processed proposal ID, preferring the
+// in-memory value tracked in dry-run mode over the stored one
+func (e *CommsEngine) getLastProcessedProposalID(network string) (string, error) {
+	if e.config.DryRun.Enabled {
+		e.dryRunMu.Lock()
+		lastProcessedID, ok := e.dryRunProcessed[network]
+		e.dryRunMu.Unlock()
+
+		if ok {
+			return lastProcessedID, nil
+		}
+	}
+
+	return e.storageService.GetLastProcessedProposalID(network)
+}
+
 func (e *CommsEngine) storeLastProcessedProposalID(network string, proposalId string) {
+	if e.config.DryRun.Enabled {
+		e.dryRunMu.Lock()
+		e.dryRunProcessed[network] = proposalId
+		e.dryRunMu.Unlock()
+
+		return
+	}
+
 	err := e.storageService.StoreLastProcessedProposalID(network, proposalId)
 	if err != nil {
 		e.log.Error().Err(err).Msg("Error storing last processed proposal ID")
@@ -0,0 +1,233 @@
+package comms_test
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rs/zerolog"
+
+	"github.com/hazim1093/zeta-comms/internal/comms"
+	"github.com/hazim1093/zeta-comms/internal/config"
+	"github.com/hazim1093/zeta-comms/internal/events"
+	"github.com/hazim1093/zeta-comms/pkg/models"
+	"github.com/hazim1093/zeta-comms/pkg/notifiers"
+	"github.com/hazim1093/zeta-comms/pkg/zetachain"
+)
+
+func TestComms(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Comms Suite")
+}
+
+// fakeNotifier records the notifications it sends, or fails with err if set
+type fakeNotifier struct {
+	mu   sync.Mutex
+	name string
+	err  error
+	sent []models.Notification
+}
+
+func (f *fakeNotifier) Send(_ string, notification models.Notification, _ notifiers.RenderOptions) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.err != nil {
+		return f.err
+	}
+
+	f.sent = append(f.sent, notification)
+
+	return nil
+}
+
+func (f *fakeNotifier) Name() string {
+	return f.name
+}
+
+func (f *fakeNotifier) setErr(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.err = err
+}
+
+func (f *fakeNotifier) titles() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	titles := make([]string, 0, len(f.sent))
+	for _, notification := range f.sent {
+		titles = append(titles, notification.Title)
+	}
+
+	return titles
+}
+
+// newEngine returns an engine that stores its state in a temporary file and sends to fake
+// Discord and Slack notifiers
+func newEngine(cfg *config.Config, client zetachain.Client) (*comms.CommsEngine, *fakeNotifier, *fakeNotifier) {
+	cfg.Storage.Filename = filepath.Join(GinkgoT().TempDir(), "state.yaml")
+
+	logger := zerolog.Nop()
+	engine := comms.NewCommsEngine(cfg, &logger)
+	engine.SetClient(client)
+
+	discord := &fakeNotifier{name: "discord"}
+	slack := &fakeNotifier{name: "slack"}
+	engine.SetNotifier(discord)
+	engine.SetNotifier(slack)
+
+	return engine, discord, slack
+}
+
+// newConfig returns a config with a mainnet network notifying an operators audience on Discord and Slack
+func newConfig() *config.Config {
+	return &config.Config{
+		Networks: map[string]config.Network{
+			"mainnet": {Audiences: []string{"operators"}},
+		},
+		AudienceConfig: map[string]config.Audience{
+			"operators": {Channels: map[string][]string{
+				"discord": {"123"},
+				"slack":   {"https://hooks.slack.com/services/T000/B000/XXXX"},
+			}},
+		},
+	}
+}
+
+var _ = Describe("CommsEngine", func() {
+	var (
+		client  *zetachain.FakeClient
+		engine  *comms.CommsEngine
+		discord *fakeNotifier
+	)
+
+	BeforeEach(func() {
+		client = zetachain.NewFakeClient()
+		client.SetProposals("mainnet", zetachain.Proposal{
+			ProposalId: "7",
+			Title:      "Upgrade to v30",
+			Status:     "PROPOSAL_STATUS_VOTING_PERIOD",
+		})
+
+		engine, discord, _ = newEngine(newConfig(), client)
+	})
+
+	It("should replay a proposal to all audiences of the network and record the deliveries", func() {
+		deliveries, err := engine.ReplayProposal("mainnet", "7")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deliveries).To(HaveLen(2))
+		Expect(deliveries[0].Manual).To(BeTrue())
+		Expect(discord.titles()).To(Equal([]string{"Upgrade to v30"}))
+
+		record, err := engine.GetProposal("mainnet", "7")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(record.Deliveries).To(HaveLen(2))
+	})
+
+	It("should record status changes of proposals for realtime audiences", func() {
+		proposal := zetachain.Proposal{ProposalId: "7", Title: "Upgrade to v30", Status: "PROPOSAL_STATUS_VOTING_PERIOD"}
+		process(engine, "mainnet", proposal)
+
+		proposal.Status = "PROPOSAL_STATUS_PASSED"
+		process(engine, "mainnet", proposal)
+
+		record, err := engine.GetProposal("mainnet", "7")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(record.Status).To(Equal("PROPOSAL_STATUS_PASSED"))
+		Expect(discord.titles()).To(Equal([]string{"Upgrade to v30"}))
+	})
+
+	It("should not replay unknown proposals or networks", func() {
+		deliveries, err := engine.ReplayProposal("mainnet", "8")
+		Expect(err).To(MatchError(ContainSubstring("failed to fetch proposal 8")))
+		Expect(deliveries).To(BeEmpty())
+
+		_, err = engine.ReplayProposal("devnet", "7")
+		Expect(errors.Is(err, comms.ErrUnknownNetwork)).To(BeTrue())
+
+		Expect(discord.titles()).To(BeEmpty())
+
+		record, err := engine.GetProposal("mainnet", "8")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(record).To(BeNil())
+	})
+
+	It("should send test notifications only to configured audiences", func() {
+		deliveries, err := engine.TestNotify("operators")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deliveries).To(HaveLen(2))
+		Expect(discord.titles()).To(Equal([]string{"Test notification"}))
+
+		deliveries, err = engine.TestNotify("developers")
+		Expect(errors.Is(err, comms.ErrUnknownAudience)).To(BeTrue())
+		Expect(deliveries).To(BeEmpty())
+		Expect(discord.titles()).To(HaveLen(1))
+	})
+
+	It("should look up the denomination metadata again after a failed query", func() {
+		cfg := newConfig()
+		cfg.Networks["mainnet"] = config.Network{
+			Audiences: []string{"operators"},
+			Denom:     config.Denom{Base: "uatom", Display: "uatom", FetchMetadata: true},
+		}
+
+		client.Err = errors.New("connection refused")
+		client.DenomsMetadata = []zetachain.DenomMetadata{
+			{Base: "uatom", Display: "atom", DenomUnits: []zetachain.DenomUnit{{Denom: "atom", Exponent: 6}}},
+		}
+
+		engine, discord, _ = newEngine(cfg, client)
+
+		deposit := []zetachain.Deposit{{Denom: "uatom", Amount: "250000000"}}
+		process(engine, "mainnet", zetachain.Proposal{ProposalId: "7", Status: "PROPOSAL_STATUS_PASSED", TotalDeposit: deposit})
+
+		client.Err = nil
+		process(engine, "mainnet", zetachain.Proposal{ProposalId: "8", Status: "PROPOSAL_STATUS_PASSED", TotalDeposit: deposit})
+		process(engine, "mainnet", zetachain.Proposal{ProposalId: "9", Status: "PROPOSAL_STATUS_PASSED", TotalDeposit: deposit})
+
+		Expect(client.Calls["GetDenomsMetadata"]).To(Equal(2))
+		Expect(discord.sent).To(HaveLen(3))
+		Expect(discord.sent[0].TotalDeposit[0].Denom).To(Equal("uatom"))
+		Expect(discord.sent[1].TotalDeposit[0]).To(Equal(zetachain.Deposit{Denom: "atom", Amount: "250"}))
+	})
+
+	It("should raise an endpoints alert for the transport of the network until it is delivered", func() {
+		cfg := newConfig()
+		cfg.Networks["mainnet"] = config.Network{Audiences: []string{"operators"}, Transport: config.TransportGRPC}
+		cfg.Alerts.Audience = "operators"
+
+		var slack *fakeNotifier
+		engine, discord, slack = newEngine(cfg, client)
+
+		unavailable := func() {
+			updateCh := make(chan events.ProposalUpdate, 1)
+			updateCh <- events.ProposalUpdate{Error: fmt.Errorf("node down: %w", zetachain.ErrEndpointsUnavailable)}
+			close(updateCh)
+
+			engine.ProcessProposalUpdates("mainnet", updateCh)
+		}
+
+		discord.setErr(errors.New("discord unavailable"))
+		slack.setErr(errors.New("slack unavailable"))
+		unavailable()
+		Expect(discord.titles()).To(BeEmpty())
+
+		// Sent again on the next failed poll, as no channel got the alert
+		discord.setErr(nil)
+		unavailable()
+		Expect(discord.titles()).To(Equal([]string{"⚠️ All gRPC endpoints are unavailable"}))
+
+		// Reported once while the problem lasts
+		unavailable()
+		Expect(discord.titles()).To(HaveLen(1))
+
+		process(engine, "mainnet")
+		Expect(discord.titles()).To(Equal([]string{"⚠️ All gRPC endpoints are unavailable", "✅ gRPC endpoints are available again"}))
+	})
+})
@@ -0,0 +1,45 @@
+package comms
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hazim1093/zeta-comms/internal/events"
+)
+
+// SetChainMonitor hands the engine the monitor of the networks' block production, which learns about
+// upgrades from the passed proposals the engine handles
+func (e *CommsEngine) SetChainMonitor(monitor *events.ChainMonitor) {
+	e.chainMonitor = monitor
+}
+
+// ProcessChainReports reports a halted network to the alerts audience, and the downtime once it
+// produces blocks again
+func (e *CommsEngine) ProcessChainReports(network string, reportsCh <-chan events.ChainReport) {
+	for report := range reportsCh {
+		e.haltAlert(network, report)
+	}
+
+	e.log.Debug().Str("network", network).Msg("Chain report channel closed")
+}
+
+func (e *CommsEngine) haltAlert(network string, report events.ChainReport) {
+	key := "halt/" + network
+	since := report.Since.UTC().Format("2006-01-02 15:04:05 MST")
+
+	switch {
+	case report.Halted && report.Upgrade != nil:
+		e.raiseAlert(key, network,
+			fmt.Sprintf("Chain halted for upgrade %s", report.Upgrade.Name),
+			fmt.Sprintf("No block since height %d at %s. This is the expected halt for upgrade %s at height %d, "+
+				"blocks are produced again once enough validators run the new version.",
+				report.Height, since, report.Upgrade.Name, report.Upgrade.Height))
+	case report.Halted:
+		e.raiseAlert(key, network,
+			"Chain stopped producing blocks",
+			fmt.Sprintf("No block since height %d at %s. No upgrade is scheduled at this height.", report.Height, since))
+	case report.Resumed:
+		e.resolveAlert(key, network,
+			fmt.Sprintf("Chain resumed at height %d after %s of downtime", report.Height, report.Downtime.Round(time.Second)))
+	}
+}
@@ -0,0 +1,78 @@
+package comms
+
+import (
+	"fmt"
+
+	"github.com/hazim1093/zeta-comms/internal/events"
+	"github.com/hazim1093/zeta-comms/internal/notifications"
+	"github.com/hazim1093/zeta-comms/pkg/zetachain"
+)
+
+// nodeResolved holds the titles of the alerts sent when a node problem is over, by kind of problem
+var nodeResolved = map[string]string{
+	events.NodeUnreachable: "Node %s is reachable again",
+	events.NodeBehind:      "Node %s caught up with the network",
+	events.NodeStalled:     "Node %s is syncing again",
+	events.NodeOutdated:    "Node %s runs the upgraded version",
+}
+
+// SetNodeMonitor hands the engine the monitor of the networks' nodes, which learns about upgrades
+// from the passed proposals the engine handles
+func (e *CommsEngine) SetNodeMonitor(monitor *events.NodeMonitor) {
+	e.nodeMonitor = monitor
+}
+
+// ProcessNodeReports reports the problems of the monitored nodes of a network to the alerts audience,
+// and that they are over once a later check no longer finds them
+func (e *CommsEngine) ProcessNodeReports(network string, reportsCh <-chan []events.NodeReport) {
+	for reports := range reportsCh {
+		for _, report := range reports {
+			e.nodeAlerts(network, report)
+		}
+	}
+
+	e.log.Debug().Str("network", network).Msg("Node report channel closed")
+}
+
+func (e *CommsEngine) nodeAlerts(network string, report events.NodeReport) {
+	problems := make(map[string]events.NodeProblem, len(report.Problems))
+	for _, problem := range report.Problems {
+		problems[problem.Kind] = problem
+	}
+
+	for _, kind := range events.NodeProblemKinds {
+		key := fmt.Sprintf("node/%s/%s/%s", network, report.Node, kind)
+
+		// An unreachable node may have any other problem, which is only known once it answers again
+		if _, unreachable := problems[events.NodeUnreachable]; unreachable && kind != events.NodeUnreachable {
+			continue
+		}
+
+		if problem, ok := problems[kind]; ok {
+			e.raiseAlert(key, network, problem.Title, problem.Details)
+
+			continue
+		}
+
+		e.resolveAlert(key, network, fmt.Sprintf(nodeResolved[kind], report.Node))
+	}
+}
+
+// observeUpgrades tells the node and chain monitors about the upgrades scheduled by passed proposals
+func (e *CommsEngine) observeUpgrades(network string, proposals []zetachain.Proposal) {
+	for _, proposal := range proposals {
+		if proposal.Status != "PROPOSAL_STATUS_PASSED" {
+			continue
+		}
+
+		name, height := notifications.UpgradePlan(proposal)
+
+		if e.nodeMonitor != nil {
+			e.nodeMonitor.ObserveUpgrade(network, name, height)
+		}
+
+		if e.chainMonitor != nil {
+			e.chainMonitor.ObserveUpgrade(network, name, height)
+		}
+	}
+}
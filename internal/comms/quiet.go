@@ -0,0 +1,201 @@
+package comms
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/hazim1093/zeta-comms/internal/notifications"
+	"github.com/hazim1093/zeta-comms/internal/storage"
+	"github.com/hazim1093/zeta-comms/pkg/models"
+	"github.com/hazim1093/zeta-comms/pkg/zetachain"
+)
+
+// quietHoursInterval is how often held notifications are checked for release
+const quietHoursInterval = time.Minute
+
+// StartQuietHours releases the notifications held during quiet hours once they are over, until ctx is done
+func (e *CommsEngine) StartQuietHours(ctx context.Context) {
+	enabled := false
+	for _, audienceConfig := range e.config.AudienceConfig {
+		enabled = enabled || audienceConfig.QuietHours.Enabled()
+	}
+
+	if !enabled {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(quietHoursInterval)
+		defer ticker.Stop()
+
+		for {
+			e.ReleaseHeld(time.Now())
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// ReleaseHeld sends the notifications held for audiences whose quiet hours are over at now.
+// Notifications stay held for the channels they could not be delivered to, and are sent to them
+// again on the next call.
+func (e *CommsEngine) ReleaseHeld(now time.Time) []models.Delivery {
+	var deliveries []models.Delivery
+
+	for audience, audienceConfig := range e.config.AudienceConfig {
+		if !audienceConfig.QuietHours.Enabled() {
+			continue
+		}
+
+		if _, quiet := e.notificationService.HeldUntil(notifications.Notification{}, audience, now); quiet {
+			continue
+		}
+
+		held, err := e.heldNotifications(audience)
+		if err != nil {
+			e.log.Error().Err(err).Str("audience", audience).Msg("Error loading held notifications")
+
+			continue
+		}
+
+		if len(held) == 0 {
+			continue
+		}
+
+		var kept []storage.HeldNotification
+
+		for _, heldNotification := range held {
+			notification := heldNotification.Notification
+
+			log := e.log.With().
+				Str("audience", audience).
+				Str("network", notification.Network).
+				Str("proposal_id", notification.ProposalId).
+				Logger()
+
+			var sent []models.Delivery
+
+			if len(heldNotification.Failed) > 0 {
+				log.Info().Msg("Retrying held notification on the channels it could not be delivered to")
+
+				sent = e.notificationService.Retry(notification, audience, heldNotification.Failed)
+			} else {
+				log.Info().Msg("Quiet hours are over, sending held notification")
+
+				sent = e.notificationService.Notify(notification, audience)
+			}
+
+			e.recordDeliveries(notification.Network, notification.ProposalId, sent)
+			deliveries = append(deliveries, sent...)
+
+			var failed []models.Delivery
+
+			for _, delivery := range sent {
+				if !delivery.Succeeded() {
+					failed = append(failed, delivery)
+				}
+			}
+
+			if len(failed) > 0 {
+				kept = append(kept, storage.HeldNotification{Notification: notification, Failed: failed})
+			}
+		}
+
+		e.releaseHeld(audience, len(held), kept)
+	}
+
+	return deliveries
+}
+
+// deliver sends a notification to an audience, or holds it back if the audience is in its quiet
+// hours and the notification is not critical
+func (e *CommsEngine) deliver(notification models.Notification, audience string) []models.Delivery {
+	until, held := e.notificationService.HeldUntil(notification, audience, time.Now())
+	if !held {
+		return e.notificationService.Notify(notification, audience)
+	}
+
+	e.log.Info().
+		Str("audience", audience).
+		Str("network", notification.Network).
+		Str("proposal_id", notification.ProposalId).
+		Time("until", until).
+		Msg("Holding notification during quiet hours")
+
+	if e.config.DryRun.Enabled {
+		e.heldMu.Lock()
+		defer e.heldMu.Unlock()
+
+		e.dryRunHeld[audience] = append(e.dryRunHeld[audience], storage.HeldNotification{Notification: notification})
+
+		return nil
+	}
+
+	if err := e.storageService.HoldNotification(audience, notification); err != nil {
+		e.log.Error().Err(err).Str("audience", audience).Msg("Error storing held notification, sending it now")
+
+		return e.notificationService.Notify(notification, audience)
+	}
+
+	return nil
+}
+
+// heldNotifications returns the notifications held for an audience, kept in memory during a dry run
+func (e *CommsEngine) heldNotifications(audience string) ([]storage.HeldNotification, error) {
+	if e.config.DryRun.Enabled {
+		e.heldMu.Lock()
+		defer e.heldMu.Unlock()
+
+		return append([]storage.HeldNotification(nil), e.dryRunHeld[audience]...), nil
+	}
+
+	return e.storageService.GetHeld(audience)
+}
+
+// releaseHeld removes the first released notifications held for an audience and holds kept again
+func (e *CommsEngine) releaseHeld(audience string, released int, kept []storage.HeldNotification) {
+	if e.config.DryRun.Enabled {
+		e.heldMu.Lock()
+		defer e.heldMu.Unlock()
+
+		held := e.dryRunHeld[audience]
+		e.dryRunHeld[audience] = slices.Concat(kept, held[min(released, len(held)):])
+
+		return
+	}
+
+	if err := e.storageService.ReleaseHeld(audience, released, kept); err != nil {
+		e.log.Error().Err(err).Str("audience", audience).Msg("Error removing released notifications, they may be sent again")
+	}
+}
+
+// classify sets the severity of a proposal notification according to the rules of its network.
+// The latest block is only fetched for upgrades, to estimate when they happen.
+func (e *CommsEngine) classify(network string, notification *models.Notification) {
+	rules := e.config.Networks[network].Severity
+
+	var latest *zetachain.BlockHeader
+	if notification.UpgradeName != "" && rules.CriticalUpgradeWithin > 0 {
+		var err error
+
+		latest, err = e.client.GetLatestBlock(network)
+		if err != nil {
+			e.log.Warn().Err(err).Str("network", network).Msg("Failed to fetch the latest block, treating the upgrade as imminent")
+		}
+	}
+
+	notification.Severity, notification.SeverityReason = notifications.Classify(*notification, rules, latest, time.Now())
+
+	if notification.Critical() {
+		e.log.Info().
+			Str("network", network).
+			Str("proposal_id", notification.ProposalId).
+			Str("reason", notification.SeverityReason).
+			Msg("Proposal is critical")
+	}
+}
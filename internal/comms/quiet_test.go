@@ -0,0 +1,76 @@
+package comms_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/hazim1093/zeta-comms/internal/comms"
+	"github.com/hazim1093/zeta-comms/internal/config"
+	"github.com/hazim1093/zeta-comms/pkg/zetachain"
+)
+
+var _ = Describe("Quiet hours", func() {
+	var (
+		engine  *comms.CommsEngine
+		discord *fakeNotifier
+		slack   *fakeNotifier
+		end     time.Time
+	)
+
+	BeforeEach(func() {
+		// Quiet hours around now, as notifications are held based on the current time
+		now := time.Now().UTC()
+		end = now.Add(time.Hour)
+
+		cfg := newConfig()
+		cfg.Networks["mainnet"] = config.Network{
+			Audiences: []string{"operators"},
+			Severity:  config.Severity{CriticalExpedited: true},
+		}
+
+		operators := cfg.AudienceConfig["operators"]
+		operators.QuietHours = config.QuietHours{Start: now.Add(-time.Hour).Format("15:04"), End: end.Format("15:04"), Timezone: "UTC"}
+		cfg.AudienceConfig["operators"] = operators
+
+		engine, discord, slack = newEngine(cfg, zetachain.NewFakeClient())
+	})
+
+	It("should hold notifications until the quiet hours are over and retry only the failed channels", func() {
+		process(engine, "mainnet", zetachain.Proposal{ProposalId: "7", Title: "Upgrade to v30", Status: "PROPOSAL_STATUS_VOTING_PERIOD"})
+
+		Expect(discord.titles()).To(BeEmpty())
+		Expect(slack.titles()).To(BeEmpty())
+		Expect(engine.ReleaseHeld(end.Add(-time.Minute))).To(BeEmpty())
+
+		discord.setErr(errors.New("discord is down"))
+
+		deliveries := engine.ReleaseHeld(end.Add(time.Minute))
+		Expect(deliveries).To(HaveLen(2))
+		Expect(discord.titles()).To(BeEmpty())
+		Expect(slack.titles()).To(Equal([]string{"Upgrade to v30"}))
+
+		discord.setErr(nil)
+
+		deliveries = engine.ReleaseHeld(end.Add(2 * time.Minute))
+		Expect(deliveries).To(HaveLen(1))
+		Expect(deliveries[0].Platform).To(Equal("discord"))
+		Expect(discord.titles()).To(Equal([]string{"Upgrade to v30"}))
+		Expect(slack.titles()).To(HaveLen(1))
+
+		Expect(engine.ReleaseHeld(end.Add(3 * time.Minute))).To(BeEmpty())
+
+		record, err := engine.GetProposal("mainnet", "7")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(record.Deliveries).To(HaveLen(3))
+	})
+
+	It("should not hold critical notifications", func() {
+		process(engine, "mainnet", zetachain.Proposal{ProposalId: "7", Title: "Emergency fix", Status: "PROPOSAL_STATUS_VOTING_PERIOD", Expedited: true})
+
+		Expect(discord.titles()).To(Equal([]string{"Emergency fix"}))
+		Expect(engine.ReleaseHeld(end.Add(time.Minute))).To(BeEmpty())
+	})
+})
@@ -14,15 +14,9 @@ import (
 )
 
 type Config struct {
-	Networks map[string]struct {
-		ApiUrl       url.URL       `mapstructure:"api_url"`
-		PollInterval time.Duration `mapstructure:"poll_interval"`
-		Audiences    []string      `mapstructure:"audiences"`
-	} `mapstructure:"networks"`
+	Networks map[string]Network `mapstructure:"networks"`
 
-	AudienceConfig map[string]struct {
-		Channels map[string][]string `mapstructure:"channels"`
-	} `mapstructure:"audience_config"`
+	AudienceConfig map[string]Audience `mapstructure:"audience_config"`
 
 	Events struct {
 		Proposals struct {
@@ -34,14 +28,34 @@ type Config struct {
 
 	Notifiers struct {
 		Discord struct {
-			BotToken string `mapstructure:"bot_token"`
+			BotToken string   `mapstructure:"bot_token"`
+			Overflow Overflow `mapstructure:"overflow"`
 		} `mapstructure:"discord"`
 
+		Slack struct {
+			Overflow Overflow `mapstructure:"overflow"`
+		} `mapstructure:"slack"`
+
 		Telegram struct {
-			BotToken string `mapstructure:"bot_token"`
+			BotToken string   `mapstructure:"bot_token"`
+			Overflow Overflow `mapstructure:"overflow"`
 		} `mapstructure:"telegram"`
 	} `mapstructure:"notifiers"`
 
+	Display Display `mapstructure:"display"`
+
+	Alerts struct {
+		Audience string `mapstructure:"audience"` // Audience notified about operational problems, empty disables alerts
+	} `mapstructure:"alerts"`
+
+	// Templates overrides the default message templates, keyed by platform and event type
+	Templates map[string]map[string]TemplateSource `mapstructure:"templates"`
+
+	DryRun struct {
+		Enabled   bool   `mapstructure:"enabled"`
+		OutputDir string `mapstructure:"output_dir"` // Empty writes rendered notifications to the log
+	} `mapstructure:"dry_run"`
+
 	Storage struct {
 		Filename string `mapstructure:"filename"`
 	} `mapstructure:"storage"`
@@ -50,6 +64,293 @@ type Config struct {
 		Format string
 		Level  string
 	}
+
+	Server struct {
+		ListenAddress      string `mapstructure:"listen_address"`      // Empty disables the HTTP server
+		ReadinessIntervals int    `mapstructure:"readiness_intervals"` // Poll intervals without a successful poll before a network is not ready
+
+		Admin struct {
+			Token string `mapstructure:"token"` // Bearer token for the admin API, empty disables it
+		} `mapstructure:"admin"`
+	} `mapstructure:"server"`
+
+	// Sources lists the config files that were loaded, in merge order
+	Sources []string `mapstructure:"-"`
+}
+
+// Network holds the settings of a single monitored chain
+type Network struct {
+	ApiUrl       url.URL       `mapstructure:"api_url"`
+	ApiUrls      []url.URL     `mapstructure:"api_urls"` // Further LCD endpoints to fail over to
+	Failover     Failover      `mapstructure:"failover"`
+	Source       Source        `mapstructure:"source"`
+	Transport    string        `mapstructure:"transport"` // TransportREST or TransportGRPC
+	GRPC         GRPC          `mapstructure:"grpc"`
+	Fetch        string        `mapstructure:"fetch"`       // FetchFull or FetchIncremental
+	GovVersion   string        `mapstructure:"gov_version"` // GovV1 or GovV1Beta1, empty detects the version
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+	Audiences    []string      `mapstructure:"audiences"` // Should match the keys in audience_config
+	Links        Links         `mapstructure:"links"`
+	Denom        Denom         `mapstructure:"denom"`
+	Severity     Severity      `mapstructure:"severity"`
+	Monitoring   Monitoring    `mapstructure:"monitoring"`
+}
+
+// Endpoints returns the LCD endpoints of the network, api_url first
+func (n Network) Endpoints() []url.URL {
+	var endpoints []url.URL
+	if n.ApiUrl.Host != "" {
+		endpoints = append(endpoints, n.ApiUrl)
+	}
+
+	return append(endpoints, n.ApiUrls...)
+}
+
+// Transports to query a network
+const (
+	TransportREST = "rest" // The LCD API at api_url, the default
+	TransportGRPC = "grpc" // The gRPC endpoint of a node
+)
+
+// GRPC is the gRPC endpoint of a network
+type GRPC struct {
+	Address string `mapstructure:"address"` // host:port, e.g. grpc.example.com:9090
+	TLS     bool   `mapstructure:"tls"`
+}
+
+// Versions of the gov module API
+const (
+	GovV1      = "v1"      // Cosmos SDK 0.46 and later
+	GovV1Beta1 = "v1beta1" // Older chains, with proposal content instead of messages
+)
+
+// Modes of fetching the proposals of a network when polling
+const (
+	FetchFull        = "full"        // All proposals on every poll, the default
+	FetchIncremental = "incremental" // Only new proposals and those that were or are in the deposit or voting period
+)
+
+// Event sources of a network
+const (
+	SourcePoll      = "poll"      // Poll the LCD API every poll interval, the default
+	SourceWebsocket = "websocket" // Subscribe to CometBFT events, polling while disconnected
+)
+
+// Source selects how a network is watched for proposal changes
+type Source struct {
+	Mode   string  `mapstructure:"mode"`    // SourcePoll or SourceWebsocket
+	RPCURL url.URL `mapstructure:"rpc_url"` // CometBFT RPC endpoint of a node, for the websocket mode
+}
+
+// Websocket reports whether the network subscribes to CometBFT events
+func (s Source) Websocket() bool {
+	return s.Mode == SourceWebsocket
+}
+
+// Endpoint selection strategies of a network with several LCD endpoints
+const (
+	StrategyLatency    = "latency"     // The healthy endpoint with the lowest latency, the default
+	StrategyRoundRobin = "round_robin" // Healthy endpoints in turn
+)
+
+// Failover controls how requests are spread over the LCD endpoints of a network
+type Failover struct {
+	Strategy string        `mapstructure:"strategy"` // StrategyLatency or StrategyRoundRobin
+	Cooldown time.Duration `mapstructure:"cooldown"` // How long a failed endpoint is skipped, doubling with each further failure
+}
+
+// DefaultCooldown is how long a failed endpoint is skipped if no cooldown is configured
+const DefaultCooldown = 30 * time.Second
+
+// Severity decides which proposals of a network are critical, critical notifications bypass quiet hours
+type Severity struct {
+	CriticalExpedited     bool          `mapstructure:"critical_expedited"`      // Expedited proposals are critical
+	CriticalUpgradeWithin time.Duration `mapstructure:"critical_upgrade_within"` // Upgrades expected within this duration are critical, 0 disables
+	BlockTime             time.Duration `mapstructure:"block_time"`              // Average block time to estimate upgrade times, 0 means DefaultBlockTime
+}
+
+// Monitoring watches the nodes we operate and the block production of a network, and reports problems
+// to the alerts audience
+type Monitoring struct {
+	Nodes      []Node        `mapstructure:"nodes"`
+	Interval   time.Duration `mapstructure:"interval"`    // How often the nodes and the latest block are checked, 0 means DefaultMonitoringInterval
+	MaxLag     int64         `mapstructure:"max_lag"`     // Blocks a node may be behind the network, 0 means DefaultMaxLag
+	StallAfter time.Duration `mapstructure:"stall_after"` // Time without a new block after which the chain counts as halted, 0 disables halt detection
+}
+
+// Node is a node we operate, queried over its LCD API or, without one, its CometBFT RPC
+type Node struct {
+	Name   string  `mapstructure:"name"`
+	ApiUrl url.URL `mapstructure:"api_url"`
+	RPCURL url.URL `mapstructure:"rpc_url"`
+}
+
+const (
+	DefaultMonitoringInterval = time.Minute
+	DefaultMaxLag             = 20
+)
+
+// DefaultBlockTime is the block time assumed for networks that do not configure one
+const DefaultBlockTime = 6 * time.Second
+
+// Denom describes the staking and deposit denomination of a network. An empty base denom
+// means the ZetaChain defaults.
+type Denom struct {
+	Base          string `mapstructure:"base"`           // On-chain denomination, e.g. azeta
+	Display       string `mapstructure:"display"`        // Denomination shown in messages, e.g. ZETA
+	Exponent      int    `mapstructure:"exponent"`       // 1 display unit = 10^exponent base units
+	TallyUnit     string `mapstructure:"tally_unit"`     // Unit of vote tallies, one of TallyUnits
+	FetchMetadata bool   `mapstructure:"fetch_metadata"` // Use the display denom and exponent from the bank module
+}
+
+// DefaultDenom is the denomination of ZetaChain
+var DefaultDenom = Denom{
+	Base:      "azeta",
+	Display:   "ZETA",
+	Exponent:  18,
+	TallyUnit: "M",
+}
+
+// TallyUnits maps the supported vote tally units to their power of ten, "none" shows display units
+var TallyUnits = map[string]int{
+	"none": 0,
+	"K":    3,
+	"M":    6,
+	"B":    9,
+}
+
+// WithDefaults fills in the ZetaChain denomination if no base denom is configured,
+// and shows tallies in millions if no unit is configured
+func (d Denom) WithDefaults() Denom {
+	if d.Base == "" {
+		fetch := d.FetchMetadata
+		d = DefaultDenom
+		d.FetchMetadata = fetch
+	}
+
+	if d.Display == "" {
+		d.Display = d.Base
+	}
+
+	if d.TallyUnit == "" {
+		d.TallyUnit = DefaultDenom.TallyUnit
+	}
+
+	return d
+}
+
+// Links holds URL templates for pages about a proposal, {id} is replaced with the proposal ID
+type Links struct {
+	Explorer   string `mapstructure:"explorer"`   // e.g. https://explorer.zetachain.com/proposals/{id}
+	Governance string `mapstructure:"governance"` // Governance portal to vote in
+}
+
+// Display controls how token amounts, vote tallies and percentages are formatted
+type Display struct {
+	Precision          int    `mapstructure:"precision"`           // Decimals of deposit amounts
+	TallyPrecision     int    `mapstructure:"tally_precision"`     // Decimals of vote tallies, shown in millions
+	PercentPrecision   int    `mapstructure:"percent_precision"`   // Decimals of vote percentages
+	ThousandsSeparator string `mapstructure:"thousands_separator"` // e.g. "," or "", empty disables grouping
+}
+
+// DefaultDisplay is used for display settings that are not configured
+var DefaultDisplay = Display{
+	Precision:        2,
+	TallyPrecision:   3,
+	PercentPrecision: 2,
+}
+
+// Overflow configures how a platform handles messages over its length limit
+type Overflow struct {
+	Mode      string `mapstructure:"mode"`       // truncate (default) or split
+	MaxLength int    `mapstructure:"max_length"` // 0 uses the platform limit
+}
+
+// Audience holds the notification channels of an audience, keyed by platform
+type Audience struct {
+	Channels map[string][]string `mapstructure:"channels"`
+
+	// Templates overrides the global templates for this audience, keyed by platform and event type
+	Templates map[string]map[string]TemplateSource `mapstructure:"templates"`
+
+	Timezone   string `mapstructure:"timezone"`    // IANA name such as Europe/Berlin, empty means UTC
+	DateFormat string `mapstructure:"date_format"` // Go time layout, empty means RFC 1123
+	Language   string `mapstructure:"language"`    // Language of message labels, one of i18n.Languages
+
+	Delivery Delivery `mapstructure:"delivery"`
+
+	QuietHours QuietHours `mapstructure:"quiet_hours"`
+}
+
+// QuietHours is a daily window in which non-critical notifications are held back
+type QuietHours struct {
+	Start    string `mapstructure:"start"`    // Clock time such as 22:00
+	End      string `mapstructure:"end"`      // Clock time such as 07:00, may be before Start to span midnight
+	Timezone string `mapstructure:"timezone"` // IANA name, empty means the audience's timezone
+}
+
+// Enabled reports whether quiet hours are configured
+func (q QuietHours) Enabled() bool {
+	return q.Start != "" || q.End != ""
+}
+
+// Until reports whether t falls into the quiet hours and, if so, when they end
+func (q QuietHours) Until(t time.Time, location *time.Location) (time.Time, bool) {
+	start, errStart := time.Parse(clockLayout, q.Start)
+	end, errEnd := time.Parse(clockLayout, q.End)
+
+	if errStart != nil || errEnd != nil || start.Equal(end) {
+		return time.Time{}, false
+	}
+
+	local := t.In(location)
+	at := func(clock time.Time, days int) time.Time {
+		return time.Date(local.Year(), local.Month(), local.Day()+days, clock.Hour(), clock.Minute(), 0, 0, location)
+	}
+
+	startToday, endToday := at(start, 0), at(end, 0)
+
+	switch {
+	case start.Before(end):
+		// e.g. 01:00 to 06:00
+		if !local.Before(startToday) && local.Before(endToday) {
+			return endToday, true
+		}
+	case !local.Before(startToday):
+		// e.g. 22:00 to 07:00, after 22:00
+		return at(end, 1), true
+	case local.Before(endToday):
+		// e.g. 22:00 to 07:00, before 07:00
+		return endToday, true
+	}
+
+	return time.Time{}, false
+}
+
+// clockLayout is the layout of the clock times of quiet hours
+const clockLayout = "15:04"
+
+// Delivery modes of an audience
+const (
+	DeliveryRealtime = "realtime" // A message per event, the default
+	DeliveryDigest   = "digest"   // Scheduled summaries of all events since the previous one
+)
+
+// Delivery controls when an audience is notified about proposals
+type Delivery struct {
+	Mode     string `mapstructure:"mode"`     // DeliveryRealtime or DeliveryDigest, empty means realtime
+	Schedule string `mapstructure:"schedule"` // Cron expression of digests in the audience's timezone, e.g. "0 9 * * 1-5"
+}
+
+// Digest reports whether the audience receives digests instead of a message per event
+func (d Delivery) Digest() bool {
+	return d.Mode == DeliveryDigest
+}
+
+// TemplateSource is a message template given either inline or as a path to a file
+type TemplateSource struct {
+	Text string `mapstructure:"text"`
+	File string `mapstructure:"file"`
 }
 
 func InitConfig() (*Config, error) {
@@ -62,6 +363,8 @@ func InitConfig() (*Config, error) {
 
 	// Set up command line flags
 	pflag.String("config", "", "Additional config files to load (comma-separated)")
+	pflag.Bool("dry-run", false, "Render notifications without sending them or advancing the stored state")
+	pflag.String("dry-run-output", "", "Directory to write rendered notifications to in dry-run mode (default: log them)")
 	pflag.Parse()
 
 	err := v.BindPFlags(pflag.CommandLine)
@@ -69,11 +372,26 @@ func InitConfig() (*Config, error) {
 		return nil, fmt.Errorf("error binding flags: %w", err)
 	}
 
+	if err := v.BindPFlag("dry_run.enabled", pflag.Lookup("dry-run")); err != nil {
+		return nil, fmt.Errorf("error binding flags: %w", err)
+	}
+
+	if err := v.BindPFlag("dry_run.output_dir", pflag.Lookup("dry-run-output")); err != nil {
+		return nil, fmt.Errorf("error binding flags: %w", err)
+	}
+
+	v.SetDefault("display.precision", DefaultDisplay.Precision)
+	v.SetDefault("display.tally_precision", DefaultDisplay.TallyPrecision)
+	v.SetDefault("display.percent_precision", DefaultDisplay.PercentPrecision)
+	v.SetDefault("display.thousands_separator", DefaultDisplay.ThousandsSeparator)
+
 	// Read the base config file first
 	if err := v.ReadInConfig(); err != nil {
 		return nil, fmt.Errorf("error reading base config file: %w", err)
 	}
 
+	sources := []string{v.ConfigFileUsed()}
+
 	// Check if additional config files were specified
 	if configFiles := v.GetString("config"); configFiles != "" {
 		// Split comma-separated list of config files
@@ -83,14 +401,17 @@ func InitConfig() (*Config, error) {
 			if err := v.MergeInConfig(); err != nil {
 				return nil, fmt.Errorf("error merging config file %s: %w", configFile, err)
 			}
+
+			sources = append(sources, configFile)
 		}
 	}
 
+	// Environment variables are interpolated first so that they also apply to durations and URLs
 	decodeHooks := mapstructure.ComposeDecodeHookFunc(
+		envVarInterpolationHookFunc(),
 		mapstructure.StringToTimeDurationHookFunc(),
 		mapstructure.StringToSliceHookFunc(","),
 		stringToURLHookFunc(),
-		envVarInterpolationHookFunc(), // Add environment variable interpolation
 	)
 
 	var cfg Config
@@ -98,6 +419,8 @@ func InitConfig() (*Config, error) {
 		return nil, fmt.Errorf("error un-marshalling config: %w", err)
 	}
 
+	cfg.Sources = sources
+
 	return &cfg, nil
 }
 
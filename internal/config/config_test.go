@@ -0,0 +1,54 @@
+package config_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/hazim1093/zeta-comms/internal/config"
+)
+
+var _ = Describe("QuietHours", func() {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+
+	at := func(day int, hour int, minute int) time.Time {
+		return time.Date(2025, 3, day, hour, minute, 0, 0, berlin)
+	}
+
+	It("should span midnight when the end is before the start", func() {
+		quietHours := config.QuietHours{Start: "22:00", End: "07:00"}
+
+		until, quiet := quietHours.Until(at(4, 23, 30), berlin)
+		Expect(quiet).To(BeTrue())
+		Expect(until).To(BeTemporally("==", at(5, 7, 0)))
+
+		until, quiet = quietHours.Until(at(5, 6, 59), berlin)
+		Expect(quiet).To(BeTrue())
+		Expect(until).To(BeTemporally("==", at(5, 7, 0)))
+
+		_, quiet = quietHours.Until(at(5, 7, 0), berlin)
+		Expect(quiet).To(BeFalse())
+
+		_, quiet = quietHours.Until(at(5, 21, 59), berlin)
+		Expect(quiet).To(BeFalse())
+	})
+
+	It("should cover a window within a day", func() {
+		quietHours := config.QuietHours{Start: "12:00", End: "13:30"}
+
+		until, quiet := quietHours.Until(at(4, 12, 0).UTC(), berlin)
+		Expect(quiet).To(BeTrue())
+		Expect(until).To(BeTemporally("==", at(4, 13, 30)))
+
+		_, quiet = quietHours.Until(at(4, 11, 59), berlin)
+		Expect(quiet).To(BeFalse())
+	})
+
+	It("should be disabled unless configured", func() {
+		Expect(config.QuietHours{}.Enabled()).To(BeFalse())
+
+		_, quiet := config.QuietHours{}.Until(at(4, 23, 0), berlin)
+		Expect(quiet).To(BeFalse())
+	})
+})
@@ -0,0 +1,639 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hazim1093/zeta-comms/pkg/i18n"
+	"github.com/robfig/cron/v3"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+)
+
+// KnownPlatforms lists the notification platforms that can be used in audience_config channels
+var KnownPlatforms = []string{"discord", "slack", "telegram"}
+
+// maxPrecision is the highest number of decimals that can be displayed
+const maxPrecision = 18
+
+// MaxExponent is the highest supported denomination exponent, configured or from chain metadata
+const MaxExponent = 36
+
+// referenceTime is used to check that date formats contain at least one date or time element
+var referenceTime = time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC)
+
+// platformLengthLimits mirrors the message length limits enforced by the notifiers in pkg/notifiers
+var platformLengthLimits = map[string]int{"discord": 4096, "slack": 3000, "telegram": 4096}
+
+// unresolvedEnvVarPattern matches ${VAR} placeholders left behind by envVarInterpolationHookFunc
+var unresolvedEnvVarPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// sliceIndexPattern matches the [N] suffixes used for slice elements in key paths
+var sliceIndexPattern = regexp.MustCompile(`\[\d+\]`)
+
+// ValidationError describes a single problem found in the configuration
+type ValidationError struct {
+	Key     string
+	File    string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	if e.File != "" {
+		return fmt.Sprintf("%s: %s: %s", e.File, e.Key, e.Message)
+	}
+
+	return fmt.Sprintf("%s: %s", e.Key, e.Message)
+}
+
+// ValidationErrors collects every problem found in the configuration
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	lines := make([]string, 0, len(e)+1)
+	lines = append(lines, fmt.Sprintf("found %d configuration problem(s):", len(e)))
+
+	for _, err := range e {
+		lines = append(lines, "  - "+err.Error())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Validate checks the configuration for problems and reports all of them at once.
+// The returned error is either nil or a ValidationErrors value.
+func (c *Config) Validate() error {
+	var errs ValidationErrors
+
+	addError := func(key string, format string, args ...interface{}) {
+		errs = append(errs, ValidationError{Key: key, Message: fmt.Sprintf(format, args...)})
+	}
+
+	c.validateNetworks(addError)
+	c.validateAudiences(addError)
+	c.validateNotifiers(addError)
+	validateTemplates("templates", c.Templates, addError)
+
+	for name, audience := range c.AudienceConfig {
+		validateTemplates("audience_config."+name+".templates", audience.Templates, addError)
+	}
+
+	if len(c.Events.Proposals.Filters.MessageTypes) == 0 {
+		addError("events.proposals.filters.message_types", "no message types configured, no proposal would ever be notified")
+	}
+
+	if c.Storage.Filename == "" {
+		addError("storage.filename", "must not be empty")
+	}
+
+	if c.Logging.Level != "" {
+		if _, err := zerolog.ParseLevel(c.Logging.Level); err != nil {
+			addError("logging.level", "unknown log level %q", c.Logging.Level)
+		}
+	}
+
+	switch c.Logging.Format {
+	case "", "console", "text", "json":
+	default:
+		addError("logging.format", "unknown log format %q, expected console, text or json", c.Logging.Format)
+	}
+
+	precisions := map[string]int{
+		"display.precision":         c.Display.Precision,
+		"display.tally_precision":   c.Display.TallyPrecision,
+		"display.percent_precision": c.Display.PercentPrecision,
+	}
+
+	for key, precision := range precisions {
+		if precision < 0 || precision > maxPrecision {
+			addError(key, "must be between 0 and %d, got %d", maxPrecision, precision)
+		}
+	}
+
+	if strings.ContainsAny(c.Display.ThousandsSeparator, "0123456789.") {
+		addError("display.thousands_separator", "must not contain digits or the decimal point, got %q", c.Display.ThousandsSeparator)
+	}
+
+	if _, ok := c.AudienceConfig[c.Alerts.Audience]; c.Alerts.Audience != "" && !ok {
+		addError("alerts.audience", "audience %q is not defined in audience_config", c.Alerts.Audience)
+	}
+
+	if c.Server.ListenAddress != "" && c.Server.ReadinessIntervals < 1 {
+		addError("server.readiness_intervals", "must be at least 1, got %d", c.Server.ReadinessIntervals)
+	}
+
+	if c.Server.ListenAddress == "" && c.Server.Admin.Token != "" {
+		addError("server.listen_address", "must be set to serve the admin API")
+	}
+
+	walkStrings(reflect.ValueOf(*c), "", func(key string, value string) {
+		// Credentials are not used in dry-run mode, so they do not need to be set
+		if c.DryRun.Enabled && isCredentialKey(key) {
+			return
+		}
+
+		for _, match := range unresolvedEnvVarPattern.FindAllStringSubmatch(value, -1) {
+			addError(key, "environment variable %s is not set", match[1])
+		}
+	})
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return c.Annotate(errs)
+}
+
+// Annotate sorts validation errors by key and fills in the config file that sets each key.
+// It is used for problems found outside this package, e.g. templates that fail to parse.
+func (c *Config) Annotate(errs ValidationErrors) ValidationErrors {
+	sort.SliceStable(errs, func(i, j int) bool {
+		return errs[i].Key < errs[j].Key
+	})
+
+	locator := newKeyLocator(c.Sources)
+	for i := range errs {
+		errs[i].File = locator.locate(errs[i].Key)
+	}
+
+	return errs
+}
+
+func (c *Config) validateNetworks(addError func(key string, format string, args ...interface{})) {
+	if len(c.Networks) == 0 {
+		addError("networks", "at least one network must be configured")
+	}
+
+	for name, network := range c.Networks {
+		prefix := "networks." + name
+
+		// api_url may be left out if api_urls lists the endpoints or the network is queried over gRPC
+		apiURLRequired := len(network.ApiUrls) == 0 && network.Transport != TransportGRPC
+		if !validEndpoint(network.ApiUrl) && (network.ApiUrl.String() != "" || apiURLRequired) {
+			addError(prefix+".api_url", "must be an absolute http(s) URL, got %q", network.ApiUrl.String())
+		}
+
+		for i, apiURL := range network.ApiUrls {
+			if !validEndpoint(apiURL) {
+				addError(fmt.Sprintf("%s.api_urls[%d]", prefix, i), "must be an absolute http(s) URL, got %q", apiURL.String())
+			}
+		}
+
+		if network.Failover.Strategy != "" && network.Failover.Strategy != StrategyLatency && network.Failover.Strategy != StrategyRoundRobin {
+			addError(prefix+".failover.strategy", "must be %q or %q, got %q", StrategyLatency, StrategyRoundRobin, network.Failover.Strategy)
+		}
+
+		switch network.Transport {
+		case "", TransportREST:
+		case TransportGRPC:
+			if _, _, err := net.SplitHostPort(network.GRPC.Address); err != nil {
+				addError(prefix+".grpc.address", "must be host:port, got %q", network.GRPC.Address)
+			}
+		default:
+			addError(prefix+".transport", "must be %q or %q, got %q", TransportREST, TransportGRPC, network.Transport)
+		}
+
+		if network.GovVersion != "" && network.GovVersion != GovV1 && network.GovVersion != GovV1Beta1 {
+			addError(prefix+".gov_version", "must be %q or %q, got %q", GovV1, GovV1Beta1, network.GovVersion)
+		}
+
+		if network.Fetch != "" && network.Fetch != FetchFull && network.Fetch != FetchIncremental {
+			addError(prefix+".fetch", "must be %q or %q, got %q", FetchFull, FetchIncremental, network.Fetch)
+		}
+
+		if network.Source.Mode != "" && network.Source.Mode != SourcePoll && !network.Source.Websocket() {
+			addError(prefix+".source.mode", "must be %q or %q, got %q", SourcePoll, SourceWebsocket, network.Source.Mode)
+		}
+
+		if rpcURL := network.Source.RPCURL; network.Source.Websocket() && (rpcURL.Host == "" || !slices.Contains([]string{"http", "https", "ws", "wss"}, rpcURL.Scheme)) {
+			addError(prefix+".source.rpc_url", "must be an absolute http(s) or ws(s) URL, got %q", rpcURL.String())
+		}
+
+		if network.Failover.Cooldown < 0 {
+			addError(prefix+".failover.cooldown", "must not be negative, got %q", network.Failover.Cooldown.String())
+		}
+
+		if network.PollInterval <= 0 {
+			addError(prefix+".poll_interval", "must be a positive duration, got %q", network.PollInterval.String())
+		}
+
+		if len(network.Audiences) == 0 {
+			addError(prefix+".audiences", "no audiences configured")
+		}
+
+		for i, audience := range network.Audiences {
+			if _, ok := c.AudienceConfig[audience]; !ok {
+				addError(fmt.Sprintf("%s.audiences[%d]", prefix, i), "audience %q is not defined in audience_config", audience)
+			}
+		}
+
+		validateDenom(prefix+".denom", network.Denom, addError)
+
+		if network.Severity.CriticalUpgradeWithin < 0 {
+			addError(prefix+".severity.critical_upgrade_within", "must not be negative, got %q", network.Severity.CriticalUpgradeWithin.String())
+		}
+
+		if network.Severity.BlockTime < 0 {
+			addError(prefix+".severity.block_time", "must not be negative, got %q", network.Severity.BlockTime.String())
+		}
+
+		validateMonitoring(prefix+".monitoring", network.Monitoring, addError)
+
+		links := map[string]string{
+			"explorer":   network.Links.Explorer,
+			"governance": network.Links.Governance,
+		}
+
+		for name, link := range links {
+			if link == "" {
+				continue
+			}
+
+			if err := validateLinkTemplate(link); err != nil {
+				addError(prefix+".links."+name, "%s", err)
+			}
+		}
+	}
+}
+
+func validateDenom(prefix string, denom Denom, addError func(key string, format string, args ...interface{})) {
+	if denom.Base == "" && (denom.Display != "" || denom.Exponent != 0) {
+		addError(prefix+".base", "must be set when display or exponent are configured")
+	}
+
+	if denom.Exponent < 0 || denom.Exponent > MaxExponent {
+		addError(prefix+".exponent", "must be between 0 and %d, got %d", MaxExponent, denom.Exponent)
+	}
+
+	if _, ok := TallyUnits[denom.TallyUnit]; denom.TallyUnit != "" && !ok {
+		addError(prefix+".tally_unit", "must be one of %s, got %q", "none, K, M, B", denom.TallyUnit)
+	}
+}
+
+func validateMonitoring(prefix string, monitoring Monitoring, addError func(key string, format string, args ...interface{})) {
+	names := make(map[string]bool)
+
+	for i, node := range monitoring.Nodes {
+		nodePrefix := fmt.Sprintf("%s.nodes[%d]", prefix, i)
+
+		switch {
+		case node.Name == "":
+			addError(nodePrefix+".name", "must be set")
+		case names[node.Name]:
+			addError(nodePrefix+".name", "node %q is defined more than once", node.Name)
+		}
+
+		names[node.Name] = true
+
+		if node.ApiUrl.String() == "" && node.RPCURL.String() == "" {
+			addError(nodePrefix, "api_url or rpc_url must be set")
+		}
+
+		if node.ApiUrl.String() != "" && !validEndpoint(node.ApiUrl) {
+			addError(nodePrefix+".api_url", "must be an absolute http(s) URL, got %q", node.ApiUrl.String())
+		}
+
+		if node.RPCURL.String() != "" && !validEndpoint(node.RPCURL) {
+			addError(nodePrefix+".rpc_url", "must be an absolute http(s) URL, got %q", node.RPCURL.String())
+		}
+	}
+
+	if monitoring.Interval < 0 {
+		addError(prefix+".interval", "must not be negative, got %q", monitoring.Interval.String())
+	}
+
+	if monitoring.MaxLag < 0 {
+		addError(prefix+".max_lag", "must not be negative, got %d", monitoring.MaxLag)
+	}
+
+	if monitoring.StallAfter < 0 {
+		addError(prefix+".stall_after", "must not be negative, got %q", monitoring.StallAfter.String())
+	}
+}
+
+// validEndpoint reports whether an LCD endpoint is an absolute http(s) URL
+func validEndpoint(endpoint url.URL) bool {
+	return endpoint.Host != "" && (endpoint.Scheme == "http" || endpoint.Scheme == "https")
+}
+
+func validateQuietHours(prefix string, quietHours QuietHours, addError func(key string, format string, args ...interface{})) {
+	if !quietHours.Enabled() {
+		return
+	}
+
+	clocks := map[string]string{
+		"start": quietHours.Start,
+		"end":   quietHours.End,
+	}
+
+	for name, clock := range clocks {
+		if _, err := time.Parse(clockLayout, clock); err != nil {
+			addError(prefix+"."+name, "must be a clock time such as 22:00, got %q", clock)
+		}
+	}
+
+	if quietHours.Start == quietHours.End {
+		addError(prefix+".end", "must differ from start")
+	}
+
+	if _, err := time.LoadLocation(quietHours.Timezone); err != nil {
+		addError(prefix+".timezone", "unknown timezone %q", quietHours.Timezone)
+	}
+}
+
+// validateLinkTemplate checks that a link template is an http(s) URL containing the {id} placeholder
+func validateLinkTemplate(link string) error {
+	if !strings.Contains(link, "{id}") {
+		return fmt.Errorf("must contain the {id} placeholder, got %q", link)
+	}
+
+	u, err := url.Parse(strings.ReplaceAll(link, "{id}", "1"))
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return fmt.Errorf("must be an absolute http(s) URL, got %q", link)
+	}
+
+	return nil
+}
+
+func (c *Config) validateAudiences(addError func(key string, format string, args ...interface{})) {
+	for name, audience := range c.AudienceConfig {
+		prefix := "audience_config." + name + ".channels"
+
+		if len(audience.Channels) == 0 {
+			addError(prefix, "no channels configured")
+		}
+
+		if _, err := time.LoadLocation(audience.Timezone); err != nil {
+			addError("audience_config."+name+".timezone", "unknown timezone %q", audience.Timezone)
+		}
+
+		switch audience.Delivery.Mode {
+		case "", DeliveryRealtime:
+		case DeliveryDigest:
+			if _, err := cron.ParseStandard(audience.Delivery.Schedule); err != nil {
+				addError("audience_config."+name+".delivery.schedule", "must be a cron expression such as %q: %s", "0 9 * * 1-5", err)
+			}
+		default:
+			addError("audience_config."+name+".delivery.mode", "must be %q or %q, got %q", DeliveryRealtime, DeliveryDigest, audience.Delivery.Mode)
+		}
+
+		validateQuietHours("audience_config."+name+".quiet_hours", audience.QuietHours, addError)
+
+		if !i18n.Supported(audience.Language) {
+			addError("audience_config."+name+".language", "unsupported language %q, expected one of %s", audience.Language, strings.Join(i18n.Languages, ", "))
+		}
+
+		if audience.DateFormat != "" && referenceTime.Format(audience.DateFormat) == audience.DateFormat {
+			addError("audience_config."+name+".date_format", "must be a Go time layout such as %q, got %q", "2006-01-02 15:04 MST", audience.DateFormat)
+		}
+
+		for platform, channels := range audience.Channels {
+			key := prefix + "." + platform
+
+			if !isKnownPlatform(platform) {
+				addError(key, "unknown platform %q, expected one of %s", platform, strings.Join(KnownPlatforms, ", "))
+
+				continue
+			}
+
+			if len(channels) == 0 {
+				addError(key, "no channels configured")
+			}
+
+			for i, channel := range channels {
+				channelKey := fmt.Sprintf("%s[%d]", key, i)
+
+				// Unresolved environment variables are reported separately
+				if unresolvedEnvVarPattern.MatchString(channel) || (c.DryRun.Enabled && isCredentialKey(channelKey)) {
+					continue
+				}
+
+				if err := validateChannel(platform, channel); err != nil {
+					addError(channelKey, "%s", err)
+				}
+			}
+		}
+	}
+}
+
+func (c *Config) validateNotifiers(addError func(key string, format string, args ...interface{})) {
+	tokens := map[string]string{
+		"discord":  c.Notifiers.Discord.BotToken,
+		"telegram": c.Notifiers.Telegram.BotToken,
+	}
+
+	for platform, token := range tokens {
+		if token == "" && c.usesPlatform(platform) && !c.DryRun.Enabled {
+			addError("notifiers."+platform+".bot_token", "must be set because %s channels are configured", platform)
+		}
+	}
+
+	overflows := map[string]Overflow{
+		"discord":  c.Notifiers.Discord.Overflow,
+		"slack":    c.Notifiers.Slack.Overflow,
+		"telegram": c.Notifiers.Telegram.Overflow,
+	}
+
+	for platform, overflow := range overflows {
+		prefix := "notifiers." + platform + ".overflow"
+
+		switch overflow.Mode {
+		case "", "truncate", "split":
+		default:
+			addError(prefix+".mode", "unknown overflow mode %q, expected truncate or split", overflow.Mode)
+		}
+
+		if limit := platformLengthLimits[platform]; overflow.MaxLength < 0 || overflow.MaxLength > limit {
+			addError(prefix+".max_length", "must be between 0 and the %s limit of %d, got %d", platform, limit, overflow.MaxLength)
+		}
+	}
+}
+
+// validateTemplates checks the template overrides under prefix. Event types and template
+// syntax are checked when the templates are loaded by the notifications package.
+func validateTemplates(prefix string, templates map[string]map[string]TemplateSource, addError func(key string, format string, args ...interface{})) {
+	for platform, events := range templates {
+		if !isKnownPlatform(platform) {
+			addError(prefix+"."+platform, "unknown platform %q, expected one of %s", platform, strings.Join(KnownPlatforms, ", "))
+
+			continue
+		}
+
+		for event, source := range events {
+			if (source.Text == "") == (source.File == "") {
+				addError(prefix+"."+platform+"."+event, "exactly one of text and file must be set")
+			}
+		}
+	}
+}
+
+// usesPlatform reports whether any audience has channels for the given platform
+func (c *Config) usesPlatform(platform string) bool {
+	for _, audience := range c.AudienceConfig {
+		if len(audience.Channels[platform]) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isCredentialKey reports whether a key holds a secret, i.e. a bot token or a Slack webhook URL
+func isCredentialKey(key string) bool {
+	return strings.HasPrefix(key, "notifiers.") ||
+		(strings.HasPrefix(key, "audience_config.") && strings.Contains(key, ".channels.slack"))
+}
+
+func isKnownPlatform(platform string) bool {
+	for _, known := range KnownPlatforms {
+		if platform == known {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateChannel checks that a channel identifier has the shape expected by the platform
+func validateChannel(platform string, channel string) error {
+	switch platform {
+	case "slack":
+		u, err := url.ParseRequestURI(channel)
+		if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+			return fmt.Errorf("slack channel must be a webhook URL")
+		}
+	case "telegram":
+		if _, err := strconv.ParseInt(channel, 10, 64); err != nil {
+			return fmt.Errorf("telegram channel must be a numeric chat ID, got %q", channel)
+		}
+	case "discord":
+		if _, err := strconv.ParseUint(channel, 10, 64); err != nil {
+			return fmt.Errorf("discord channel must be a numeric channel ID, got %q", channel)
+		}
+	}
+
+	return nil
+}
+
+// walkStrings calls fn for every string reachable from value, using mapstructure tags to build key paths
+func walkStrings(value reflect.Value, key string, fn func(key string, value string)) {
+	switch value.Kind() {
+	case reflect.String:
+		fn(key, value.String())
+	case reflect.Struct:
+		if u, ok := value.Interface().(url.URL); ok {
+			if raw, err := url.PathUnescape(u.String()); err == nil {
+				fn(key, raw)
+			}
+
+			return
+		}
+
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			name := field.Tag.Get("mapstructure")
+			if name == "-" {
+				continue
+			}
+
+			if name == "" {
+				name = strings.ToLower(field.Name)
+			}
+
+			walkStrings(value.Field(i), joinKey(key, name), fn)
+		}
+	case reflect.Map:
+		keys := value.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return keys[i].String() < keys[j].String()
+		})
+
+		for _, mapKey := range keys {
+			walkStrings(value.MapIndex(mapKey), joinKey(key, mapKey.String()), fn)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			walkStrings(value.Index(i), fmt.Sprintf("%s[%d]", key, i), fn)
+		}
+	case reflect.Ptr, reflect.Interface:
+		if !value.IsNil() {
+			walkStrings(value.Elem(), key, fn)
+		}
+	}
+}
+
+func joinKey(prefix string, name string) string {
+	if prefix == "" {
+		return name
+	}
+
+	return prefix + "." + name
+}
+
+// keyLocator finds which of the loaded config files defines a given key
+type keyLocator struct {
+	files  []string
+	vipers map[string]*viper.Viper
+}
+
+func newKeyLocator(files []string) *keyLocator {
+	return &keyLocator{
+		files:  files,
+		vipers: make(map[string]*viper.Viper),
+	}
+}
+
+// locate returns the last loaded file that sets the key or its closest parent, or "" if none does
+func (l *keyLocator) locate(key string) string {
+	// Strip slice indexes, viper only knows about map keys
+	key = sliceIndexPattern.ReplaceAllString(key, "")
+
+	for candidate := key; candidate != ""; candidate = parentKey(candidate) {
+		for i := len(l.files) - 1; i >= 0; i-- {
+			v := l.load(l.files[i])
+			if v != nil && v.IsSet(candidate) {
+				return l.files[i]
+			}
+		}
+	}
+
+	return ""
+}
+
+func (l *keyLocator) load(file string) *viper.Viper {
+	if v, ok := l.vipers[file]; ok {
+		return v
+	}
+
+	v := viper.New()
+	v.SetConfigFile(file)
+
+	if err := v.ReadInConfig(); err != nil {
+		v = nil
+	}
+
+	l.vipers[file] = v
+
+	return v
+}
+
+func parentKey(key string) string {
+	idx := strings.LastIndex(key, ".")
+	if idx < 0 {
+		return ""
+	}
+
+	return key[:idx]
+}
@@ -0,0 +1,332 @@
+package config_test
+
+import (
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/hazim1093/zeta-comms/internal/config"
+)
+
+func TestConfig(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Config Suite")
+}
+
+var _ = Describe("Validate", func() {
+	var cfg *config.Config
+
+	// keysOf returns the key paths of all validation errors
+	keysOf := func(err error) []string {
+		var validationErrs config.ValidationErrors
+		Expect(errors.As(err, &validationErrs)).To(BeTrue())
+
+		keys := make([]string, 0, len(validationErrs))
+		for _, validationErr := range validationErrs {
+			keys = append(keys, validationErr.Key)
+		}
+
+		return keys
+	}
+
+	BeforeEach(func() {
+		apiURL, _ := url.Parse("https://zetachain.example.com/lcd")
+
+		cfg = &config.Config{
+			Networks: map[string]config.Network{
+				"mainnet": {
+					ApiUrl:       *apiURL,
+					PollInterval: 10 * time.Second,
+					Audiences:    []string{"operators"},
+				},
+			},
+			AudienceConfig: map[string]config.Audience{
+				"operators": {
+					Channels: map[string][]string{
+						"discord":  {"1398006827903357150"},
+						"slack":    {"https://hooks.slack.com/services/T000/B000/XXXX"},
+						"telegram": {"-1002380605871"},
+					},
+				},
+			},
+		}
+		cfg.Events.Proposals.Filters.MessageTypes = []string{"/cosmos.upgrade.v1beta1.MsgSoftwareUpgrade"}
+		cfg.Notifiers.Discord.BotToken = "discord-token"
+		cfg.Notifiers.Telegram.BotToken = "telegram-token"
+		cfg.Storage.Filename = "file-db.yaml"
+	})
+
+	It("should accept a valid configuration", func() {
+		Expect(cfg.Validate()).To(Succeed())
+	})
+
+	It("should report all problems at once", func() {
+		network := cfg.Networks["mainnet"]
+		network.PollInterval = 0
+		network.Audiences = append(network.Audiences, "missing")
+		cfg.Networks["mainnet"] = network
+
+		cfg.AudienceConfig["operators"].Channels["teams"] = []string{"general"}
+		cfg.Notifiers.Discord.BotToken = "${DISCORD_BOT_TOKEN}"
+
+		Expect(keysOf(cfg.Validate())).To(ConsistOf(
+			"audience_config.operators.channels.teams",
+			"networks.mainnet.audiences[1]",
+			"networks.mainnet.poll_interval",
+			"notifiers.discord.bot_token",
+		))
+	})
+
+	It("should report unresolved environment variables in channels", func() {
+		cfg.AudienceConfig["operators"].Channels["slack"] = []string{"${SLACK_MAINNET_WEBHOOK}"}
+
+		err := cfg.Validate()
+		Expect(keysOf(err)).To(ConsistOf("audience_config.operators.channels.slack[0]"))
+		Expect(err.Error()).To(ContainSubstring("environment variable SLACK_MAINNET_WEBHOOK is not set"))
+	})
+
+	It("should reject malformed channel identifiers", func() {
+		cfg.AudienceConfig["operators"].Channels["telegram"] = []string{"zetachain-notifs"}
+
+		Expect(keysOf(cfg.Validate())).To(ConsistOf("audience_config.operators.channels.telegram[0]"))
+	})
+
+	It("should require bot tokens only for platforms in use", func() {
+		delete(cfg.AudienceConfig["operators"].Channels, "telegram")
+		cfg.Notifiers.Telegram.BotToken = ""
+		cfg.Notifiers.Discord.BotToken = ""
+
+		Expect(keysOf(cfg.Validate())).To(ConsistOf("notifiers.discord.bot_token"))
+	})
+
+	It("should reject invalid overflow settings and link templates", func() {
+		cfg.Notifiers.Slack.Overflow = config.Overflow{Mode: "wrap", MaxLength: 4000}
+		cfg.Notifiers.Telegram.Overflow = config.Overflow{Mode: "split", MaxLength: 1000}
+
+		network := cfg.Networks["mainnet"]
+		network.Links.Explorer = "https://explorer.zetachain.com/proposals"
+		network.Links.Governance = "hub.zetachain.com/governance/{id}"
+		cfg.Networks["mainnet"] = network
+
+		Expect(keysOf(cfg.Validate())).To(ConsistOf(
+			"networks.mainnet.links.explorer",
+			"networks.mainnet.links.governance",
+			"notifiers.slack.overflow.max_length",
+			"notifiers.slack.overflow.mode",
+		))
+	})
+
+	It("should reject incomplete denominations and unknown tally units", func() {
+		network := cfg.Networks["mainnet"]
+		network.Denom = config.Denom{Display: "ATOM", Exponent: -1, TallyUnit: "T"}
+		cfg.Networks["mainnet"] = network
+
+		Expect(keysOf(cfg.Validate())).To(ConsistOf(
+			"networks.mainnet.denom.base",
+			"networks.mainnet.denom.exponent",
+			"networks.mainnet.denom.tally_unit",
+		))
+	})
+
+	It("should reject unknown timezones and languages and date formats without date elements", func() {
+		audience := cfg.AudienceConfig["operators"]
+		audience.Timezone = "Mars/Olympus_Mons"
+		audience.DateFormat = "YYYY-MM-DD"
+		audience.Language = "klingon"
+		cfg.AudienceConfig["operators"] = audience
+
+		Expect(keysOf(cfg.Validate())).To(ConsistOf(
+			"audience_config.operators.date_format",
+			"audience_config.operators.language",
+			"audience_config.operators.timezone",
+		))
+
+		audience.Timezone = "America/New_York"
+		audience.DateFormat = "Mon Jan 2 15:04 MST"
+		audience.Language = "zh"
+		cfg.AudienceConfig["operators"] = audience
+
+		Expect(cfg.Validate()).To(Succeed())
+	})
+
+	It("should require a valid schedule for digest delivery", func() {
+		audience := cfg.AudienceConfig["operators"]
+		audience.Delivery = config.Delivery{Mode: config.DeliveryDigest, Schedule: "every morning"}
+		cfg.AudienceConfig["operators"] = audience
+
+		Expect(keysOf(cfg.Validate())).To(ConsistOf("audience_config.operators.delivery.schedule"))
+
+		audience.Delivery = config.Delivery{Mode: "weekly"}
+		cfg.AudienceConfig["operators"] = audience
+
+		Expect(keysOf(cfg.Validate())).To(ConsistOf("audience_config.operators.delivery.mode"))
+
+		audience.Delivery = config.Delivery{Mode: config.DeliveryDigest, Schedule: "0 9 * * 1-5"}
+		cfg.AudienceConfig["operators"] = audience
+
+		Expect(cfg.Validate()).To(Succeed())
+	})
+
+	It("should reject malformed quiet hours and negative severity durations", func() {
+		audience := cfg.AudienceConfig["operators"]
+		audience.QuietHours = config.QuietHours{Start: "10pm", End: "07:00", Timezone: "Mars/Olympus"}
+		cfg.AudienceConfig["operators"] = audience
+
+		network := cfg.Networks["mainnet"]
+		network.Severity.CriticalUpgradeWithin = -time.Hour
+		cfg.Networks["mainnet"] = network
+
+		Expect(keysOf(cfg.Validate())).To(ConsistOf(
+			"audience_config.operators.quiet_hours.start",
+			"audience_config.operators.quiet_hours.timezone",
+			"networks.mainnet.severity.critical_upgrade_within",
+		))
+
+		audience.QuietHours = config.QuietHours{Start: "22:00", End: "22:00"}
+		cfg.AudienceConfig["operators"] = audience
+		network.Severity.CriticalUpgradeWithin = 6 * time.Hour
+		cfg.Networks["mainnet"] = network
+
+		Expect(keysOf(cfg.Validate())).To(ConsistOf("audience_config.operators.quiet_hours.end"))
+
+		audience.QuietHours = config.QuietHours{Start: "22:00", End: "07:00", Timezone: "Asia/Seoul"}
+		cfg.AudienceConfig["operators"] = audience
+
+		Expect(cfg.Validate()).To(Succeed())
+	})
+
+	It("should validate fallback endpoints, the failover strategy and the alerts audience", func() {
+		fallback, _ := url.Parse("lcd.example.com")
+
+		network := cfg.Networks["mainnet"]
+		network.ApiUrls = []url.URL{*fallback}
+		network.Failover = config.Failover{Strategy: "random"}
+		cfg.Networks["mainnet"] = network
+		cfg.Alerts.Audience = "ops"
+
+		Expect(keysOf(cfg.Validate())).To(ConsistOf(
+			"networks.mainnet.api_urls[0]",
+			"networks.mainnet.failover.strategy",
+			"alerts.audience",
+		))
+
+		fallback, _ = url.Parse("https://lcd.example.com")
+		network.ApiUrl = url.URL{}
+		network.ApiUrls = []url.URL{*fallback}
+		network.Failover = config.Failover{Strategy: config.StrategyRoundRobin, Cooldown: time.Minute}
+		cfg.Networks["mainnet"] = network
+		cfg.Alerts.Audience = "operators"
+
+		Expect(cfg.Validate()).To(Succeed())
+	})
+
+	It("should require an RPC URL for websocket event sources", func() {
+		network := cfg.Networks["mainnet"]
+		network.Source = config.Source{Mode: config.SourceWebsocket}
+		cfg.Networks["mainnet"] = network
+
+		Expect(keysOf(cfg.Validate())).To(ConsistOf("networks.mainnet.source.rpc_url"))
+
+		network.Source = config.Source{Mode: "push"}
+		cfg.Networks["mainnet"] = network
+
+		Expect(keysOf(cfg.Validate())).To(ConsistOf("networks.mainnet.source.mode"))
+
+		rpcURL, _ := url.Parse("https://rpc.example.com")
+		network.Source = config.Source{Mode: config.SourceWebsocket, RPCURL: *rpcURL}
+		cfg.Networks["mainnet"] = network
+
+		Expect(cfg.Validate()).To(Succeed())
+	})
+
+	It("should validate the transport, the fetch mode and the gov version", func() {
+		network := cfg.Networks["mainnet"]
+		network.ApiUrl = url.URL{}
+		network.Transport = config.TransportGRPC
+		network.GRPC = config.GRPC{Address: "grpc.example.com"}
+		cfg.Networks["mainnet"] = network
+
+		Expect(keysOf(cfg.Validate())).To(ConsistOf("networks.mainnet.grpc.address"))
+
+		network.Transport = "websocket"
+		network.Fetch = "delta"
+		network.GovVersion = "v2"
+		cfg.Networks["mainnet"] = network
+
+		Expect(keysOf(cfg.Validate())).To(ConsistOf(
+			"networks.mainnet.api_url",
+			"networks.mainnet.transport",
+			"networks.mainnet.fetch",
+			"networks.mainnet.gov_version",
+		))
+
+		network.Transport = config.TransportGRPC
+		network.GRPC = config.GRPC{Address: "grpc.example.com:9090", TLS: true}
+		network.Fetch = config.FetchIncremental
+		network.GovVersion = config.GovV1Beta1
+		cfg.Networks["mainnet"] = network
+
+		Expect(cfg.Validate()).To(Succeed())
+	})
+
+	It("should validate the monitored nodes", func() {
+		apiURL, _ := url.Parse("https://lcd.node-1.example.com")
+		rpcURL, _ := url.Parse("rpc.node-2.example.com:26657")
+
+		network := cfg.Networks["mainnet"]
+		network.Monitoring = config.Monitoring{
+			Nodes: []config.Node{
+				{Name: "node-1", ApiUrl: *apiURL},
+				{Name: "node-1", RPCURL: *rpcURL},
+				{},
+			},
+			Interval:   -time.Minute,
+			StallAfter: -time.Minute,
+		}
+		cfg.Networks["mainnet"] = network
+
+		Expect(keysOf(cfg.Validate())).To(ConsistOf(
+			"networks.mainnet.monitoring.interval",
+			"networks.mainnet.monitoring.nodes[1].name",
+			"networks.mainnet.monitoring.nodes[1].rpc_url",
+			"networks.mainnet.monitoring.nodes[2]",
+			"networks.mainnet.monitoring.nodes[2].name",
+			"networks.mainnet.monitoring.stall_after",
+		))
+
+		rpcURL, _ = url.Parse("http://rpc.node-2.example.com:26657")
+		network.Monitoring = config.Monitoring{
+			Nodes:      []config.Node{{Name: "node-1", ApiUrl: *apiURL}, {Name: "node-2", RPCURL: *rpcURL}},
+			MaxLag:     50,
+			StallAfter: 5 * time.Minute,
+		}
+		cfg.Networks["mainnet"] = network
+
+		Expect(cfg.Validate()).To(Succeed())
+	})
+
+	It("should point each problem at the file that defines the key", func() {
+		dir := GinkgoT().TempDir()
+		base := filepath.Join(dir, "config.yaml")
+		override := filepath.Join(dir, "override.yaml")
+
+		Expect(os.WriteFile(base, []byte("networks:\n  mainnet:\n    poll_interval: 10s\n"), 0o600)).To(Succeed())
+		Expect(os.WriteFile(override, []byte("networks:\n  mainnet:\n    poll_interval: 0s\n"), 0o600)).To(Succeed())
+
+		network := cfg.Networks["mainnet"]
+		network.PollInterval = 0
+		cfg.Networks["mainnet"] = network
+		cfg.Sources = []string{base, override}
+
+		var validationErrs config.ValidationErrors
+		Expect(errors.As(cfg.Validate(), &validationErrs)).To(BeTrue())
+		Expect(validationErrs).To(HaveLen(1))
+		Expect(validationErrs[0].File).To(Equal(override))
+	})
+})
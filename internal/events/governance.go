@@ -2,45 +2,104 @@ package events
 
 import (
 	"context"
+	"fmt"
 	"slices"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/hazim1093/zeta-comms/internal/config"
+	"github.com/hazim1093/zeta-comms/internal/metrics"
 	"github.com/hazim1093/zeta-comms/pkg/zetachain"
 	"github.com/rs/zerolog"
 )
 
 type GovService struct {
-	restClient *zetachain.RESTClient
-	config     *config.Config
-	log        *zerolog.Logger
+	client zetachain.Client
+	config *config.Config
+	log    *zerolog.Logger
+
+	mu                 sync.RWMutex
+	lastSuccessfulPoll map[string]time.Time
+	cursors            map[string]*proposalCursor // Progress of incremental fetches per network
 }
 
 // ProposalUpdate contains either proposals or an error
 type ProposalUpdate struct {
 	Proposals []zetachain.Proposal
 	Error     error
+
+	// Partial is set if Proposals only holds the proposals affected by an event or fetched
+	// incrementally, instead of all of them
+	Partial bool
 }
 
 func NewGovService(cfg *config.Config, logger *zerolog.Logger) *GovService {
-	restClient := zetachain.NewRESTClient(cfg, logger)
-
 	return &GovService{
-		restClient: restClient,
-		config:     cfg,
-		log:        logger,
+		client:             zetachain.NewClient(cfg, logger),
+		config:             cfg,
+		log:                logger,
+		lastSuccessfulPoll: make(map[string]time.Time),
+		cursors:            make(map[string]*proposalCursor),
 	}
 }
 
+// SetClient replaces the client used to query the networks, e.g. with a fake in tests
+func (g *GovService) SetClient(client zetachain.Client) {
+	g.client = client
+}
+
+// Ready implements the server.ReadinessCheck interface. A network is ready when it has been
+// polled successfully within the configured number of poll intervals, also while it is subscribed to events.
+func (g *GovService) Ready() error {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	var stalled []string
+
+	for network, networkConfig := range g.config.Networks {
+		lastPoll, ok := g.lastSuccessfulPoll[network]
+		if !ok {
+			stalled = append(stalled, network+" (never polled)")
+
+			continue
+		}
+
+		maxAge := time.Duration(g.config.Server.ReadinessIntervals) * networkConfig.PollInterval
+		if age := time.Since(lastPoll); age > maxAge {
+			stalled = append(stalled, fmt.Sprintf("%s (last poll %s ago)", network, age.Round(time.Second)))
+		}
+	}
+
+	if len(stalled) > 0 {
+		sort.Strings(stalled)
+
+		return fmt.Errorf("proposal polling stalled: %s", strings.Join(stalled, ", "))
+	}
+
+	return nil
+}
+
+// StartPollingProposals watches the proposals of a network, by polling or by subscribing to
+// CometBFT events depending on the network's source, and sends the updates to the returned channel
 func (g *GovService) StartPollingProposals(ctx context.Context, network string) chan ProposalUpdate {
 	log := g.log.With().Str("network", network).Logger()
 	pollInterval := g.config.Networks[network].PollInterval
 
-	log.Info().Msg("Starting to poll software upgrade proposals every " + pollInterval.String())
-
 	// Create a buffered channel to avoid blocking
 	updateCh := make(chan ProposalUpdate, 10)
 
+	if g.config.Networks[network].Source.Websocket() {
+		log.Info().Msg("Subscribing to governance events, polling every " + pollInterval.String() + " while disconnected")
+
+		go g.subscribeProposals(ctx, network, pollInterval, updateCh)
+
+		return updateCh
+	}
+
+	log.Info().Msg("Starting to poll software upgrade proposals every " + pollInterval.String())
+
 	go g.pollProposals(ctx, network, pollInterval, updateCh)
 
 	return updateCh
@@ -55,7 +114,7 @@ func (g *GovService) pollProposals(ctx context.Context, network string, pollInte
 	defer ticker.Stop()
 
 	// Initial fetch
-	proposals, err := g.getSoftwareUpgradeProposals(network)
+	proposals, partial, err := g.fetchProposals(network)
 	if err != nil {
 		log.Error().Err(err).Msg("failed to get initial proposals")
 		// Send the error to the channel instead of returning
@@ -63,23 +122,14 @@ func (g *GovService) pollProposals(ctx context.Context, network string, pollInte
 	} else {
 		log.Debug().Msgf("Initial proposals fetched")
 		// Send initial proposals to the channel
-		updateCh <- ProposalUpdate{Proposals: proposals}
+		updateCh <- ProposalUpdate{Proposals: proposals, Partial: partial}
 	}
 
 	// Polling loop
 	for {
 		select {
 		case <-ticker.C:
-			log.Info().Msg("Polling for proposals ...")
-
-			proposals, err := g.getSoftwareUpgradeProposals(network)
-			if err != nil {
-				log.Error().Err(err).Msg("failed to get proposals")
-				updateCh <- ProposalUpdate{Error: err}
-			} else {
-				log.Debug().Msgf("Proposals fetched")
-				updateCh <- ProposalUpdate{Proposals: proposals}
-			}
+			g.poll(network, updateCh)
 
 		case <-ctx.Done():
 			log.Info().Msg("Stopping proposal polling due to context cancellation")
@@ -89,8 +139,62 @@ func (g *GovService) pollProposals(ctx context.Context, network string, pollInte
 	}
 }
 
+// poll fetches the proposals of a network and sends them, or the error, to the channel
+func (g *GovService) poll(network string, updateCh chan ProposalUpdate) {
+	log := g.log.With().Str("network", network).Logger()
+	log.Info().Msg("Polling for proposals ...")
+
+	proposals, partial, err := g.fetchProposals(network)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to get proposals")
+		updateCh <- ProposalUpdate{Error: err}
+
+		return
+	}
+
+	log.Debug().Msgf("Proposals fetched")
+	updateCh <- ProposalUpdate{Proposals: proposals, Partial: partial}
+}
+
+// ListProposals fetches the proposals of a network that match the configured filters
+func (g *GovService) ListProposals(network string) ([]zetachain.Proposal, error) {
+	if _, ok := g.config.Networks[network]; !ok {
+		return nil, fmt.Errorf("network %s not found in config", network)
+	}
+
+	return g.getSoftwareUpgradeProposals(network)
+}
+
+// fetchProposals fetches the filtered proposals and records the poll outcome. partial is set if
+// the network fetches incrementally and only the proposals that may have changed were fetched.
+func (g *GovService) fetchProposals(network string) (proposals []zetachain.Proposal, partial bool, err error) {
+	metrics.Polls.WithLabelValues(network).Inc()
+
+	if g.config.Networks[network].Fetch == config.FetchIncremental {
+		proposals, partial, err = g.getChangedProposals(network)
+	} else {
+		proposals, err = g.getSoftwareUpgradeProposals(network)
+	}
+
+	if err != nil {
+		metrics.PollErrors.WithLabelValues(network).Inc()
+
+		return nil, false, err
+	}
+
+	now := time.Now()
+
+	g.mu.Lock()
+	g.lastSuccessfulPoll[network] = now
+	g.mu.Unlock()
+
+	metrics.LastSuccessfulPoll.WithLabelValues(network).Set(float64(now.Unix()))
+
+	return proposals, partial, nil
+}
+
 func (g *GovService) getSoftwareUpgradeProposals(network string) ([]zetachain.Proposal, error) {
-	proposalsResp, err := g.restClient.GetProposals(network)
+	proposalsResp, err := g.client.GetProposals(network, zetachain.ProposalsQuery{})
 	if err != nil {
 		g.log.Error().Err(err).Msg("failed to get proposals")
 
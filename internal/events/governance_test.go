@@ -0,0 +1,213 @@
+package events_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rs/zerolog"
+
+	"github.com/hazim1093/zeta-comms/internal/config"
+	"github.com/hazim1093/zeta-comms/internal/events"
+	"github.com/hazim1093/zeta-comms/pkg/zetachain"
+)
+
+func TestEvents(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Events Suite")
+}
+
+func upgradeProposal(id string, status string) zetachain.Proposal {
+	return zetachain.Proposal{
+		ProposalId: id,
+		Status:     status,
+		Messages:   []zetachain.Message{{Type: "/cosmos.upgrade.v1beta1.MsgSoftwareUpgrade"}},
+	}
+}
+
+func proposalIDs(proposals []zetachain.Proposal) []string {
+	var ids []string
+	for _, proposal := range proposals {
+		ids = append(ids, proposal.ProposalId)
+	}
+
+	return ids
+}
+
+// failingClient fails to list proposals while failing is set
+type failingClient struct {
+	*zetachain.FakeClient
+	failing atomic.Bool
+}
+
+func (c *failingClient) GetProposals(network string, query zetachain.ProposalsQuery) (*zetachain.ProposalsResponse, error) {
+	if c.failing.Load() {
+		return nil, errors.New("node unavailable")
+	}
+
+	return c.FakeClient.GetProposals(network, query)
+}
+
+// subscriptionServer confirms the event subscriptions of a client and keeps the connection open
+func subscriptionServer() *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		for {
+			var request struct {
+				ID int `json:"id"`
+			}
+			if err := conn.ReadJSON(&request); err != nil {
+				return
+			}
+
+			if err := conn.WriteJSON(map[string]interface{}{"jsonrpc": "2.0", "id": request.ID, "result": map[string]interface{}{}}); err != nil {
+				return
+			}
+		}
+	}))
+	DeferCleanup(server.Close)
+
+	return server
+}
+
+var _ = Describe("GovService", func() {
+	var (
+		cfg     *config.Config
+		client  *zetachain.FakeClient
+		service *events.GovService
+	)
+
+	BeforeEach(func() {
+		cfg = &config.Config{
+			Networks: map[string]config.Network{
+				"mainnet": {Fetch: config.FetchIncremental, PollInterval: time.Hour},
+			},
+		}
+		cfg.Events.Proposals.Filters.MessageTypes = []string{"/cosmos.upgrade.v1beta1.MsgSoftwareUpgrade"}
+
+		client = zetachain.NewFakeClient()
+
+		logger := zerolog.Nop()
+		service = events.NewGovService(cfg, &logger)
+		service.SetClient(client)
+	})
+
+	It("should only fetch new and active proposals after the first poll in incremental mode", func() {
+		client.SetProposals("mainnet",
+			upgradeProposal("1", "PROPOSAL_STATUS_PASSED"),
+			upgradeProposal("2", "PROPOSAL_STATUS_VOTING_PERIOD"),
+			upgradeProposal("3", "PROPOSAL_STATUS_REJECTED"),
+		)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+
+		updateCh := service.StartPollingProposals(ctx, "mainnet")
+
+		var update events.ProposalUpdate
+		Eventually(updateCh).Should(Receive(&update))
+		Expect(update.Error).NotTo(HaveOccurred())
+		Expect(update.Partial).To(BeFalse())
+		Expect(proposalIDs(update.Proposals)).To(Equal([]string{"1", "2", "3"}))
+
+		// Restart polling to fetch again right away. Meanwhile proposal 2 passes and proposal 4 is submitted.
+		cancel()
+		Eventually(updateCh).Should(BeClosed())
+
+		client.SetProposals("mainnet",
+			upgradeProposal("1", "PROPOSAL_STATUS_PASSED"),
+			upgradeProposal("2", "PROPOSAL_STATUS_PASSED"),
+			upgradeProposal("3", "PROPOSAL_STATUS_REJECTED"),
+			upgradeProposal("4", "PROPOSAL_STATUS_DEPOSIT_PERIOD"),
+		)
+		client.ProposalsQueries = nil
+
+		ctx, cancel = context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+
+		updateCh = service.StartPollingProposals(ctx, "mainnet")
+
+		Eventually(updateCh).Should(Receive(&update))
+		Expect(update.Error).NotTo(HaveOccurred())
+		Expect(update.Partial).To(BeTrue())
+		Expect(proposalIDs(update.Proposals)).To(Equal([]string{"2", "4"}))
+		Expect(update.Proposals[0].Status).To(Equal("PROPOSAL_STATUS_PASSED"))
+
+		cancel()
+		Eventually(updateCh).Should(BeClosed())
+
+		Expect(client.ProposalsQueries).To(ConsistOf(
+			zetachain.ProposalsQuery{Reverse: true, AfterID: 3},
+			zetachain.ProposalsQuery{Status: "PROPOSAL_STATUS_DEPOSIT_PERIOD"},
+			zetachain.ProposalsQuery{Status: "PROPOSAL_STATUS_VOTING_PERIOD"},
+		))
+	})
+
+	It("should fetch all proposals on every poll by default", func() {
+		cfg.Networks["mainnet"] = config.Network{PollInterval: 10 * time.Millisecond}
+		client.SetProposals("mainnet", upgradeProposal("1", "PROPOSAL_STATUS_PASSED"))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+
+		updateCh := service.StartPollingProposals(ctx, "mainnet")
+
+		for range 2 {
+			var update events.ProposalUpdate
+			Eventually(updateCh).Should(Receive(&update))
+			Expect(update.Partial).To(BeFalse())
+			Expect(proposalIDs(update.Proposals)).To(Equal([]string{"1"}))
+		}
+	})
+
+	It("should keep polling while subscribed to events and not be ready once the polls fail", func() {
+		rpcURL, _ := url.Parse(subscriptionServer().URL)
+
+		cfg.Server.ReadinessIntervals = 3
+		cfg.Networks["mainnet"] = config.Network{
+			PollInterval: 20 * time.Millisecond,
+			Source:       config.Source{Mode: config.SourceWebsocket, RPCURL: *rpcURL},
+		}
+		client.SetProposals("mainnet", upgradeProposal("1", "PROPOSAL_STATUS_VOTING_PERIOD"))
+
+		failing := &failingClient{FakeClient: client}
+		service.SetClient(failing)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+
+		updateCh := service.StartPollingProposals(ctx, "mainnet")
+
+		// The proposals are fetched on subscribing and on every poll after that
+		for range 3 {
+			var update events.ProposalUpdate
+			Eventually(updateCh).Should(Receive(&update))
+			Expect(update.Error).NotTo(HaveOccurred())
+			Expect(proposalIDs(update.Proposals)).To(Equal([]string{"1"}))
+		}
+
+		Expect(service.Ready()).To(Succeed())
+
+		failing.failing.Store(true)
+
+		go func() {
+			for range updateCh {
+			}
+		}()
+
+		Eventually(service.Ready).Should(MatchError(ContainSubstring("mainnet (last poll")))
+	})
+})
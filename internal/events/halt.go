@@ -0,0 +1,170 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hazim1093/zeta-comms/internal/config"
+	"github.com/hazim1093/zeta-comms/internal/metrics"
+	"github.com/hazim1093/zeta-comms/pkg/zetachain"
+	"github.com/rs/zerolog"
+)
+
+// ChainReport holds the result of checking the block production of a network
+type ChainReport struct {
+	Height int64     // Height of the latest block
+	Since  time.Time // Time the latest block was produced
+	Halted bool      // No block was produced for stall_after
+
+	// Upgrade is set while the chain is halted at the height of a scheduled upgrade
+	Upgrade *Upgrade
+
+	// Resumed is set on the first check that finds a new block after a halt, Downtime is how long
+	// the chain was halted for
+	Resumed  bool
+	Downtime time.Duration
+}
+
+// ChainMonitor detects networks that stopped producing blocks, and whether a halt is the expected
+// one at the height of an upgrade
+type ChainMonitor struct {
+	upgradeSchedule
+
+	client zetachain.Client
+	config *config.Config
+	log    *zerolog.Logger
+
+	mu     sync.Mutex
+	chains map[string]*chainState
+}
+
+// chainState is what a check remembers about a network for the next one
+type chainState struct {
+	height int64
+	since  time.Time
+	halted bool
+}
+
+func NewChainMonitor(cfg *config.Config, logger *zerolog.Logger) *ChainMonitor {
+	return &ChainMonitor{
+		client: zetachain.NewClient(cfg, logger),
+		config: cfg,
+		log:    logger,
+		chains: make(map[string]*chainState),
+	}
+}
+
+// SetClient replaces the client used to query the latest block, e.g. with a fake in tests
+func (m *ChainMonitor) SetClient(client zetachain.Client) {
+	m.client = client
+}
+
+// StartMonitoring checks the latest block of a network every monitoring interval and sends the
+// reports to the returned channel. It returns nil if halt detection is disabled for the network.
+func (m *ChainMonitor) StartMonitoring(ctx context.Context, network string) chan ChainReport {
+	monitoring := m.config.Networks[network].Monitoring
+	if monitoring.StallAfter == 0 {
+		return nil
+	}
+
+	interval := monitoring.Interval
+	if interval == 0 {
+		interval = config.DefaultMonitoringInterval
+	}
+
+	log := m.log.With().Str("network", network).Logger()
+	log.Info().Msgf("Checking block production every %s, halted after %s without a block", interval, monitoring.StallAfter)
+
+	reportsCh := make(chan ChainReport, 10)
+
+	go func() {
+		defer close(reportsCh)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			// Unavailable endpoints are reported by the proposal polling
+			if report, err := m.CheckChain(network, time.Now()); err != nil {
+				log.Debug().Err(err).Msg("Failed to check block production")
+			} else {
+				reportsCh <- *report
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return reportsCh
+}
+
+// CheckChain fetches the latest block of a network and reports whether the chain is halted at now
+func (m *ChainMonitor) CheckChain(network string, now time.Time) (*ChainReport, error) {
+	header, err := m.client.GetLatestBlock(network)
+	if err != nil {
+		return nil, err
+	}
+
+	height, err := strconv.ParseInt(header.Height, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid block height %q", header.Height)
+	}
+
+	blockTime := header.Time
+	if blockTime.IsZero() {
+		blockTime = now
+	}
+
+	upgrade, upgradeKnown := m.upgrade(network)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	state, ok := m.chains[network]
+	if !ok {
+		state = &chainState{}
+		m.chains[network] = state
+	}
+
+	report := &ChainReport{}
+
+	if height > state.height {
+		// The first block after a halt is not known, the latest one is close enough
+		if state.halted {
+			report.Resumed = true
+			report.Downtime = blockTime.Sub(state.since)
+		}
+
+		state.height = height
+		state.since = blockTime
+	}
+
+	age := now.Sub(state.since)
+	state.halted = age >= m.config.Networks[network].Monitoring.StallAfter
+
+	report.Height = state.height
+	report.Since = state.since
+	report.Halted = state.halted
+
+	// A chain halts before it commits the block at the upgrade height
+	if state.halted && upgradeKnown && state.height >= upgrade.Height-1 && state.height <= upgrade.Height {
+		report.Upgrade = &upgrade
+	}
+
+	metrics.LatestBlockAge.WithLabelValues(network).Set(max(age, 0).Seconds())
+
+	if state.halted {
+		metrics.ChainHalted.WithLabelValues(network).Set(1)
+	} else {
+		metrics.ChainHalted.WithLabelValues(network).Set(0)
+	}
+
+	return report, nil
+}
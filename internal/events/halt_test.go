@@ -0,0 +1,76 @@
+package events_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rs/zerolog"
+
+	"github.com/hazim1093/zeta-comms/internal/config"
+	"github.com/hazim1093/zeta-comms/internal/events"
+	"github.com/hazim1093/zeta-comms/pkg/zetachain"
+)
+
+var _ = Describe("ChainMonitor", func() {
+	var (
+		client  *zetachain.FakeClient
+		monitor *events.ChainMonitor
+		start   time.Time
+	)
+
+	BeforeEach(func() {
+		cfg := &config.Config{
+			Networks: map[string]config.Network{
+				"mainnet": {Monitoring: config.Monitoring{StallAfter: 2 * time.Minute}},
+			},
+		}
+
+		start = time.Date(2025, 3, 6, 14, 30, 0, 0, time.UTC)
+
+		client = zetachain.NewFakeClient()
+		client.LatestBlock = zetachain.BlockHeader{Height: "1000", Time: start}
+
+		logger := zerolog.Nop()
+		monitor = events.NewChainMonitor(cfg, &logger)
+		monitor.SetClient(client)
+	})
+
+	It("should report a halt without new blocks for stall_after and the downtime once blocks resume", func() {
+		report, err := monitor.CheckChain("mainnet", start.Add(time.Minute))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Halted).To(BeFalse())
+
+		report, err = monitor.CheckChain("mainnet", start.Add(3*time.Minute))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*report).To(Equal(events.ChainReport{Height: 1000, Since: start, Halted: true}))
+
+		client.LatestBlock = zetachain.BlockHeader{Height: "1001", Time: start.Add(10 * time.Minute)}
+
+		report, err = monitor.CheckChain("mainnet", start.Add(10*time.Minute))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Halted).To(BeFalse())
+		Expect(report.Resumed).To(BeTrue())
+		Expect(report.Downtime).To(Equal(10 * time.Minute))
+
+		report, err = monitor.CheckChain("mainnet", start.Add(11*time.Minute))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Resumed).To(BeFalse())
+	})
+
+	It("should recognize the halt at the height of a scheduled upgrade", func() {
+		monitor.ObserveUpgrade("mainnet", "v30", "1001")
+
+		report, err := monitor.CheckChain("mainnet", start.Add(5*time.Minute))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Halted).To(BeTrue())
+		Expect(report.Upgrade).To(Equal(&events.Upgrade{Name: "v30", Height: 1001}))
+
+		monitor.ObserveUpgrade("mainnet", "v31", "5000")
+
+		report, err = monitor.CheckChain("mainnet", start.Add(6*time.Minute))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Halted).To(BeTrue())
+		Expect(report.Upgrade).To(BeNil())
+	})
+})
@@ -0,0 +1,107 @@
+package events
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"sort"
+
+	"github.com/hazim1093/zeta-comms/pkg/zetachain"
+)
+
+// activeStatuses are the statuses in which a proposal can still change
+var activeStatuses = []string{"PROPOSAL_STATUS_DEPOSIT_PERIOD", "PROPOSAL_STATUS_VOTING_PERIOD"}
+
+// proposalCursor remembers what the incremental fetches of a network have seen
+type proposalCursor struct {
+	highestID uint64
+	active    map[string]bool // IDs of the proposals that were in an active status
+}
+
+// getChangedProposals fetches the filtered proposals of a network that may have changed since the
+// previous fetch: proposals newer than the highest known ID, proposals in an active status and
+// proposals that were active before. The first fetch gets all proposals, and partial is false then.
+func (g *GovService) getChangedProposals(network string) (proposals []zetachain.Proposal, partial bool, err error) {
+	g.mu.RLock()
+	cursor := g.cursors[network]
+	g.mu.RUnlock()
+
+	if cursor == nil {
+		response, err := g.client.GetProposals(network, zetachain.ProposalsQuery{})
+		if err != nil {
+			return nil, false, err
+		}
+
+		g.advanceCursor(network, &proposalCursor{}, response.Proposals)
+
+		return g.filterProposals(response.Proposals), false, nil
+	}
+
+	changed := make(map[string]zetachain.Proposal)
+
+	queries := []zetachain.ProposalsQuery{{Reverse: true, AfterID: cursor.highestID}}
+	for _, status := range activeStatuses {
+		queries = append(queries, zetachain.ProposalsQuery{Status: status})
+	}
+
+	for _, query := range queries {
+		response, err := g.client.GetProposals(network, query)
+		if err != nil {
+			return nil, false, err
+		}
+
+		for _, proposal := range response.Proposals {
+			changed[proposal.ProposalId] = proposal
+		}
+	}
+
+	// Proposals that left the active statuses are fetched one by one to learn their outcome. Proposals
+	// that failed to reach the minimum deposit are deleted from the chain and cannot be fetched.
+	for id := range cursor.active {
+		if _, ok := changed[id]; ok {
+			continue
+		}
+
+		proposal, err := g.client.GetProposal(network, id)
+		if errors.Is(err, zetachain.ErrEndpointsUnavailable) {
+			return nil, false, err
+		}
+
+		if err != nil {
+			g.log.Warn().Err(err).Str("network", network).Str("proposal_id", id).Msg("Formerly active proposal not found, no longer tracking it")
+
+			continue
+		}
+
+		changed[id] = *proposal
+	}
+
+	fetched := slices.Collect(maps.Values(changed))
+	sort.Slice(fetched, func(i, j int) bool {
+		return zetachain.ProposalID(fetched[i]) < zetachain.ProposalID(fetched[j])
+	})
+
+	g.advanceCursor(network, cursor, fetched)
+
+	return g.filterProposals(fetched), true, nil
+}
+
+// advanceCursor stores the cursor of a network updated with the fetched proposals
+func (g *GovService) advanceCursor(network string, cursor *proposalCursor, proposals []zetachain.Proposal) {
+	next := &proposalCursor{
+		highestID: cursor.highestID,
+		active:    make(map[string]bool),
+	}
+
+	for _, proposal := range proposals {
+		next.highestID = max(next.highestID, zetachain.ProposalID(proposal))
+
+		if slices.Contains(activeStatuses, proposal.Status) {
+			next.active[proposal.ProposalId] = true
+		}
+	}
+
+	g.mu.Lock()
+	g.cursors[network] = next
+	g.mu.Unlock()
+}
@@ -0,0 +1,281 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hazim1093/zeta-comms/internal/config"
+	"github.com/hazim1093/zeta-comms/internal/metrics"
+	"github.com/hazim1093/zeta-comms/pkg/zetachain"
+	"github.com/rs/zerolog"
+)
+
+// Kinds of node problems found by the NodeMonitor
+const (
+	NodeUnreachable = "unreachable" // The node does not answer
+	NodeBehind      = "behind"      // The node is more than max_lag blocks behind the network
+	NodeStalled     = "stalled"     // The node did not add blocks since the last check while the network did
+	NodeOutdated    = "outdated"    // The node still runs the previous version after an upgrade
+)
+
+// NodeProblemKinds lists all kinds of node problems
+var NodeProblemKinds = []string{NodeUnreachable, NodeBehind, NodeStalled, NodeOutdated}
+
+// NodeProblem is a problem found with a node
+type NodeProblem struct {
+	Kind    string
+	Title   string
+	Details string
+}
+
+// NodeReport holds the result of checking a node, a healthy node has no problems
+type NodeReport struct {
+	Node     string
+	Status   *zetachain.NodeStatus // Nil if the node could not be queried
+	Problems []NodeProblem
+}
+
+// NodeMonitor checks the nodes configured for each network: whether they answer, keep up with
+// the network and run the new version once an upgrade took effect
+type NodeMonitor struct {
+	upgradeSchedule
+
+	client     zetachain.Client
+	nodeClient *zetachain.NodeClient
+	config     *config.Config
+	log        *zerolog.Logger
+
+	mu    sync.Mutex
+	nodes map[string]*nodeState // Keyed by network and node name
+}
+
+// nodeState is what a check remembers about a node for the next one
+type nodeState struct {
+	checked       bool
+	height        int64
+	networkHeight int64
+
+	// The version the node ran before the network reached the height of upgrade
+	upgrade         string
+	previousVersion string
+}
+
+func NewNodeMonitor(cfg *config.Config, logger *zerolog.Logger) *NodeMonitor {
+	return &NodeMonitor{
+		client:     zetachain.NewClient(cfg, logger),
+		nodeClient: zetachain.NewNodeClient(),
+		config:     cfg,
+		log:        logger,
+		nodes:      make(map[string]*nodeState),
+	}
+}
+
+// SetClient replaces the client used to query the height of the networks, e.g. with a fake in tests
+func (m *NodeMonitor) SetClient(client zetachain.Client) {
+	m.client = client
+}
+
+// StartMonitoring checks the nodes of a network every monitoring interval and sends the reports
+// to the returned channel. It returns nil if no nodes are configured for the network.
+func (m *NodeMonitor) StartMonitoring(ctx context.Context, network string) chan []NodeReport {
+	monitoring := m.config.Networks[network].Monitoring
+	if len(monitoring.Nodes) == 0 {
+		return nil
+	}
+
+	interval := monitoring.Interval
+	if interval == 0 {
+		interval = config.DefaultMonitoringInterval
+	}
+
+	m.log.Info().Str("network", network).Msgf("Checking %d nodes every %s", len(monitoring.Nodes), interval)
+
+	reportsCh := make(chan []NodeReport, 10)
+
+	go func() {
+		defer close(reportsCh)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			reportsCh <- m.CheckNodes(network)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return reportsCh
+}
+
+// CheckNodes queries the nodes of a network and reports their problems
+func (m *NodeMonitor) CheckNodes(network string) []NodeReport {
+	monitoring := m.config.Networks[network].Monitoring
+
+	statuses := make([]*zetachain.NodeStatus, len(monitoring.Nodes))
+	errs := make([]error, len(monitoring.Nodes))
+
+	for i, node := range monitoring.Nodes {
+		statuses[i], errs[i] = m.nodeClient.GetNodeStatus(node)
+	}
+
+	networkHeight := m.networkHeight(network, statuses)
+
+	maxLag := monitoring.MaxLag
+	if maxLag == 0 {
+		maxLag = config.DefaultMaxLag
+	}
+
+	upgrade, upgradeKnown := m.upgrade(network)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	reports := make([]NodeReport, len(monitoring.Nodes))
+
+	for i, node := range monitoring.Nodes {
+		status := statuses[i]
+		reports[i] = NodeReport{Node: node.Name, Status: status}
+
+		if errs[i] != nil {
+			metrics.NodeUp.WithLabelValues(network, node.Name).Set(0)
+			reports[i].Problems = []NodeProblem{{
+				Kind:    NodeUnreachable,
+				Title:   fmt.Sprintf("Node %s is unreachable", node.Name),
+				Details: fmt.Sprintf("```\n%s\n```", errs[i]),
+			}}
+
+			continue
+		}
+
+		metrics.NodeUp.WithLabelValues(network, node.Name).Set(1)
+		metrics.NodeLag.WithLabelValues(network, node.Name).Set(float64(max(networkHeight-status.Height, 0)))
+
+		state, ok := m.nodes[network+"/"+node.Name]
+		if !ok {
+			state = &nodeState{}
+			m.nodes[network+"/"+node.Name] = state
+		}
+
+		if lag := networkHeight - status.Height; lag > maxLag {
+			details := fmt.Sprintf("The node is at height %d, the network at %d.", status.Height, networkHeight)
+			if status.Syncing {
+				details += " The node reports that it is catching up."
+			}
+
+			reports[i].Problems = append(reports[i].Problems, NodeProblem{
+				Kind:    NodeBehind,
+				Title:   fmt.Sprintf("Node %s is %d blocks behind", node.Name, lag),
+				Details: details,
+			})
+		}
+
+		if state.checked && status.Height <= state.height && networkHeight > state.networkHeight {
+			reports[i].Problems = append(reports[i].Problems, NodeProblem{
+				Kind:    NodeStalled,
+				Title:   fmt.Sprintf("Node %s is not syncing", node.Name),
+				Details: fmt.Sprintf("The node is stuck at height %d while the network advanced to %d.", status.Height, networkHeight),
+			})
+		}
+
+		if upgradeKnown {
+			if networkHeight < upgrade.Height {
+				state.upgrade = upgrade.Name
+				state.previousVersion = status.Version
+			} else if outdated(status.Version, upgrade, state) {
+				reports[i].Problems = append(reports[i].Problems, NodeProblem{
+					Kind:    NodeOutdated,
+					Title:   fmt.Sprintf("Node %s still runs %s after upgrade %s", node.Name, status.Version, upgrade.Name),
+					Details: fmt.Sprintf("The upgrade took effect at height %d.", upgrade.Height),
+				})
+			}
+		}
+
+		state.checked = true
+		state.height = status.Height
+		state.networkHeight = networkHeight
+	}
+
+	return reports
+}
+
+// networkHeight returns the latest height of a network, from its endpoints or from our nodes if
+// they are ahead or the endpoints cannot be reached
+func (m *NodeMonitor) networkHeight(network string, statuses []*zetachain.NodeStatus) int64 {
+	var height int64
+
+	header, err := m.client.GetLatestBlock(network)
+	if err == nil {
+		height, err = strconv.ParseInt(header.Height, 10, 64)
+	}
+
+	if err != nil {
+		m.log.Debug().Err(err).Str("network", network).Msg("Failed to fetch the network height, comparing the nodes to each other")
+	}
+
+	for _, status := range statuses {
+		if status != nil && status.Height > height {
+			height = status.Height
+		}
+	}
+
+	return height
+}
+
+// versionPattern matches the numeric part of versions and version-like upgrade names, e.g. v30.0.3 or v30
+var versionPattern = regexp.MustCompile(`^v?(\d+(?:\.\d+)*)`)
+
+// outdated reports whether a node version predates an upgrade. Upgrades named after a version
+// are compared to it, e.g. v30.0.3 is up to date for upgrade v30. Otherwise a node is outdated if
+// it reports the version it ran before the network reached the upgrade height.
+func outdated(version string, upgrade Upgrade, state *nodeState) bool {
+	nodeVersion, nodeOK := parseVersion(version)
+	upgradeVersion, upgradeOK := parseVersion(upgrade.Name)
+
+	if nodeOK && upgradeOK {
+		// Missing parts count as 0, e.g. v30 is v30.0.0
+		for i, part := range upgradeVersion {
+			nodePart := 0
+			if i < len(nodeVersion) {
+				nodePart = nodeVersion[i]
+			}
+
+			if nodePart != part {
+				return nodePart < part
+			}
+		}
+
+		return false
+	}
+
+	return state.upgrade == upgrade.Name && state.previousVersion != "" && version == state.previousVersion
+}
+
+func parseVersion(version string) ([]int, bool) {
+	match := versionPattern.FindStringSubmatch(version)
+	if match == nil {
+		return nil, false
+	}
+
+	var parts []int
+
+	for _, part := range strings.Split(match[1], ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, false
+		}
+
+		parts = append(parts, n)
+	}
+
+	return parts, true
+}
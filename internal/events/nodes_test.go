@@ -0,0 +1,178 @@
+package events_test
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rs/zerolog"
+
+	"github.com/hazim1093/zeta-comms/internal/config"
+	"github.com/hazim1093/zeta-comms/internal/events"
+	"github.com/hazim1093/zeta-comms/pkg/zetachain"
+)
+
+// fakeNode serves the status endpoints of the LCD API and the CometBFT RPC of a node
+type fakeNode struct {
+	mu      sync.Mutex
+	version string
+	height  int64
+	syncing bool
+}
+
+func (n *fakeNode) set(version string, height int64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.version, n.height = version, height
+}
+
+func (n *fakeNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	switch r.URL.Path {
+	case "/cosmos/base/tendermint/v1beta1/node_info":
+		fmt.Fprintf(w, `{"default_node_info": {"network": "zetachain_7000-1", "moniker": "node"}, "application_version": {"version": %q}}`, n.version)
+	case "/cosmos/base/tendermint/v1beta1/syncing":
+		fmt.Fprintf(w, `{"syncing": %t}`, n.syncing)
+	case "/cosmos/base/tendermint/v1beta1/blocks/latest":
+		fmt.Fprintf(w, `{"block": {"header": {"height": "%d", "time": "2025-03-06T14:30:00Z"}}}`, n.height)
+	case "/status":
+		fmt.Fprintf(w, `{"result": {"node_info": {"network": "zetachain_7000-1"}, "sync_info": {"latest_block_height": "%d", "catching_up": %t}}}`, n.height, n.syncing)
+	case "/abci_info":
+		fmt.Fprintf(w, `{"result": {"response": {"version": %q}}}`, n.version)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+var _ = Describe("NodeMonitor", func() {
+	var (
+		cfg      *config.Config
+		client   *zetachain.FakeClient
+		monitor  *events.NodeMonitor
+		lcdNode  *fakeNode
+		rpcNode  *fakeNode
+		problems func() map[string][]string
+	)
+
+	// serve starts a server for a fake node and returns its URL
+	serve := func(node *fakeNode) url.URL {
+		server := httptest.NewServer(node)
+		DeferCleanup(server.Close)
+
+		serverURL, _ := url.Parse(server.URL)
+
+		return *serverURL
+	}
+
+	BeforeEach(func() {
+		lcdNode = &fakeNode{version: "v29.1.0", height: 1000}
+		rpcNode = &fakeNode{version: "v29.1.0", height: 1000}
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		downURL, _ := url.Parse("http://" + listener.Addr().String())
+		Expect(listener.Close()).To(Succeed())
+
+		cfg = &config.Config{
+			Networks: map[string]config.Network{
+				"mainnet": {Monitoring: config.Monitoring{
+					MaxLag: 10,
+					Nodes: []config.Node{
+						{Name: "lcd-node", ApiUrl: serve(lcdNode)},
+						{Name: "rpc-node", RPCURL: serve(rpcNode)},
+						{Name: "down-node", ApiUrl: *downURL},
+					},
+				}},
+			},
+		}
+
+		client = zetachain.NewFakeClient()
+		client.LatestBlock = zetachain.BlockHeader{Height: "1000"}
+
+		logger := zerolog.Nop()
+		monitor = events.NewNodeMonitor(cfg, &logger)
+		monitor.SetClient(client)
+
+		// problems checks the nodes and returns the kinds of problems per node
+		problems = func() map[string][]string {
+			kinds := make(map[string][]string)
+			for _, report := range monitor.CheckNodes("mainnet") {
+				kinds[report.Node] = []string{}
+				for _, problem := range report.Problems {
+					kinds[report.Node] = append(kinds[report.Node], problem.Kind)
+				}
+			}
+
+			return kinds
+		}
+	})
+
+	It("should report unreachable nodes and nodes that fall behind or stop syncing", func() {
+		Expect(problems()).To(Equal(map[string][]string{
+			"lcd-node":  {},
+			"rpc-node":  {},
+			"down-node": {events.NodeUnreachable},
+		}))
+
+		lcdNode.set("v29.1.0", 1020)
+		client.LatestBlock.Height = "1020"
+
+		Expect(problems()).To(Equal(map[string][]string{
+			"lcd-node":  {},
+			"rpc-node":  {events.NodeBehind, events.NodeStalled},
+			"down-node": {events.NodeUnreachable},
+		}))
+
+		rpcNode.set("v29.1.0", 1015)
+
+		Expect(problems()).To(Equal(map[string][]string{
+			"lcd-node":  {},
+			"rpc-node":  {},
+			"down-node": {events.NodeUnreachable},
+		}))
+	})
+
+	It("should report nodes that still run the previous version once the network reached the upgrade height", func() {
+		monitor.ObserveUpgrade("mainnet", "v30", "1010")
+		monitor.ObserveUpgrade("mainnet", "v12", "500")
+
+		Expect(problems()["rpc-node"]).To(BeEmpty())
+
+		// The node without an upgrade halts one block before the upgrade height
+		lcdNode.set("v30.0.1", 1012)
+		rpcNode.set("v29.1.0", 1009)
+		client.LatestBlock.Height = "1012"
+
+		reports := monitor.CheckNodes("mainnet")
+		Expect(reports[0].Problems).To(BeEmpty())
+		Expect(reports[1].Problems).To(ContainElement(events.NodeProblem{
+			Kind:    events.NodeOutdated,
+			Title:   "Node rpc-node still runs v29.1.0 after upgrade v30",
+			Details: "The upgrade took effect at height 1010.",
+		}))
+	})
+
+	It("should compare to the version before the upgrade if the upgrade is not named after a version", func() {
+		monitor.ObserveUpgrade("mainnet", "athens-upgrade", "1010")
+
+		Expect(problems()["lcd-node"]).To(BeEmpty())
+
+		lcdNode.set("v30.0.1", 1012)
+		rpcNode.set("v29.1.0", 1012)
+		client.LatestBlock.Height = "1012"
+
+		kinds := problems()
+		Expect(kinds["lcd-node"]).To(BeEmpty())
+		Expect(kinds["rpc-node"]).To(Equal([]string{events.NodeOutdated}))
+	})
+})
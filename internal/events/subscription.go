@@ -0,0 +1,145 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/hazim1093/zeta-comms/internal/metrics"
+	"github.com/hazim1093/zeta-comms/pkg/zetachain"
+)
+
+const (
+	minReconnectDelay = time.Second
+	maxReconnectDelay = time.Minute
+)
+
+// subscribeProposals watches a network through the governance events of a CometBFT node and fetches
+// only the proposals affected by each event. All proposals are fetched whenever the subscription is
+// (re)established to catch up on missed events, and polled every pollInterval, more often while it is
+// down. The polls while subscribed keep a node that stops sending events from going unnoticed.
+func (g *GovService) subscribeProposals(ctx context.Context, network string, pollInterval time.Duration, updateCh chan ProposalUpdate) {
+	log := g.log.With().Str("network", network).Logger()
+
+	defer close(updateCh)
+
+	rpcURL := g.config.Networks[network].Source.RPCURL
+	delay := minReconnectDelay
+
+	var lastPoll time.Time
+
+	for {
+		subscribedCtx, cancel := context.WithCancel(ctx)
+		polling := make(chan struct{})
+		subscribed := false
+
+		err := zetachain.SubscribeProposalEvents(ctx, rpcURL,
+			func() {
+				log.Info().Msg("Subscribed to governance events")
+				g.setSubscribed(network, true)
+
+				subscribed = true
+				delay = minReconnectDelay
+				lastPoll = time.Now()
+				g.poll(network, updateCh)
+
+				go func() {
+					defer close(polling)
+
+					g.pollUntil(subscribedCtx, network, pollInterval, &lastPoll, updateCh)
+				}()
+			},
+			func(event zetachain.ProposalEvent) {
+				log.Debug().Str("event", event.Type).Str("proposal_id", event.ProposalID).Msg("Governance event received")
+				g.fetchProposal(network, event.ProposalID, updateCh)
+			},
+		)
+
+		// The polls while subscribed must be done before lastPoll is read or updateCh is closed
+		cancel()
+
+		if subscribed {
+			<-polling
+		}
+
+		g.setSubscribed(network, false)
+
+		if ctx.Err() != nil {
+			log.Info().Msg("Stopping event subscription due to context cancellation")
+
+			return
+		}
+
+		log.Warn().Err(err).Dur("retry_in", delay).Msg("Event subscription unavailable, polling until reconnected")
+
+		// Poll right away unless the last poll is recent, e.g. when reconnecting fails repeatedly
+		if time.Since(lastPoll) >= pollInterval {
+			lastPoll = time.Now()
+			g.poll(network, updateCh)
+		}
+
+		if !g.pollFor(ctx, network, pollInterval, delay, &lastPoll, updateCh) {
+			return
+		}
+
+		delay = min(delay*2, maxReconnectDelay)
+	}
+}
+
+// pollFor polls every pollInterval until wait has passed, and reports false if ctx is done first
+func (g *GovService) pollFor(ctx context.Context, network string, pollInterval time.Duration, wait time.Duration, lastPoll *time.Time, updateCh chan ProposalUpdate) bool {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-timer.C:
+			return true
+		case <-ticker.C:
+			*lastPoll = time.Now()
+			g.poll(network, updateCh)
+		}
+	}
+}
+
+// pollUntil polls every pollInterval until ctx is done
+func (g *GovService) pollUntil(ctx context.Context, network string, pollInterval time.Duration, lastPoll *time.Time, updateCh chan ProposalUpdate) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			*lastPoll = time.Now()
+			g.poll(network, updateCh)
+		}
+	}
+}
+
+// fetchProposal sends a proposal affected by an event as a partial update, if it matches the filters
+func (g *GovService) fetchProposal(network string, proposalID string, updateCh chan ProposalUpdate) {
+	proposal, err := g.client.GetProposal(network, proposalID)
+	if err != nil {
+		g.log.Error().Err(err).Str("network", network).Str("proposal_id", proposalID).Msg("failed to get proposal")
+		updateCh <- ProposalUpdate{Error: err}
+
+		return
+	}
+
+	updateCh <- ProposalUpdate{Proposals: g.filterProposals([]zetachain.Proposal{*proposal}), Partial: true}
+}
+
+func (g *GovService) setSubscribed(network string, subscribed bool) {
+	value := 0.0
+	if subscribed {
+		value = 1
+	}
+
+	metrics.EventSubscriptionUp.WithLabelValues(network).Set(value)
+}
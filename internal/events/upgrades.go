@@ -0,0 +1,47 @@
+package events
+
+import (
+	"strconv"
+	"sync"
+)
+
+// Upgrade is a software upgrade scheduled by a passed proposal
+type Upgrade struct {
+	Name   string
+	Height int64
+}
+
+// upgradeSchedule holds the latest upgrade of each network, learned from passed proposals
+type upgradeSchedule struct {
+	mu       sync.Mutex
+	upgrades map[string]Upgrade
+}
+
+// ObserveUpgrade records the upgrade of a passed proposal. Only the upgrade with the highest height
+// is kept, so that earlier upgrades do not count.
+func (s *upgradeSchedule) ObserveUpgrade(network string, name string, height string) {
+	upgradeHeight, err := strconv.ParseInt(height, 10, 64)
+	if err != nil || name == "" {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.upgrades == nil {
+		s.upgrades = make(map[string]Upgrade)
+	}
+
+	if upgradeHeight > s.upgrades[network].Height {
+		s.upgrades[network] = Upgrade{Name: name, Height: upgradeHeight}
+	}
+}
+
+func (s *upgradeSchedule) upgrade(network string) (Upgrade, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	upgrade, ok := s.upgrades[network]
+
+	return upgrade, ok
+}
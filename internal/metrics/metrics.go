@@ -0,0 +1,108 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "zeta_comms"
+
+var (
+	// Polls counts proposal polls per network, successful or not
+	Polls = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "polls_total",
+		Help:      "Number of proposal polls per network.",
+	}, []string{"network"})
+
+	// PollErrors counts failed proposal polls per network
+	PollErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "poll_errors_total",
+		Help:      "Number of failed proposal polls per network.",
+	}, []string{"network"})
+
+	// LastSuccessfulPoll records the unix time of the last successful poll per network
+	LastSuccessfulPoll = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "last_successful_poll_timestamp_seconds",
+		Help:      "Unix timestamp of the last successful proposal poll per network.",
+	}, []string{"network"})
+
+	// ProposalsSeen counts new proposals detected per network
+	ProposalsSeen = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "proposals_seen_total",
+		Help:      "Number of new proposals detected per network.",
+	}, []string{"network"})
+
+	// NotificationsSent counts notifications delivered per platform and audience
+	NotificationsSent = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "notifications_sent_total",
+		Help:      "Number of notifications sent successfully per platform and audience.",
+	}, []string{"platform", "audience"})
+
+	// NotificationsFailed counts notifications that could not be delivered per platform and audience
+	NotificationsFailed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "notifications_failed_total",
+		Help:      "Number of notifications that failed to send per platform and audience.",
+	}, []string{"platform", "audience"})
+
+	// Broadcasts counts broadcast messages received
+	Broadcasts = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "broadcasts_total",
+		Help:      "Number of broadcast messages processed.",
+	})
+
+	// EndpointHealthy reports whether an LCD endpoint is currently used
+	EndpointHealthy = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "lcd_endpoint_healthy",
+		Help:      "Whether an LCD endpoint is healthy (1) or skipped after failures (0).",
+	}, []string{"network", "endpoint"})
+
+	// EndpointLatency records the moving average latency of successful requests to an LCD endpoint
+	EndpointLatency = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "lcd_endpoint_latency_seconds",
+		Help:      "Moving average latency of successful requests to an LCD endpoint.",
+	}, []string{"network", "endpoint"})
+
+	// EventSubscriptionUp reports whether the CometBFT event subscription of a network is live
+	EventSubscriptionUp = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "event_subscription_up",
+		Help:      "Whether the CometBFT event subscription of a network is live (1) or polling is used (0).",
+	}, []string{"network"})
+
+	// NodeUp reports whether a monitored node answered the last check
+	NodeUp = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "node_up",
+		Help:      "Whether a monitored node answered the last check (1) or not (0).",
+	}, []string{"network", "node"})
+
+	// NodeLag records how many blocks a monitored node is behind the network
+	NodeLag = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "node_lag_blocks",
+		Help:      "Number of blocks a monitored node is behind the network.",
+	}, []string{"network", "node"})
+
+	// LatestBlockAge records how long ago the latest block of a network was produced
+	LatestBlockAge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "latest_block_age_seconds",
+		Help:      "Seconds since the latest block of a network was produced.",
+	}, []string{"network"})
+
+	// ChainHalted reports whether a network stopped producing blocks
+	ChainHalted = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "chain_halted",
+		Help:      "Whether a network produced no block for stall_after (1) or not (0).",
+	}, []string{"network"})
+)
@@ -1,90 +1,83 @@
 package notifications
 
 import (
+	"errors"
 	"fmt"
-	"strconv"
+	"math/big"
+	"net/url"
+	"strings"
+	"sync"
 
+	"github.com/hazim1093/zeta-comms/internal/config"
+	"github.com/hazim1093/zeta-comms/pkg/amount"
 	"github.com/hazim1093/zeta-comms/pkg/models"
 	"github.com/hazim1093/zeta-comms/pkg/zetachain"
 )
 
-func MapFromProposal(network string, proposal zetachain.Proposal) models.Notification {
-	// Extract upgrade information if available
-	var upgradeName, targetHeight string
+// notAvailable is shown in place of values that could not be parsed
+const notAvailable = "n/a"
 
-	for _, msg := range proposal.Messages {
-		if msg.Data.Plan.Name != "" {
-			upgradeName = msg.Data.Plan.Name
-		}
+// Mapper maps proposals to notifications, formatting amounts as configured
+type Mapper struct {
+	display config.Display
 
-		if msg.Data.Plan.Height != "" {
-			targetHeight = msg.Data.Plan.Height
-		}
+	mu     sync.RWMutex
+	denoms map[string]config.Denom
+}
+
+// NewMapper creates a mapper with the display settings and denominations of the configuration
+func NewMapper(cfg *config.Config) *Mapper {
+	denoms := make(map[string]config.Denom, len(cfg.Networks))
+	for name, network := range cfg.Networks {
+		denoms[name] = network.Denom.WithDefaults()
 	}
 
-	// Parse vote counts to float64 for calculations
-	yesCount, _ := strconv.ParseFloat(proposal.FinalTallyResult.YesCount, 64)
-	noCount, _ := strconv.ParseFloat(proposal.FinalTallyResult.NoCount, 64)
-	abstainCount, _ := strconv.ParseFloat(proposal.FinalTallyResult.AbstainCount, 64)
-	vetoCount, _ := strconv.ParseFloat(proposal.FinalTallyResult.NoWithVetoCount, 64)
-
-	// Calculate total votes
-	totalVotes := yesCount + noCount + abstainCount + vetoCount
-
-	// Convert from azeta to ZETA (1 ZETA = 10^18 azeta)
-	divisor := 1000000000000000000.0 // 10^18
-	yesZeta := yesCount / divisor
-	noZeta := noCount / divisor
-	abstainZeta := abstainCount / divisor
-	vetoZeta := vetoCount / divisor
-	totalZeta := totalVotes / divisor
-
-	// Convert to millions for display
-	yesMillions := yesZeta / 1000000
-	noMillions := noZeta / 1000000
-	abstainMillions := abstainZeta / 1000000
-	vetoMillions := vetoZeta / 1000000
-	totalMillions := totalZeta / 1000000
-
-	// Calculate percentages (avoid division by zero)
-	var yesPercentage, noPercentage, abstainPercentage, vetoPercentage float64
-	if totalVotes > 0 {
-		yesPercentage = (yesCount / totalVotes) * 100
-		noPercentage = (noCount / totalVotes) * 100
-		abstainPercentage = (abstainCount / totalVotes) * 100
-		vetoPercentage = (vetoCount / totalVotes) * 100
+	return &Mapper{
+		display: cfg.Display,
+		denoms:  denoms,
 	}
+}
 
-	// Format vote strings with both values and percentages
-	yesVotesStr := fmt.Sprintf("%.3fM (%.2f%%)", yesMillions, yesPercentage)
-	noVotesStr := fmt.Sprintf("%.3fM (%.2f%%)", noMillions, noPercentage)
-	abstainVotesStr := fmt.Sprintf("%.3fM (%.2f%%)", abstainMillions, abstainPercentage)
-	vetoVotesStr := fmt.Sprintf("%.3fM (%.2f%%)", vetoMillions, vetoPercentage)
-	totalVotesStr := fmt.Sprintf("%.3fM", totalMillions)
-
-	// Convert azeta to ZETA in deposit information (1 ZETA = 10^18 azeta)
-	// Note: This conversion is only for deposit amounts, not for vote counts
-	convertedDeposit := make([]zetachain.Deposit, 0, len(proposal.TotalDeposit))
-
-	for _, deposit := range proposal.TotalDeposit {
-		if deposit.Denom == "azeta" {
-			// Convert azeta to ZETA (1 ZETA = 10^18 azeta)
-			amount, _ := strconv.ParseFloat(deposit.Amount, 64)
-			zetaAmount := amount / 1000000000000000000 // 10^18
-
-			// Create new deposit with converted amount
-			convertedDeposit = append(convertedDeposit, zetachain.Deposit{
-				Denom:  "ZETA",
-				Amount: fmt.Sprintf("%.2f", zetaAmount),
-			})
-		} else {
-			// Keep other denominations as is
-			convertedDeposit = append(convertedDeposit, deposit)
-		}
+// MapFromProposal maps a ZetaChain proposal using the default display settings, ignoring invalid amounts
+func MapFromProposal(network string, proposal zetachain.Proposal) models.Notification {
+	notification, _ := NewMapper(&config.Config{Display: config.DefaultDisplay}).Map(network, proposal)
+
+	return notification
+}
+
+// SetDenom replaces the denomination of a network, e.g. with one fetched from the chain
+func (m *Mapper) SetDenom(network string, denom config.Denom) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.denoms[network] = denom
+}
+
+// denom returns the denomination of a network, networks without one use ZetaChain's
+func (m *Mapper) denom(network string) config.Denom {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if denom, ok := m.denoms[network]; ok {
+		return denom
 	}
 
+	return config.DefaultDenom
+}
+
+// Map maps a proposal to a notification. Amounts that cannot be parsed are shown as "n/a" or
+// left as reported by the chain, and are returned as an error alongside the notification.
+func (m *Mapper) Map(network string, proposal zetachain.Proposal) (models.Notification, error) {
+	upgradeName, targetHeight := UpgradePlan(proposal)
+
+	denom := m.denom(network)
+	tally, tallyErr := m.formatTally(proposal.FinalTallyResult, denom)
+	deposit, depositErr := m.formatDeposit(proposal.TotalDeposit, denom)
+	messages, messagesErr := m.mapMessages(proposal.Messages, denom)
+
 	// Create and return the notification with enhanced information
 	return models.Notification{
+		EventType:     models.EventProposal,
 		Network:       network,
 		ProposalId:    proposal.ProposalId,
 		Title:         proposal.Title,
@@ -92,15 +85,204 @@ func MapFromProposal(network string, proposal zetachain.Proposal) models.Notific
 		Status:        proposal.Status,
 		UpgradeName:   upgradeName,
 		TargetHeight:  targetHeight,
-		YesVotes:      yesVotesStr,
-		NoVotes:       noVotesStr,
-		AbstainVotes:  abstainVotesStr,
-		VetoVotes:     vetoVotesStr,
-		TotalVotes:    totalVotesStr,
+		YesVotes:      tally.yes,
+		NoVotes:       tally.no,
+		AbstainVotes:  tally.abstain,
+		VetoVotes:     tally.veto,
+		TotalVotes:    tally.total,
 		SubmitTime:    proposal.SubmitTime,
 		VotingEndTime: proposal.VotingEndTime,
 		Expedited:     proposal.Expedited,
 		FailedReason:  proposal.FailedReason,
-		TotalDeposit:  convertedDeposit,
+		TotalDeposit:  deposit,
+		Messages:      messages,
+	}, errors.Join(tallyErr, depositErr, messagesErr)
+}
+
+// mapMessages describes the messages of a proposal with the key fields of their types. Amounts are
+// converted to the display denom. Messages whose fields cannot be decoded are listed without them.
+func (m *Mapper) mapMessages(messages []zetachain.Message, denom config.Denom) ([]models.ProposalMessage, error) {
+	if len(messages) == 0 {
+		return nil, nil
+	}
+
+	mapped := make([]models.ProposalMessage, 0, len(messages))
+
+	var errs []error
+
+	for _, msg := range messages {
+		messageFields, err := msg.Fields()
+		if err != nil {
+			errs = append(errs, err)
+		}
+
+		fields := make([]models.MessageField, 0, len(messageFields))
+		for _, field := range messageFields {
+			value := field.Value
+
+			if field.Coins != nil {
+				coins, err := m.formatDeposit(field.Coins, denom)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("%s: %w", msg.Type, err))
+				}
+
+				value = zetachain.FormatCoins(coins)
+			}
+
+			fields = append(fields, models.MessageField{Name: field.Name, Value: value})
+		}
+
+		mapped = append(mapped, models.ProposalMessage{Type: msg.Type, Fields: fields})
+	}
+
+	return mapped, errors.Join(errs...)
+}
+
+// UpgradePlan returns the name and height of the software upgrade a proposal schedules, if any
+func UpgradePlan(proposal zetachain.Proposal) (name string, height string) {
+	for _, msg := range proposal.Messages {
+		if msg.Data.Plan.Name != "" {
+			name = msg.Data.Plan.Name
+		}
+
+		if msg.Data.Plan.Height != "" {
+			height = msg.Data.Plan.Height
+		}
+	}
+
+	return name, height
+}
+
+// formattedTally holds the display strings of a tally result
+type formattedTally struct {
+	yes, no, abstain, veto, total string
+}
+
+// formatTally formats each vote option as "<amount><unit> (<percent>%)", e.g. "1.234M (55.56%)".
+// If any count is invalid, the total and all percentages are unknown as well.
+func (m *Mapper) formatTally(result zetachain.TallyResult, denom config.Denom) (formattedTally, error) {
+	counts := []string{result.YesCount, result.NoCount, result.AbstainCount, result.NoWithVetoCount}
+	values := make([]*big.Int, len(counts))
+
+	var errs []error
+
+	for i, count := range counts {
+		value, err := amount.Parse(count)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("tally: %w", err))
+		}
+
+		values[i] = value
+	}
+
+	tallyFormat := amount.Format{Precision: m.display.TallyPrecision, ThousandsSeparator: m.display.ThousandsSeparator}
+	percentFormat := amount.Format{Precision: m.display.PercentPrecision, ThousandsSeparator: m.display.ThousandsSeparator}
+
+	unit := denom.TallyUnit
+	if unit == "none" {
+		unit = ""
+	}
+
+	exponent := denom.Exponent + config.TallyUnits[denom.TallyUnit]
+
+	var total *big.Int
+	if len(errs) == 0 {
+		total = amount.Sum(values...)
+	}
+
+	formatted := make([]string, len(values))
+	for i, value := range values {
+		switch {
+		case value == nil:
+			formatted[i] = notAvailable
+		case total == nil:
+			formatted[i] = fmt.Sprintf("%s%s (%s)", amount.Decimal(value, exponent, tallyFormat), unit, notAvailable)
+		default:
+			formatted[i] = fmt.Sprintf("%s%s (%s%%)", amount.Decimal(value, exponent, tallyFormat), unit, amount.Percent(value, total, percentFormat))
+		}
+	}
+
+	totalVotes := notAvailable
+	if total != nil {
+		totalVotes = amount.Decimal(total, exponent, tallyFormat) + unit
+	}
+
+	return formattedTally{
+		yes:     formatted[0],
+		no:      formatted[1],
+		abstain: formatted[2],
+		veto:    formatted[3],
+		total:   totalVotes,
+	}, errors.Join(errs...)
+}
+
+// formatDeposit converts deposits in the base denom to the display denom, e.g. azeta to ZETA.
+// Other denominations and invalid amounts are kept as is.
+func (m *Mapper) formatDeposit(deposits []zetachain.Deposit, denom config.Denom) ([]zetachain.Deposit, error) {
+	format := amount.Format{Precision: m.display.Precision, ThousandsSeparator: m.display.ThousandsSeparator}
+	converted := make([]zetachain.Deposit, 0, len(deposits))
+
+	var errs []error
+
+	for _, deposit := range deposits {
+		if deposit.Denom != denom.Base {
+			converted = append(converted, deposit)
+
+			continue
+		}
+
+		value, err := amount.Parse(deposit.Amount)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("deposit: %w", err))
+			converted = append(converted, deposit)
+
+			continue
+		}
+
+		converted = append(converted, zetachain.Deposit{
+			Denom:  denom.Display,
+			Amount: amount.Decimal(value, denom.Exponent, format),
+		})
+	}
+
+	return converted, errors.Join(errs...)
+}
+
+// DenomFromMetadata takes the display denom and exponent of a denomination from the metadata of
+// the bank module. The symbol, e.g. ZETA, is preferred over the display unit name, e.g. zeta.
+func DenomFromMetadata(denom config.Denom, metadatas []zetachain.DenomMetadata) (config.Denom, error) {
+	for _, metadata := range metadatas {
+		if metadata.Base != denom.Base {
+			continue
+		}
+
+		exponent, ok := metadata.DisplayExponent()
+		if !ok || exponent < 0 {
+			return denom, fmt.Errorf("metadata of %s has no unit for its display denom %q", denom.Base, metadata.Display)
+		}
+
+		if exponent > config.MaxExponent {
+			return denom, fmt.Errorf("exponent %d of %s exceeds the supported maximum of %d", exponent, denom.Base, config.MaxExponent)
+		}
+
+		denom.Exponent = exponent
+		denom.Display = metadata.Display
+
+		if metadata.Symbol != "" {
+			denom.Display = metadata.Symbol
+		}
+
+		return denom, nil
+	}
+
+	return denom, fmt.Errorf("no metadata registered for %s", denom.Base)
+}
+
+// ProposalLink fills the proposal ID into a link template, an empty template yields no link
+func ProposalLink(link string, proposalID string) string {
+	if link == "" {
+		return ""
 	}
+
+	return strings.ReplaceAll(link, "{id}", url.PathEscape(proposalID))
 }
@@ -1,10 +1,13 @@
 package notifications_test
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
+	"github.com/hazim1093/zeta-comms/internal/config"
 	"github.com/hazim1093/zeta-comms/internal/notifications"
+	"github.com/hazim1093/zeta-comms/pkg/models"
 	"github.com/hazim1093/zeta-comms/pkg/zetachain"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -143,6 +146,34 @@ var _ = Describe("Mapper", func() {
 			})
 		})
 
+		Context("with messages of other types", func() {
+			BeforeEach(func() {
+				var spend zetachain.Message
+				Expect(json.Unmarshal([]byte(`{
+					"@type": "/cosmos.distribution.v1beta1.MsgCommunityPoolSpend",
+					"recipient": "zeta1recipient",
+					"amount": [{"denom": "azeta", "amount": "2500000000000000000"}]
+				}`), &spend)).To(Succeed())
+
+				proposal.Messages = []zetachain.Message{spend, {Type: "/cosmos.gov.v1beta1.MsgVote"}}
+			})
+
+			It("should list every message with its fields, converting amounts to the display denom", func() {
+				result := notifications.MapFromProposal(network, proposal)
+
+				Expect(result.Messages).To(Equal([]models.ProposalMessage{
+					{
+						Type: "/cosmos.distribution.v1beta1.MsgCommunityPoolSpend",
+						Fields: []models.MessageField{
+							{Name: "Recipient", Value: "zeta1recipient"},
+							{Name: "Amount", Value: "2.50 ZETA"},
+						},
+					},
+					{Type: "/cosmos.gov.v1beta1.MsgVote", Fields: []models.MessageField{}},
+				}))
+			})
+		})
+
 		Context("with vote calculations", func() {
 			It("should correctly calculate vote percentages", func() {
 				result := notifications.MapFromProposal(network, proposal)
@@ -290,15 +321,24 @@ var _ = Describe("Mapper", func() {
 				}
 			})
 
-			It("should handle invalid vote counts gracefully", func() {
+			It("should show invalid counts and the values depending on them as unavailable", func() {
 				result := notifications.MapFromProposal(network, proposal)
 
-				// All should be zero since parsing fails
-				Expect(result.YesVotes).To(Equal("0.000M (0.00%)"))
-				Expect(result.NoVotes).To(Equal("0.000M (0.00%)"))
-				Expect(result.AbstainVotes).To(Equal("0.000M (0.00%)"))
-				Expect(result.VetoVotes).To(Equal("0.000M (0.00%)"))
-				Expect(result.TotalVotes).To(Equal("0.000M"))
+				Expect(result.YesVotes).To(Equal("n/a"))
+				Expect(result.NoVotes).To(Equal("n/a"))
+				Expect(result.AbstainVotes).To(Equal("n/a"))
+				Expect(result.VetoVotes).To(Equal("n/a"))
+				Expect(result.TotalVotes).To(Equal("n/a"))
+			})
+
+			It("should keep valid counts but not derive a total from partial data", func() {
+				proposal.FinalTallyResult.YesCount = "1000000000000000000000000"
+
+				result, err := notifications.NewMapper(&config.Config{Display: config.DefaultDisplay}).Map(network, proposal)
+				Expect(err).To(MatchError(ContainSubstring(`invalid amount "not_a_number"`)))
+				Expect(result.YesVotes).To(Equal("1.000M (n/a)"))
+				Expect(result.NoVotes).To(Equal("n/a"))
+				Expect(result.TotalVotes).To(Equal("n/a"))
 			})
 		})
 
@@ -312,13 +352,121 @@ var _ = Describe("Mapper", func() {
 				}
 			})
 
-			It("should handle invalid deposit amounts gracefully", func() {
-				result := notifications.MapFromProposal(network, proposal)
+			It("should keep invalid deposit amounts as reported and return an error", func() {
+				result, err := notifications.NewMapper(&config.Config{Display: config.DefaultDisplay}).Map(network, proposal)
+				Expect(err).To(MatchError(ContainSubstring(`invalid amount "invalid_amount"`)))
 
 				Expect(result.TotalDeposit).To(HaveLen(1))
-				Expect(result.TotalDeposit[0].Denom).To(Equal("ZETA"))
-				Expect(result.TotalDeposit[0].Amount).To(Equal("0.00"))
+				Expect(result.TotalDeposit[0].Denom).To(Equal("azeta"))
+				Expect(result.TotalDeposit[0].Amount).To(Equal("invalid_amount"))
+			})
+		})
+
+		Context("with amounts beyond float64 precision", func() {
+			BeforeEach(func() {
+				proposal.FinalTallyResult = zetachain.TallyResult{
+					YesCount:        "123456789123456789123456789", // 123,456,789.123456789123456789 ZETA
+					NoCount:         "1",
+					AbstainCount:    "0",
+					NoWithVetoCount: "0",
+				}
+				proposal.TotalDeposit = []zetachain.Deposit{
+					{
+						Denom:  "azeta",
+						Amount: "9007199254740993000000000000005", // 9,007,199,254,740.993000000000000005 ZETA
+					},
+				}
+			})
+
+			It("should format exact values with the configured display settings", func() {
+				cfg := &config.Config{Display: config.Display{
+					Precision:          3,
+					TallyPrecision:     4,
+					PercentPrecision:   4,
+					ThousandsSeparator: ",",
+				}}
+
+				result, err := notifications.NewMapper(cfg).Map(network, proposal)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(result.YesVotes).To(Equal("123.4568M (100.0000%)"))
+				Expect(result.NoVotes).To(Equal("0.0000M (0.0000%)"))
+				Expect(result.TotalVotes).To(Equal("123.4568M"))
+				Expect(result.TotalDeposit[0].Amount).To(Equal("9,007,199,254,740.993"))
+			})
+		})
+	})
+
+	Describe("Map", func() {
+		var cfg *config.Config
+
+		BeforeEach(func() {
+			cfg = &config.Config{
+				Display: config.DefaultDisplay,
+				Networks: map[string]config.Network{
+					"cosmoshub": {Denom: config.Denom{Base: "uatom", Display: "ATOM", Exponent: 6, TallyUnit: "K"}},
+				},
+			}
+
+			proposal.FinalTallyResult = zetachain.TallyResult{
+				YesCount:        "3000000000", // 3,000 ATOM
+				NoCount:         "1000000000", // 1,000 ATOM
+				AbstainCount:    "0",
+				NoWithVetoCount: "0",
+			}
+			proposal.TotalDeposit = []zetachain.Deposit{
+				{Denom: "uatom", Amount: "250000000"},
+				{Denom: "azeta", Amount: "1000000000000000000"},
+			}
+		})
+
+		It("should use the denomination configured for the network", func() {
+			result, err := notifications.NewMapper(cfg).Map("cosmoshub", proposal)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result.YesVotes).To(Equal("3.000K (75.00%)"))
+			Expect(result.TotalVotes).To(Equal("4.000K"))
+			Expect(result.TotalDeposit).To(Equal([]zetachain.Deposit{
+				{Denom: "ATOM", Amount: "250.00"},
+				{Denom: "azeta", Amount: "1000000000000000000"},
+			}))
+		})
+
+		It("should use ZetaChain's denomination for networks without one", func() {
+			result, err := notifications.NewMapper(cfg).Map("testnet", proposal)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result.YesVotes).To(Equal("0.000M (75.00%)"))
+			Expect(result.TotalDeposit[1]).To(Equal(zetachain.Deposit{Denom: "ZETA", Amount: "1.00"}))
+		})
+
+		It("should use denomination metadata from the chain", func() {
+			denom, err := notifications.DenomFromMetadata(config.Denom{Base: "uatom", TallyUnit: "none"}, []zetachain.DenomMetadata{
+				{Base: "azeta", Display: "zeta", DenomUnits: []zetachain.DenomUnit{{Denom: "zeta", Exponent: 18}}},
+				{Base: "uatom", Display: "atom", DenomUnits: []zetachain.DenomUnit{{Denom: "uatom"}, {Denom: "atom", Exponent: 6}}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(denom).To(Equal(config.Denom{Base: "uatom", Display: "atom", Exponent: 6, TallyUnit: "none"}))
+
+			mapper := notifications.NewMapper(cfg)
+			mapper.SetDenom("cosmoshub", denom)
+
+			result, err := mapper.Map("cosmoshub", proposal)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.NoVotes).To(Equal("1000.000 (25.00%)"))
+			Expect(result.TotalDeposit[0]).To(Equal(zetachain.Deposit{Denom: "atom", Amount: "250.00"}))
+		})
+
+		It("should fail for denominations without metadata", func() {
+			_, err := notifications.DenomFromMetadata(config.Denom{Base: "uosmo"}, nil)
+			Expect(err).To(MatchError("no metadata registered for uosmo"))
+		})
+
+		It("should fail for exponents the configuration would reject", func() {
+			_, err := notifications.DenomFromMetadata(config.Denom{Base: "ufoo"}, []zetachain.DenomMetadata{
+				{Base: "ufoo", Display: "foo", DenomUnits: []zetachain.DenomUnit{{Denom: "foo", Exponent: 40}}},
 			})
+			Expect(err).To(MatchError("exponent 40 of ufoo exceeds the supported maximum of 36"))
 		})
 	})
 })
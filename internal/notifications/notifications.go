@@ -1,7 +1,15 @@
 package notifications
 
 import (
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/hazim1093/zeta-comms/internal/config"
+	"github.com/hazim1093/zeta-comms/internal/metrics"
 	"github.com/hazim1093/zeta-comms/pkg/models"
 	"github.com/hazim1093/zeta-comms/pkg/notifiers"
 	"github.com/hazim1093/zeta-comms/pkg/notifiers/discord"
@@ -17,24 +25,40 @@ type NotificationService struct {
 	config    *config.Config
 	log       *zerolog.Logger
 	notifiers map[string]notifiers.Notifier
+
+	// renderOptions holds the render options per audience and platform
+	renderOptions map[string]map[string]notifiers.RenderOptions
 }
 
 func NewNotificationService(cfg *config.Config, log *zerolog.Logger) *NotificationService {
 	service := &NotificationService{
-		config:    cfg,
-		log:       log,
-		notifiers: make(map[string]notifiers.Notifier),
+		config:        cfg,
+		log:           log,
+		notifiers:     make(map[string]notifiers.Notifier),
+		renderOptions: make(map[string]map[string]notifiers.RenderOptions),
+	}
+
+	service.initializeRenderOptions()
+
+	if cfg.DryRun.Enabled {
+		service.initializeDryRunNotifiers()
+
+		return service
 	}
 
 	// Initialize Discord client
-	if discordClient, err := discord.InitializeDiscordClient(log, cfg.Notifiers.Discord.BotToken); err == nil {
+	if cfg.Notifiers.Discord.BotToken == "" {
+		log.Warn().Msg("No Discord bot token configured, Discord notifications are disabled")
+	} else if discordClient, err := discord.InitializeDiscordClient(log, cfg.Notifiers.Discord.BotToken); err == nil {
 		service.notifiers["discord"] = discordClient
 	} else {
 		log.Error().Err(err).Msg("Failed to initialize Discord client")
 	}
 
 	// Initialize Telegram client
-	if telegramClient, err := telegram.InitializeTelegramClient(log, cfg.Notifiers.Telegram.BotToken); err == nil {
+	if cfg.Notifiers.Telegram.BotToken == "" {
+		log.Warn().Msg("No Telegram bot token configured, Telegram notifications are disabled")
+	} else if telegramClient, err := telegram.InitializeTelegramClient(log, cfg.Notifiers.Telegram.BotToken); err == nil {
 		service.notifiers["telegram"] = telegramClient
 	} else {
 		log.Error().Err(err).Msg("Failed to initialize Telegram client")
@@ -46,45 +70,229 @@ func NewNotificationService(cfg *config.Config, log *zerolog.Logger) *Notificati
 	return service
 }
 
-func (n *NotificationService) Notify(notification Notification, audience string) {
+// SetNotifier replaces the notifier of a platform, e.g. with a fake in tests
+func (n *NotificationService) SetNotifier(notifier notifiers.Notifier) {
+	n.notifiers[notifier.Name()] = notifier
+}
+
+// initializeRenderOptions prepares the templates, overflow, time and language settings of every audience and platform
+func (n *NotificationService) initializeRenderOptions() {
+	templates, err := LoadTemplates(n.config)
+	if err != nil {
+		n.log.Error().Err(err).Msg("Failed to load some templates, using the defaults instead")
+	}
+
+	overflows := map[string]config.Overflow{
+		"discord":  n.config.Notifiers.Discord.Overflow,
+		"slack":    n.config.Notifiers.Slack.Overflow,
+		"telegram": n.config.Notifiers.Telegram.Overflow,
+	}
+
+	for audience, audienceConfig := range n.config.AudienceConfig {
+		n.renderOptions[audience] = make(map[string]notifiers.RenderOptions)
+
+		location, err := time.LoadLocation(audienceConfig.Timezone)
+		if err != nil {
+			n.log.Error().Err(err).Str("audience", audience).Msg("Unknown timezone, using UTC instead")
+
+			location = time.UTC
+		}
+
+		for platform, overflow := range overflows {
+			n.renderOptions[audience][platform] = notifiers.RenderOptions{
+				Templates: templates[audience][platform],
+				Overflow: notifiers.Overflow{
+					Mode:      overflow.Mode,
+					MaxLength: overflow.MaxLength,
+				},
+				Time: notifiers.TimeOptions{
+					Location: location,
+					Layout:   audienceConfig.DateFormat,
+				},
+				Language: audienceConfig.Language,
+			}
+		}
+	}
+}
+
+// initializeDryRunNotifiers registers notifiers that render but never send, so that no
+// platform connection or credentials are needed
+func (n *NotificationService) initializeDryRunNotifiers() {
+	outputDir := n.config.DryRun.OutputDir
+
+	n.notifiers["discord"] = notifiers.NewDryRunNotifier(n.log, "discord", "json", discord.Render, outputDir)
+	n.notifiers["slack"] = notifiers.NewDryRunNotifier(n.log, "slack", "json", slack.Render, outputDir)
+	n.notifiers["telegram"] = notifiers.NewDryRunNotifier(n.log, "telegram", "txt", telegram.Render, outputDir)
+
+	n.log.Warn().Str("output_dir", outputDir).Msg("Dry run enabled, notifications will be rendered but not sent")
+}
+
+// Ready implements the server.ReadinessCheck interface. It fails when a platform used by
+// any audience has no initialized notifier.
+func (n *NotificationService) Ready() error {
+	var missing []string
+
+	for _, audience := range n.config.AudienceConfig {
+		for platform := range audience.Channels {
+			if _, ok := n.notifiers[platform]; !ok && !slices.Contains(missing, platform) {
+				missing = append(missing, platform)
+			}
+		}
+	}
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+
+		return fmt.Errorf("notifiers not initialized: %s", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// HeldUntil reports whether a notification has to be held back because the audience is in its
+// quiet hours, and when they end. Critical notifications are never held.
+func (n *NotificationService) HeldUntil(notification Notification, audience string, now time.Time) (time.Time, bool) {
+	audienceConfig, ok := n.config.AudienceConfig[audience]
+	if !ok || notification.Critical() || !audienceConfig.QuietHours.Enabled() {
+		return time.Time{}, false
+	}
+
+	timezone := audienceConfig.QuietHours.Timezone
+	if timezone == "" {
+		timezone = audienceConfig.Timezone
+	}
+
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		location = time.UTC
+	}
+
+	return audienceConfig.QuietHours.Until(now, location)
+}
+
+// Notify sends the notification to every channel of the audience and returns the outcome per channel
+func (n *NotificationService) Notify(notification Notification, audience string) []models.Delivery {
 	log := n.log.With().Str("audience", audience).Logger()
 
 	audienceConfig, ok := n.config.AudienceConfig[audience]
 	if !ok {
 		log.Error().Msg("No audience config found")
 
-		return
+		return nil
 	}
 
+	var deliveries []models.Delivery
+
 	for platform, channels := range audienceConfig.Channels {
-		n.sendToChannels(platform, channels, notification, log)
+		deliveries = append(deliveries, n.sendToChannels(platform, audience, channels, notification, log)...)
 	}
+
+	return deliveries
 }
 
-func (n *NotificationService) sendToChannels(platform string, channels []string, notification Notification, log zerolog.Logger) {
+// Retry sends the notification again to the channels of the audience whose delivery failed
+func (n *NotificationService) Retry(notification Notification, audience string, failed []models.Delivery) []models.Delivery {
+	log := n.log.With().Str("audience", audience).Logger()
+
+	var deliveries []models.Delivery
+
+	for platform, channels := range n.config.AudienceConfig[audience].Channels {
+		var retry []string
+
+		for _, channel := range channels {
+			redacted := redactChannel(platform, channel)
+			if slices.ContainsFunc(failed, func(delivery models.Delivery) bool {
+				return delivery.Platform == platform && delivery.Channel == redacted
+			}) {
+				retry = append(retry, channel)
+			}
+		}
+
+		if len(retry) > 0 {
+			deliveries = append(deliveries, n.sendToChannels(platform, audience, retry, notification, log)...)
+		}
+	}
+
+	return deliveries
+}
+
+func (n *NotificationService) sendToChannels(platform string, audience string, channels []string, notification Notification, log zerolog.Logger) []models.Delivery {
+	deliveries := make([]models.Delivery, 0, len(channels))
+
 	notifier, exists := n.notifiers[platform]
 	if !exists {
 		log.Error().Msgf("No notifier found for platform: %s", platform)
+		metrics.NotificationsFailed.WithLabelValues(platform, audience).Add(float64(len(channels)))
+
+		for _, channel := range channels {
+			deliveries = append(deliveries, newDelivery(audience, platform, channel, fmt.Errorf("no notifier found for platform: %s", platform)))
+		}
 
-		return
+		return deliveries
 	}
 
+	options := n.renderOptions[audience][platform]
+
 	for _, channel := range channels {
-		err := notifier.Send(channel, notification)
+		err := notifier.Send(channel, notification, options)
+		deliveries = append(deliveries, newDelivery(audience, platform, channel, err))
+
 		if err != nil {
 			log.Error().
 				Err(err).
 				Str("platform", platform).
 				Str("channel", channel).
 				Str("proposal_id", notification.ProposalId).
+				Str("severity", notification.Severity).
 				Msg("Failed to send notification")
+			metrics.NotificationsFailed.WithLabelValues(platform, audience).Inc()
 
 			continue
 		}
 
+		metrics.NotificationsSent.WithLabelValues(platform, audience).Inc()
+
 		log.Info().
 			Str("platform", platform).
 			Str("proposal_id", notification.ProposalId).
+			Str("severity", notification.Severity).
 			Msg("Notification sent successfully")
 	}
+
+	return deliveries
+}
+
+func newDelivery(audience string, platform string, channel string, err error) models.Delivery {
+	delivery := models.Delivery{
+		Audience: audience,
+		Platform: platform,
+		Channel:  redactChannel(platform, channel),
+		Time:     time.Now().UTC(),
+	}
+
+	if err != nil {
+		delivery.Error = err.Error()
+	}
+
+	return delivery
+}
+
+// redactChannel hides secrets embedded in channel identifiers, such as Slack webhook URLs,
+// so that delivery records can be stored and exposed safely
+func redactChannel(platform string, channel string) string {
+	if platform != "slack" {
+		return channel
+	}
+
+	u, err := url.Parse(channel)
+	if err != nil || u.Host == "" {
+		return "<redacted>"
+	}
+
+	suffix := u.Path
+	if len(suffix) > 4 {
+		suffix = suffix[len(suffix)-4:]
+	}
+
+	return u.Scheme + "://" + u.Host + "/..." + suffix
 }
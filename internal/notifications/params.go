@@ -0,0 +1,72 @@
+package notifications
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hazim1093/zeta-comms/pkg/models"
+	"github.com/hazim1093/zeta-comms/pkg/zetachain"
+)
+
+// CompareParams compares the params of the MsgUpdateParams messages of a proposal to the current
+// params of their modules and sets the changes on the mapped messages. Proposals past the voting
+// period are skipped, as their params may be in effect already. Messages whose params cannot be
+// compared keep listing their fields and are marked as such.
+func CompareParams(client zetachain.Client, network string, proposal zetachain.Proposal, messages []models.ProposalMessage) error {
+	if proposal.Status != "PROPOSAL_STATUS_DEPOSIT_PERIOD" && proposal.Status != "PROPOSAL_STATUS_VOTING_PERIOD" {
+		return nil
+	}
+
+	var errs []error
+
+	for i, msg := range proposal.Messages {
+		if i >= len(messages) || zetachain.MessageName(msg.Type) != "MsgUpdateParams" {
+			continue
+		}
+
+		changes, err := compareParams(client, network, msg)
+		if err != nil {
+			messages[i].ParamsUnavailable = true
+
+			if !errors.Is(err, zetachain.ErrUnsupported) {
+				errs = append(errs, fmt.Errorf("%s: %w", msg.Type, err))
+			}
+
+			continue
+		}
+
+		messages[i].ParamsCompared = true
+		messages[i].Changes = changes
+	}
+
+	return errors.Join(errs...)
+}
+
+func compareParams(client zetachain.Client, network string, msg zetachain.Message) ([]models.ParamChange, error) {
+	var proposed struct {
+		Params json.RawMessage `json:"params"`
+	}
+
+	if err := json.Unmarshal(msg.Raw, &proposed); err != nil || len(proposed.Params) == 0 {
+		// Messages fetched over gRPC carry no params
+		return nil, zetachain.ErrUnsupported
+	}
+
+	current, err := client.GetModuleParams(network, zetachain.MessagePackage(msg.Type))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the current params: %w", err)
+	}
+
+	paramChanges, err := zetachain.DiffParams(current, proposed.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]models.ParamChange, len(paramChanges))
+	for i, change := range paramChanges {
+		changes[i] = models.ParamChange{Name: change.Name, Old: change.Old, New: change.New}
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,79 @@
+package notifications_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/hazim1093/zeta-comms/internal/notifications"
+	"github.com/hazim1093/zeta-comms/pkg/models"
+	"github.com/hazim1093/zeta-comms/pkg/zetachain"
+)
+
+var _ = Describe("CompareParams", func() {
+	var (
+		client   *zetachain.FakeClient
+		proposal zetachain.Proposal
+	)
+
+	BeforeEach(func() {
+		client = zetachain.NewFakeClient()
+		client.ModuleParams = map[string]json.RawMessage{
+			"zetachain.zetacore.observer": json.RawMessage(`{"ballot_maturity_blocks": "100", "admin_policy": []}`),
+		}
+
+		var updateParams zetachain.Message
+		Expect(json.Unmarshal([]byte(`{
+			"@type": "/zetachain.zetacore.observer.MsgUpdateParams",
+			"params": {"ballot_maturity_blocks": "200", "admin_policy": []}
+		}`), &updateParams)).To(Succeed())
+
+		proposal = zetachain.Proposal{
+			ProposalId: "7",
+			Status:     "PROPOSAL_STATUS_VOTING_PERIOD",
+			Messages:   []zetachain.Message{{Type: "/cosmos.gov.v1beta1.MsgVote"}, updateParams},
+		}
+	})
+
+	It("should set the params that differ from the chain on the message", func() {
+		notification := notifications.MapFromProposal("mainnet", proposal)
+
+		Expect(notifications.CompareParams(client, "mainnet", proposal, notification.Messages)).To(Succeed())
+		Expect(notification.Messages[0].ParamsCompared).To(BeFalse())
+		Expect(notification.Messages[1].ParamsCompared).To(BeTrue())
+		Expect(notification.Messages[1].ParamsUnavailable).To(BeFalse())
+		Expect(notification.Messages[1].Changes).To(Equal([]models.ParamChange{
+			{Name: "ballot_maturity_blocks", Old: "100", New: "200"},
+		}))
+	})
+
+	It("should skip proposals whose params may be in effect already", func() {
+		proposal.Status = "PROPOSAL_STATUS_PASSED"
+		notification := notifications.MapFromProposal("mainnet", proposal)
+
+		Expect(notifications.CompareParams(client, "mainnet", proposal, notification.Messages)).To(Succeed())
+		Expect(notification.Messages[1].ParamsCompared).To(BeFalse())
+		Expect(client.Calls["GetModuleParams"]).To(BeZero())
+	})
+
+	It("should keep the fields of messages whose module params cannot be fetched", func() {
+		delete(client.ModuleParams, "zetachain.zetacore.observer")
+		notification := notifications.MapFromProposal("mainnet", proposal)
+
+		Expect(notifications.CompareParams(client, "mainnet", proposal, notification.Messages)).To(MatchError(ContainSubstring("MsgUpdateParams")))
+		Expect(notification.Messages[1].ParamsCompared).To(BeFalse())
+		Expect(notification.Messages[1].ParamsUnavailable).To(BeTrue())
+		Expect(notification.Messages[1].Fields).To(ContainElement(models.MessageField{Name: "ballot_maturity_blocks", Value: "200"}))
+	})
+
+	It("should mark messages without known params as unavailable for comparison", func() {
+		proposal.Messages[1] = zetachain.Message{Type: "/zetachain.zetacore.observer.MsgUpdateParams"}
+		notification := notifications.MapFromProposal("mainnet", proposal)
+
+		Expect(notifications.CompareParams(client, "mainnet", proposal, notification.Messages)).To(Succeed())
+		Expect(notification.Messages[1].ParamsCompared).To(BeFalse())
+		Expect(notification.Messages[1].ParamsUnavailable).To(BeTrue())
+		Expect(notification.Messages[0].ParamsUnavailable).To(BeFalse())
+	})
+})
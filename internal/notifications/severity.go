@@ -0,0 +1,55 @@
+package notifications
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hazim1093/zeta-comms/internal/config"
+	"github.com/hazim1093/zeta-comms/pkg/models"
+	"github.com/hazim1093/zeta-comms/pkg/zetachain"
+)
+
+// Classify returns the severity of a proposal notification under the rules of its network, and
+// why it is critical. latest is the latest block of the network, used to estimate when an upgrade
+// happens, or nil if it is unknown, in which case upgrades are considered imminent.
+func Classify(notification models.Notification, rules config.Severity, latest *zetachain.BlockHeader, now time.Time) (string, string) {
+	if notification.Status == "PROPOSAL_STATUS_REJECTED" || notification.Status == "PROPOSAL_STATUS_FAILED" {
+		return models.SeverityNormal, ""
+	}
+
+	if rules.CriticalExpedited && notification.Expedited {
+		return models.SeverityCritical, "expedited proposal"
+	}
+
+	if rules.CriticalUpgradeWithin <= 0 || notification.UpgradeName == "" {
+		return models.SeverityNormal, ""
+	}
+
+	if latest == nil {
+		return models.SeverityCritical, "upgrade time unknown"
+	}
+
+	target, errTarget := strconv.ParseInt(notification.TargetHeight, 10, 64)
+	height, errHeight := strconv.ParseInt(latest.Height, 10, 64)
+
+	if errTarget != nil || errHeight != nil {
+		return models.SeverityCritical, "upgrade time unknown"
+	}
+
+	if target <= height {
+		return models.SeverityNormal, ""
+	}
+
+	blockTime := rules.BlockTime
+	if blockTime <= 0 {
+		blockTime = config.DefaultBlockTime
+	}
+
+	expected := latest.Time.Add(time.Duration(target-height) * blockTime)
+	if expected.Sub(now) > rules.CriticalUpgradeWithin {
+		return models.SeverityNormal, ""
+	}
+
+	return models.SeverityCritical, fmt.Sprintf("upgrade expected in about %s", expected.Sub(now).Round(time.Minute))
+}
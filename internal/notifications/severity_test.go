@@ -0,0 +1,64 @@
+package notifications_test
+
+import (
+	"time"
+
+	"github.com/hazim1093/zeta-comms/internal/config"
+	"github.com/hazim1093/zeta-comms/internal/notifications"
+	"github.com/hazim1093/zeta-comms/pkg/models"
+	"github.com/hazim1093/zeta-comms/pkg/zetachain"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Classify", func() {
+	now := time.Date(2025, 3, 4, 10, 0, 0, 0, time.UTC)
+	rules := config.Severity{CriticalExpedited: true, CriticalUpgradeWithin: 24 * time.Hour, BlockTime: 6 * time.Second}
+	latest := &zetachain.BlockHeader{Height: "1000000", Time: now}
+
+	upgrade := models.Notification{
+		Status:       "PROPOSAL_STATUS_VOTING_PERIOD",
+		UpgradeName:  "v30",
+		TargetHeight: "1010000", // 10000 blocks of 6s, about 16h40m
+	}
+
+	It("should treat upgrades expected within the window as critical", func() {
+		severity, reason := notifications.Classify(upgrade, rules, latest, now)
+		Expect(severity).To(Equal(models.SeverityCritical))
+		Expect(reason).To(Equal("upgrade expected in about 16h40m0s"))
+
+		rules := rules
+		rules.CriticalUpgradeWithin = 12 * time.Hour
+
+		severity, _ = notifications.Classify(upgrade, rules, latest, now)
+		Expect(severity).To(Equal(models.SeverityNormal))
+	})
+
+	It("should treat upgrades as critical if the chain height is unknown", func() {
+		severity, reason := notifications.Classify(upgrade, rules, nil, now)
+		Expect(severity).To(Equal(models.SeverityCritical))
+		Expect(reason).To(Equal("upgrade time unknown"))
+	})
+
+	It("should not treat past or rejected upgrades as critical", func() {
+		severity, _ := notifications.Classify(upgrade, rules, &zetachain.BlockHeader{Height: "1010001", Time: now}, now)
+		Expect(severity).To(Equal(models.SeverityNormal))
+
+		rejected := upgrade
+		rejected.Status = "PROPOSAL_STATUS_REJECTED"
+
+		severity, _ = notifications.Classify(rejected, rules, latest, now)
+		Expect(severity).To(Equal(models.SeverityNormal))
+	})
+
+	It("should treat expedited proposals as critical only if configured", func() {
+		expedited := models.Notification{Status: "PROPOSAL_STATUS_VOTING_PERIOD", Expedited: true}
+
+		severity, reason := notifications.Classify(expedited, rules, nil, now)
+		Expect(severity).To(Equal(models.SeverityCritical))
+		Expect(reason).To(Equal("expedited proposal"))
+
+		severity, _ = notifications.Classify(expedited, config.Severity{}, nil, now)
+		Expect(severity).To(Equal(models.SeverityNormal))
+	})
+})
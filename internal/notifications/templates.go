@@ -0,0 +1,119 @@
+package notifications
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/hazim1093/zeta-comms/internal/config"
+	"github.com/hazim1093/zeta-comms/pkg/models"
+	"github.com/hazim1093/zeta-comms/pkg/notifiers"
+	"github.com/hazim1093/zeta-comms/pkg/notifiers/discord"
+	"github.com/hazim1093/zeta-comms/pkg/notifiers/slack"
+	"github.com/hazim1093/zeta-comms/pkg/notifiers/telegram"
+)
+
+// templateParsers parses custom templates with the helpers of each platform
+var templateParsers = map[string]func(name string, text string) (*template.Template, error){
+	"discord":  discord.ParseTemplate,
+	"slack":    slack.ParseTemplate,
+	"telegram": telegram.ParseTemplate,
+}
+
+// LoadTemplates parses the configured template overrides and returns them per audience and
+// platform. Audience templates take precedence over the global ones. Templates that cannot be
+// loaded are reported as config.ValidationErrors and left out, so the defaults are used instead.
+func LoadTemplates(cfg *config.Config) (map[string]map[string]notifiers.TemplateSet, error) {
+	var errs config.ValidationErrors
+
+	global := parseTemplates("templates", cfg.Templates, &errs)
+
+	templates := make(map[string]map[string]notifiers.TemplateSet, len(cfg.AudienceConfig))
+
+	for name, audience := range cfg.AudienceConfig {
+		overrides := parseTemplates("audience_config."+name+".templates", audience.Templates, &errs)
+		templates[name] = make(map[string]notifiers.TemplateSet)
+
+		for platform := range templateParsers {
+			set := make(notifiers.TemplateSet)
+
+			for event, tmpl := range global[platform] {
+				set[event] = tmpl
+			}
+
+			for event, tmpl := range overrides[platform] {
+				set[event] = tmpl
+			}
+
+			if len(set) > 0 {
+				templates[name][platform] = set
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return templates, cfg.Annotate(errs)
+	}
+
+	return templates, nil
+}
+
+// ValidateTemplates checks that all configured templates can be loaded
+func ValidateTemplates(cfg *config.Config) error {
+	_, err := LoadTemplates(cfg)
+
+	return err
+}
+
+func parseTemplates(prefix string, sources map[string]map[string]config.TemplateSource, errs *config.ValidationErrors) map[string]notifiers.TemplateSet {
+	templates := make(map[string]notifiers.TemplateSet)
+
+	addError := func(key string, format string, args ...interface{}) {
+		*errs = append(*errs, config.ValidationError{Key: key, Message: fmt.Sprintf(format, args...)})
+	}
+
+	for platform, events := range sources {
+		parse, ok := templateParsers[platform]
+		if !ok {
+			// Unknown platforms are reported by config.Validate
+			continue
+		}
+
+		templates[platform] = make(notifiers.TemplateSet)
+
+		for event, source := range events {
+			key := prefix + "." + platform + "." + event
+
+			if !slices.Contains(models.EventTypes, event) {
+				addError(key, "unknown event type %q, expected one of %s", event, strings.Join(models.EventTypes, ", "))
+
+				continue
+			}
+
+			text := source.Text
+			if source.File != "" {
+				data, err := os.ReadFile(source.File)
+				if err != nil {
+					addError(key, "failed to read template file: %s", err)
+
+					continue
+				}
+
+				text = string(data)
+			}
+
+			tmpl, err := parse(key, text)
+			if err != nil {
+				addError(key, "failed to parse template: %s", err)
+
+				continue
+			}
+
+			templates[platform][event] = tmpl
+		}
+	}
+
+	return templates
+}
@@ -0,0 +1,111 @@
+package notifications_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rs/zerolog"
+
+	"github.com/hazim1093/zeta-comms/internal/config"
+	"github.com/hazim1093/zeta-comms/internal/notifications"
+	"github.com/hazim1093/zeta-comms/pkg/models"
+)
+
+var _ = Describe("Templates", func() {
+	var (
+		cfg       *config.Config
+		outputDir string
+	)
+
+	BeforeEach(func() {
+		outputDir = GinkgoT().TempDir()
+
+		cfg = &config.Config{
+			AudienceConfig: map[string]config.Audience{
+				"developers": {
+					Channels: map[string][]string{"telegram": {"-100123"}},
+				},
+				"validators": {
+					Channels: map[string][]string{"telegram": {"-100456"}},
+					Templates: map[string]map[string]config.TemplateSource{
+						"telegram": {"proposal": {Text: "Validators: {{ .Title }} is {{ status .Status }}"}},
+					},
+				},
+			},
+			Templates: map[string]map[string]config.TemplateSource{
+				"telegram": {"proposal": {Text: "{{ upper .Network }} #{{ .ProposalId }}: {{ .Title }}"}},
+			},
+		}
+		cfg.DryRun.Enabled = true
+		cfg.DryRun.OutputDir = outputDir
+	})
+
+	render := func(audience string) string {
+		log := zerolog.Nop()
+		service := notifications.NewNotificationService(cfg, &log)
+
+		deliveries := service.Notify(models.Notification{
+			EventType:  models.EventProposal,
+			Network:    "mainnet",
+			ProposalId: "42",
+			Title:      "Upgrade v30",
+			Status:     "PROPOSAL_STATUS_PASSED",
+		}, audience)
+		Expect(deliveries).To(HaveLen(1))
+		Expect(deliveries[0].Succeeded()).To(BeTrue())
+
+		files, err := filepath.Glob(filepath.Join(outputDir, "*"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files).To(HaveLen(1))
+
+		content, err := os.ReadFile(files[0])
+		Expect(err).NotTo(HaveOccurred())
+
+		return string(content)
+	}
+
+	It("should render with the global template override", func() {
+		Expect(render("developers")).To(Equal("MAINNET #42: Upgrade v30"))
+	})
+
+	It("should prefer the audience template over the global one", func() {
+		Expect(render("validators")).To(Equal("Validators: Upgrade v30 is ✅ Passed"))
+	})
+
+	It("should load templates from files", func() {
+		file := filepath.Join(GinkgoT().TempDir(), "proposal.tmpl")
+		Expect(os.WriteFile(file, []byte("From file: {{ .Title }}"), 0o644)).To(Succeed())
+
+		cfg.Templates["telegram"]["proposal"] = config.TemplateSource{File: file}
+
+		Expect(render("developers")).To(Equal("From file: Upgrade v30"))
+	})
+
+	It("should report templates that cannot be loaded", func() {
+		cfg.Templates["telegram"]["vote"] = config.TemplateSource{Text: "{{ .Title }}"}
+		cfg.AudienceConfig["validators"].Templates["telegram"]["proposal"] = config.TemplateSource{Text: "{{ .Title"}
+		cfg.AudienceConfig["developers"] = config.Audience{
+			Channels: cfg.AudienceConfig["developers"].Channels,
+			Templates: map[string]map[string]config.TemplateSource{
+				"discord": {"broadcast": {File: "/does/not/exist.tmpl"}},
+			},
+		}
+
+		var validationErrs config.ValidationErrors
+		Expect(errors.As(notifications.ValidateTemplates(cfg), &validationErrs)).To(BeTrue())
+
+		keys := make([]string, 0, len(validationErrs))
+		for _, validationErr := range validationErrs {
+			keys = append(keys, validationErr.Key)
+		}
+
+		Expect(keys).To(ConsistOf(
+			"audience_config.developers.templates.discord.broadcast",
+			"audience_config.validators.templates.telegram.proposal",
+			"templates.telegram.vote",
+		))
+	})
+})
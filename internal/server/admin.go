@@ -0,0 +1,162 @@
+package server
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/hazim1093/zeta-comms/internal/comms"
+	"github.com/hazim1093/zeta-comms/internal/storage"
+	"github.com/hazim1093/zeta-comms/pkg/models"
+)
+
+// AdminBackend is the set of operations exposed by the admin API, implemented by comms.CommsEngine
+type AdminBackend interface {
+	ListProposals(network string) ([]storage.ProposalRecord, error)
+	GetProposal(network string, proposalID string) (*storage.ProposalRecord, error)
+	ResendProposal(network string, proposalID string, audience string) ([]models.Delivery, error)
+	Broadcast(msg models.BroadcastMessage) []models.Delivery
+}
+
+type resendRequest struct {
+	Audience string `json:"audience"`
+}
+
+type broadcastRequest struct {
+	Message string `json:"message"`
+}
+
+type deliveriesResponse struct {
+	Deliveries []models.Delivery `json:"deliveries"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// EnableAdminAPI registers the authenticated admin endpoints. Requests must carry the
+// configured token as a bearer token.
+func (s *Server) EnableAdminAPI(backend AdminBackend) {
+	admin := &adminHandlers{backend: backend}
+
+	s.mux.Handle("GET /admin/networks/{network}/proposals", s.authenticated(admin.listProposals))
+	s.mux.Handle("GET /admin/networks/{network}/proposals/{id}", s.authenticated(admin.getProposal))
+	s.mux.Handle("POST /admin/networks/{network}/proposals/{id}/resend", s.authenticated(admin.resendProposal))
+	s.mux.Handle("POST /admin/broadcast", s.authenticated(admin.broadcast))
+
+	s.log.Info().Msg("Admin API enabled")
+}
+
+// authenticated rejects requests that do not carry the admin token
+func (s *Server) authenticated(next http.HandlerFunc) http.Handler {
+	expected := []byte(s.config.Server.Admin.Token)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || len(expected) == 0 || subtle.ConstantTimeCompare([]byte(token), expected) != 1 {
+			s.log.Warn().Str("path", r.URL.Path).Str("remote_addr", r.RemoteAddr).Msg("Unauthorized admin request")
+			writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "unauthorized"})
+
+			return
+		}
+
+		next(w, r)
+	})
+}
+
+type adminHandlers struct {
+	backend AdminBackend
+}
+
+func (a *adminHandlers) listProposals(w http.ResponseWriter, r *http.Request) {
+	records, err := a.backend.ListProposals(r.PathValue("network"))
+	if err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, records)
+}
+
+// getProposal returns a tracked proposal. The delivery history can be narrowed down to a
+// destination with the audience, platform and channel query parameters.
+func (a *adminHandlers) getProposal(w http.ResponseWriter, r *http.Request) {
+	record, err := a.backend.GetProposal(r.PathValue("network"), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	if record == nil {
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: "proposal is not tracked"})
+
+		return
+	}
+
+	query := r.URL.Query()
+	deliveries := make([]models.Delivery, 0, len(record.Deliveries))
+
+	for _, delivery := range record.Deliveries {
+		if matchesFilter(query.Get("audience"), delivery.Audience) &&
+			matchesFilter(query.Get("platform"), delivery.Platform) &&
+			matchesFilter(query.Get("channel"), delivery.Channel) {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+
+	record.Deliveries = deliveries
+
+	writeJSON(w, http.StatusOK, record)
+}
+
+func (a *adminHandlers) resendProposal(w http.ResponseWriter, r *http.Request) {
+	var request resendRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.Audience == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "request body must be a JSON object with an audience"})
+
+		return
+	}
+
+	deliveries, err := a.backend.ResendProposal(r.PathValue("network"), r.PathValue("id"), request.Audience)
+	if err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, deliveriesResponse{Deliveries: deliveries})
+}
+
+func (a *adminHandlers) broadcast(w http.ResponseWriter, r *http.Request) {
+	var request broadcastRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || strings.TrimSpace(request.Message) == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "request body must be a JSON object with a message"})
+
+		return
+	}
+
+	deliveries := a.backend.Broadcast(models.BroadcastMessage{
+		Message:  strings.TrimSpace(request.Message),
+		Username: "admin-api",
+	})
+
+	writeJSON(w, http.StatusOK, deliveriesResponse{Deliveries: deliveries})
+}
+
+func matchesFilter(filter string, value string) bool {
+	return filter == "" || filter == value
+}
+
+// writeError maps backend errors to HTTP status codes
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	if errors.Is(err, comms.ErrUnknownNetwork) || errors.Is(err, comms.ErrUnknownAudience) {
+		status = http.StatusNotFound
+	}
+
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
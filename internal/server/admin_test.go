@@ -0,0 +1,154 @@
+package server_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rs/zerolog"
+
+	"github.com/hazim1093/zeta-comms/internal/comms"
+	"github.com/hazim1093/zeta-comms/internal/config"
+	"github.com/hazim1093/zeta-comms/internal/server"
+	"github.com/hazim1093/zeta-comms/internal/storage"
+	"github.com/hazim1093/zeta-comms/pkg/models"
+)
+
+type fakeBackend struct {
+	records    map[string][]storage.ProposalRecord
+	resent     []string
+	broadcasts []string
+}
+
+func (f *fakeBackend) ListProposals(network string) ([]storage.ProposalRecord, error) {
+	records, ok := f.records[network]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", comms.ErrUnknownNetwork, network)
+	}
+
+	return records, nil
+}
+
+func (f *fakeBackend) GetProposal(network string, proposalID string) (*storage.ProposalRecord, error) {
+	records, err := f.ListProposals(network)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, record := range records {
+		if record.ID == proposalID {
+			return &record, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (f *fakeBackend) ResendProposal(network string, proposalID string, audience string) ([]models.Delivery, error) {
+	if audience != "developers" {
+		return nil, fmt.Errorf("%w: %s", comms.ErrUnknownAudience, audience)
+	}
+
+	f.resent = append(f.resent, network+"/"+proposalID+"/"+audience)
+
+	return []models.Delivery{{Audience: audience, Platform: "discord", Channel: "123", Manual: true}}, nil
+}
+
+func (f *fakeBackend) Broadcast(msg models.BroadcastMessage) []models.Delivery {
+	f.broadcasts = append(f.broadcasts, msg.Message)
+
+	return nil
+}
+
+var _ = Describe("Admin API", func() {
+	var (
+		log     zerolog.Logger
+		backend *fakeBackend
+		srv     *server.Server
+	)
+
+	request := func(method string, path string, token string, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		recorder := httptest.NewRecorder()
+		srv.ServeHTTP(recorder, req)
+
+		return recorder
+	}
+
+	BeforeEach(func() {
+		log = zerolog.Nop()
+		backend = &fakeBackend{
+			records: map[string][]storage.ProposalRecord{
+				"mainnet": {
+					{
+						ID:    "42",
+						Title: "Upgrade v30",
+						Deliveries: []models.Delivery{
+							{Audience: "developers", Platform: "discord", Channel: "123"},
+							{Audience: "operators", Platform: "telegram", Channel: "-100", Error: "chat not found"},
+						},
+					},
+				},
+			},
+		}
+
+		cfg := &config.Config{}
+		cfg.Server.Admin.Token = "secret"
+
+		srv = server.NewServer(cfg, &log, nil)
+		srv.EnableAdminAPI(backend)
+	})
+
+	It("should reject requests without a valid token", func() {
+		Expect(request(http.MethodGet, "/admin/networks/mainnet/proposals", "", "").Code).To(Equal(http.StatusUnauthorized))
+		Expect(request(http.MethodGet, "/admin/networks/mainnet/proposals", "wrong", "").Code).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("should list tracked proposals", func() {
+		recorder := request(http.MethodGet, "/admin/networks/mainnet/proposals", "secret", "")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+
+		var records []storage.ProposalRecord
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &records)).To(Succeed())
+		Expect(records).To(HaveLen(1))
+		Expect(records[0].Title).To(Equal("Upgrade v30"))
+	})
+
+	It("should return 404 for unknown networks", func() {
+		Expect(request(http.MethodGet, "/admin/networks/devnet/proposals", "secret", "").Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should filter delivery history by destination", func() {
+		recorder := request(http.MethodGet, "/admin/networks/mainnet/proposals/42?platform=telegram", "secret", "")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+
+		var record storage.ProposalRecord
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &record)).To(Succeed())
+		Expect(record.Deliveries).To(HaveLen(1))
+		Expect(record.Deliveries[0].Error).To(Equal("chat not found"))
+	})
+
+	It("should re-send a proposal to an audience", func() {
+		recorder := request(http.MethodPost, "/admin/networks/mainnet/proposals/42/resend", "secret", `{"audience":"developers"}`)
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(backend.resent).To(ConsistOf("mainnet/42/developers"))
+
+		recorder = request(http.MethodPost, "/admin/networks/mainnet/proposals/42/resend", "secret", `{"audience":"nobody"}`)
+		Expect(recorder.Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should broadcast a message", func() {
+		Expect(request(http.MethodPost, "/admin/broadcast", "secret", `{"message":"  maintenance at 14:00 UTC "}`).Code).To(Equal(http.StatusOK))
+		Expect(backend.broadcasts).To(ConsistOf("maintenance at 14:00 UTC"))
+
+		Expect(request(http.MethodPost, "/admin/broadcast", "secret", `{}`).Code).To(Equal(http.StatusBadRequest))
+	})
+})
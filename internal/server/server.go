@@ -0,0 +1,127 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/hazim1093/zeta-comms/internal/config"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rs/zerolog"
+)
+
+const shutdownTimeout = 5 * time.Second
+
+// ReadinessCheck is implemented by components that can report whether they are working
+type ReadinessCheck interface {
+	// Ready returns nil when the component is ready, or an error describing what is wrong
+	Ready() error
+}
+
+// Server exposes health, readiness and metrics endpoints over HTTP
+type Server struct {
+	config *config.Config
+	log    *zerolog.Logger
+	mux    *http.ServeMux
+	checks map[string]ReadinessCheck
+}
+
+type readinessResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+func NewServer(cfg *config.Config, logger *zerolog.Logger, checks map[string]ReadinessCheck) *Server {
+	log := logger.With().Str("service", "server").Logger()
+
+	s := &Server{
+		config: cfg,
+		log:    &log,
+		mux:    http.NewServeMux(),
+		checks: checks,
+	}
+
+	s.mux.HandleFunc("GET /healthz", s.handleHealthz)
+	s.mux.HandleFunc("GET /readyz", s.handleReadyz)
+	s.mux.Handle("GET /metrics", promhttp.Handler())
+
+	return s
+}
+
+// Start serves HTTP requests in the background until the context is cancelled. The returned
+// channel is closed once the server has shut down, after in-flight requests completed.
+func (s *Server) Start(ctx context.Context) <-chan struct{} {
+	httpServer := &http.Server{
+		Addr:              s.config.Server.ListenAddress,
+		Handler:           s.mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		s.log.Info().Str("address", httpServer.Addr).Msg("Starting HTTP server")
+
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.log.Error().Err(err).Msg("HTTP server failed")
+		}
+	}()
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			s.log.Error().Err(err).Msg("Failed to shut down HTTP server")
+		}
+	}()
+
+	return done
+}
+
+// ServeHTTP makes the server usable as an http.Handler, mainly for tests
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handleHealthz(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, _ = w.Write([]byte("ok\n"))
+}
+
+func (s *Server) handleReadyz(w http.ResponseWriter, _ *http.Request) {
+	response := readinessResponse{
+		Status: "ok",
+		Checks: make(map[string]string, len(s.checks)),
+	}
+
+	for name, check := range s.checks {
+		if err := check.Ready(); err != nil {
+			response.Status = "unavailable"
+			response.Checks[name] = err.Error()
+
+			continue
+		}
+
+		response.Checks[name] = "ok"
+	}
+
+	status := http.StatusOK
+	if response.Status != "ok" {
+		status = http.StatusServiceUnavailable
+	}
+
+	writeJSON(w, status, response)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,119 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rs/zerolog"
+
+	"github.com/hazim1093/zeta-comms/internal/config"
+	"github.com/hazim1093/zeta-comms/internal/server"
+)
+
+func TestServer(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Server Suite")
+}
+
+type fakeCheck struct {
+	err error
+}
+
+func (f *fakeCheck) Ready() error {
+	return f.err
+}
+
+var _ = Describe("Server", func() {
+	var (
+		log       zerolog.Logger
+		proposals *fakeCheck
+		notifiers *fakeCheck
+		srv       *server.Server
+	)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		srv.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+
+		return recorder
+	}
+
+	BeforeEach(func() {
+		log = zerolog.Nop()
+		proposals = &fakeCheck{}
+		notifiers = &fakeCheck{}
+		srv = server.NewServer(&config.Config{}, &log, map[string]server.ReadinessCheck{
+			"proposals": proposals,
+			"notifiers": notifiers,
+		})
+	})
+
+	It("should always report healthy", func() {
+		proposals.err = errors.New("stalled")
+
+		Expect(get("/healthz").Code).To(Equal(http.StatusOK))
+	})
+
+	It("should report ready when all checks pass", func() {
+		Expect(get("/readyz").Code).To(Equal(http.StatusOK))
+	})
+
+	It("should report the failing checks when not ready", func() {
+		proposals.err = errors.New("proposal polling stalled: mainnet (never polled)")
+
+		recorder := get("/readyz")
+		Expect(recorder.Code).To(Equal(http.StatusServiceUnavailable))
+
+		var body struct {
+			Status string            `json:"status"`
+			Checks map[string]string `json:"checks"`
+		}
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &body)).To(Succeed())
+		Expect(body.Status).To(Equal("unavailable"))
+		Expect(body.Checks).To(HaveKeyWithValue("proposals", "proposal polling stalled: mainnet (never polled)"))
+		Expect(body.Checks).To(HaveKeyWithValue("notifiers", "ok"))
+	})
+
+	It("should expose prometheus metrics", func() {
+		recorder := get("/metrics")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Body.String()).To(ContainSubstring("go_goroutines"))
+	})
+
+	It("should report when it has shut down after the context is cancelled", func() {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		address := listener.Addr().String()
+		Expect(listener.Close()).To(Succeed())
+
+		cfg := &config.Config{}
+		cfg.Server.ListenAddress = address
+		srv = server.NewServer(cfg, &log, nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := srv.Start(ctx)
+
+		Eventually(func() error {
+			response, err := http.Get("http://" + address + "/healthz")
+			if err == nil {
+				response.Body.Close()
+			}
+
+			return err
+		}).Should(Succeed())
+		Consistently(done, "50ms").ShouldNot(BeClosed())
+
+		cancel()
+		Eventually(done).Should(BeClosed())
+
+		_, err = http.Get("http://" + address + "/healthz")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -1,24 +1,62 @@
 package storage
 
 import (
+	"fmt"
 	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/hazim1093/zeta-comms/internal/config"
+	"github.com/hazim1093/zeta-comms/pkg/models"
 	"github.com/rs/zerolog"
 	"gopkg.in/yaml.v2"
 )
 
 type Data struct {
 	Networks map[string]NetworkData `yaml:"networks"`
+	Digests  map[string]DigestData  `yaml:"digests,omitempty"` // Keyed by audience
+
+	// Notifications held back during quiet hours, keyed by audience
+	Held map[string][]HeldNotification `yaml:"held,omitempty"`
+}
+
+// HeldNotification is a notification held back during the quiet hours of an audience. Failed lists
+// the deliveries that failed when it was released, it is then only sent to their channels again.
+type HeldNotification struct {
+	Notification models.Notification `yaml:"notification"`
+	Failed       []models.Delivery   `yaml:"failed,omitempty"`
+}
+
+// DigestData holds the events collected for the next digest of an audience
+type DigestData struct {
+	LastSent time.Time           `yaml:"lastSent,omitempty"`
+	Items    []models.DigestItem `yaml:"items,omitempty"`
 }
 
 type NetworkData struct {
-	LastProcessedProposalID string `yaml:"lastProcessedProposalId"`
+	LastProcessedProposalID string                    `yaml:"lastProcessedProposalId"`
+	Proposals               map[string]ProposalRecord `yaml:"proposals,omitempty"`
+}
+
+// ProposalRecord tracks a proposal that has been processed together with its delivery history
+type ProposalRecord struct {
+	ID         string            `json:"id" yaml:"id"`
+	Title      string            `json:"title" yaml:"title"`
+	Status     string            `json:"status" yaml:"status"`
+	FirstSeen  time.Time         `json:"first_seen" yaml:"firstSeen"`
+	Deliveries []models.Delivery `json:"deliveries" yaml:"deliveries,omitempty"`
 }
 
+// MaxDeliveries is the number of deliveries kept in the history of a proposal, older ones are dropped
+const MaxDeliveries = 50
+
 type StorageService struct {
 	config *config.Config
 	log    *zerolog.Logger
+	mu     sync.Mutex
 }
 
 func NewStorageService(cfg *config.Config, logger *zerolog.Logger) *StorageService {
@@ -31,48 +69,279 @@ func NewStorageService(cfg *config.Config, logger *zerolog.Logger) *StorageServi
 }
 
 func (s *StorageService) StoreLastProcessedProposalID(network string, proposalID string) error {
-	// Load existing data
+	return s.updateNetwork(network, func(networkData *NetworkData) {
+		networkData.LastProcessedProposalID = proposalID
+	})
+}
+
+func (s *StorageService) GetLastProcessedProposalID(network string) (string, error) {
+	networkData, err := s.getNetwork(network)
+	if err != nil {
+		return "", err
+	}
+
+	return networkData.LastProcessedProposalID, nil
+}
+
+// RecordProposal adds a proposal to the tracked proposals or updates its title and status
+func (s *StorageService) RecordProposal(network string, proposalID string, title string, status string) error {
+	return s.updateNetwork(network, func(networkData *NetworkData) {
+		record, ok := networkData.Proposals[proposalID]
+		if !ok {
+			record = ProposalRecord{
+				ID:        proposalID,
+				FirstSeen: time.Now().UTC(),
+			}
+		}
+
+		record.Title = title
+		record.Status = status
+		networkData.Proposals[proposalID] = record
+	})
+}
+
+// RecordDeliveries appends delivery results to the history of a tracked proposal, keeping the last
+// MaxDeliveries of them
+func (s *StorageService) RecordDeliveries(network string, proposalID string, deliveries []models.Delivery) error {
+	return s.updateNetwork(network, func(networkData *NetworkData) {
+		record, ok := networkData.Proposals[proposalID]
+		if !ok {
+			record = ProposalRecord{
+				ID:        proposalID,
+				FirstSeen: time.Now().UTC(),
+			}
+		}
+
+		record.Deliveries = append(record.Deliveries, deliveries...)
+		if excess := len(record.Deliveries) - MaxDeliveries; excess > 0 {
+			record.Deliveries = append([]models.Delivery(nil), record.Deliveries[excess:]...)
+		}
+
+		networkData.Proposals[proposalID] = record
+	})
+}
+
+// GetProposals returns the tracked proposals of a network ordered by proposal ID
+func (s *StorageService) GetProposals(network string) ([]ProposalRecord, error) {
+	networkData, err := s.getNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]ProposalRecord, 0, len(networkData.Proposals))
+	for _, record := range networkData.Proposals {
+		records = append(records, record)
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return lessProposalID(records[i].ID, records[j].ID)
+	})
+
+	return records, nil
+}
+
+// GetProposal returns a tracked proposal, or nil if the proposal is not tracked
+func (s *StorageService) GetProposal(network string, proposalID string) (*ProposalRecord, error) {
+	networkData, err := s.getNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+
+	record, ok := networkData.Proposals[proposalID]
+	if !ok {
+		return nil, nil
+	}
+
+	return &record, nil
+}
+
+// GetNetworks returns the stored data of all networks
+func (s *StorageService) GetNetworks() (map[string]NetworkData, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	data, err := loadYamlFile(s.config.Storage.Filename)
 	if err != nil {
-		// If file doesn't exist, create new data
 		if os.IsNotExist(err) {
-			data = Data{
-				Networks: make(map[string]NetworkData),
+			return map[string]NetworkData{}, nil
+		}
+
+		return nil, err
+	}
+
+	return data.Networks, nil
+}
+
+// ResetNetwork sets the last processed proposal ID of a network and forgets tracked proposals
+// after it. An empty ID clears all stored state of the network.
+func (s *StorageService) ResetNetwork(network string, lastProcessedProposalID string) error {
+	return s.updateNetwork(network, func(networkData *NetworkData) {
+		networkData.LastProcessedProposalID = lastProcessedProposalID
+
+		for id := range networkData.Proposals {
+			if lastProcessedProposalID == "" || lessProposalID(lastProcessedProposalID, id) {
+				delete(networkData.Proposals, id)
 			}
-		} else {
-			return err
 		}
+	})
+}
+
+// AddDigestItems stores events for the next digest of an audience
+func (s *StorageService) AddDigestItems(audience string, items ...models.DigestItem) error {
+	return s.update(func(data *Data) {
+		digest := data.Digests[audience]
+		digest.Items = append(digest.Items, items...)
+		data.Digests[audience] = digest
+	})
+}
+
+// GetDigest returns the events collected for the next digest of an audience
+func (s *StorageService) GetDigest(audience string) (DigestData, error) {
+	data, err := s.load()
+	if err != nil {
+		return DigestData{}, err
 	}
 
-	// Ensure Networks map is initialized
-	if data.Networks == nil {
-		data.Networks = make(map[string]NetworkData)
+	return data.Digests[audience], nil
+}
+
+// CompleteDigest marks the digest of an audience as sent at the given time and removes
+// the events collected up to then
+func (s *StorageService) CompleteDigest(audience string, sent time.Time) error {
+	return s.update(func(data *Data) {
+		digest := data.Digests[audience]
+		digest.LastSent = sent
+
+		pending := digest.Items[:0]
+		for _, item := range digest.Items {
+			if item.Time.After(sent) {
+				pending = append(pending, item)
+			}
+		}
+
+		digest.Items = pending
+		data.Digests[audience] = digest
+	})
+}
+
+// HoldNotification stores a notification to be sent to an audience after its quiet hours
+func (s *StorageService) HoldNotification(audience string, notification models.Notification) error {
+	return s.update(func(data *Data) {
+		data.Held[audience] = append(data.Held[audience], HeldNotification{Notification: notification})
+	})
+}
+
+// GetHeld returns the notifications held for an audience, oldest first
+func (s *StorageService) GetHeld(audience string) ([]HeldNotification, error) {
+	data, err := s.load()
+	if err != nil {
+		return nil, err
 	}
 
-	// Update or add the network's proposal ID
-	data.Networks[network] = NetworkData{
-		LastProcessedProposalID: proposalID,
+	return data.Held[audience], nil
+}
+
+// ReleaseHeld removes the first released notifications held for an audience, as returned by
+// GetHeld, and holds kept again in their place, ahead of the notifications held since
+func (s *StorageService) ReleaseHeld(audience string, released int, kept []HeldNotification) error {
+	return s.update(func(data *Data) {
+		held := data.Held[audience]
+		held = slices.Concat(kept, held[min(released, len(held)):])
+
+		if len(held) == 0 {
+			delete(data.Held, audience)
+
+			return
+		}
+
+		data.Held[audience] = held
+	})
+}
+
+func (s *StorageService) getNetwork(network string) (NetworkData, error) {
+	data, err := s.load()
+	if err != nil {
+		return NetworkData{}, err
 	}
 
-	return saveYamlFile(s.config.Storage.Filename, data)
+	return data.Networks[network], nil
 }
 
-func (s *StorageService) GetLastProcessedProposalID(network string) (string, error) {
+// load returns the stored data, which is empty if nothing has been stored yet
+func (s *StorageService) load() (Data, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	data, err := loadYamlFile(s.config.Storage.Filename)
 	if err != nil {
 		// If file doesn't exist, create new data
 		if os.IsNotExist(err) {
-			return "", nil // No data available for this network
-		} else {
-			return "", err
+			return Data{}, nil
 		}
+
+		return Data{}, err
 	}
 
-	if networkData, ok := data.Networks[network]; ok {
-		return networkData.LastProcessedProposalID, nil
+	return data, nil
+}
+
+// updateNetwork loads the stored data, applies update to the network's data and saves it back
+func (s *StorageService) updateNetwork(network string, update func(networkData *NetworkData)) error {
+	return s.update(func(data *Data) {
+		networkData := data.Networks[network]
+		if networkData.Proposals == nil {
+			networkData.Proposals = make(map[string]ProposalRecord)
+		}
+
+		update(&networkData)
+		data.Networks[network] = networkData
+	})
+}
+
+// update loads the stored data, applies update to it and saves it back
+func (s *StorageService) update(update func(data *Data)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Load existing data
+	data, err := loadYamlFile(s.config.Storage.Filename)
+	if err != nil {
+		// If file doesn't exist, create new data
+		if !os.IsNotExist(err) {
+			return fmt.Errorf("failed to load storage file: %w", err)
+		}
+
+		data = Data{}
+	}
+
+	// Ensure the maps are initialized
+	if data.Networks == nil {
+		data.Networks = make(map[string]NetworkData)
+	}
+
+	if data.Digests == nil {
+		data.Digests = make(map[string]DigestData)
+	}
+
+	if data.Held == nil {
+		data.Held = make(map[string][]HeldNotification)
+	}
+
+	update(&data)
+
+	return saveYamlFile(s.config.Storage.Filename, data)
+}
+
+// lessProposalID orders proposal IDs numerically, falling back to string comparison
+func lessProposalID(a string, b string) bool {
+	aInt, errA := strconv.ParseInt(a, 10, 64)
+	bInt, errB := strconv.ParseInt(b, 10, 64)
+
+	if errA != nil || errB != nil {
+		return a < b
 	}
 
-	return "", nil
+	return aInt < bInt
 }
 
 func saveYamlFile(filename string, data Data) error {
@@ -0,0 +1,138 @@
+package storage_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rs/zerolog"
+
+	"github.com/hazim1093/zeta-comms/internal/config"
+	"github.com/hazim1093/zeta-comms/internal/storage"
+	"github.com/hazim1093/zeta-comms/pkg/models"
+)
+
+func TestStorage(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Storage Suite")
+}
+
+var _ = Describe("StorageService", func() {
+	var (
+		cfg            *config.Config
+		storageService *storage.StorageService
+	)
+
+	BeforeEach(func() {
+		cfg = &config.Config{}
+		cfg.Storage.Filename = filepath.Join(GinkgoT().TempDir(), "state.yaml")
+
+		logger := zerolog.Nop()
+		storageService = storage.NewStorageService(cfg, &logger)
+	})
+
+	Context("ResetNetwork", func() {
+		BeforeEach(func() {
+			for _, id := range []string{"5", "9", "10"} {
+				Expect(storageService.RecordProposal("mainnet", id, "Proposal "+id, "PROPOSAL_STATUS_PASSED")).To(Succeed())
+			}
+
+			Expect(storageService.StoreLastProcessedProposalID("mainnet", "10")).To(Succeed())
+			Expect(storageService.StoreLastProcessedProposalID("testnet", "3")).To(Succeed())
+		})
+
+		ids := func() []string {
+			records, err := storageService.GetProposals("mainnet")
+			Expect(err).NotTo(HaveOccurred())
+
+			var ids []string
+			for _, record := range records {
+				ids = append(ids, record.ID)
+			}
+
+			return ids
+		}
+
+		It("should forget the proposals after the given last processed ID", func() {
+			Expect(storageService.ResetNetwork("mainnet", "9")).To(Succeed())
+
+			Expect(storageService.GetLastProcessedProposalID("mainnet")).To(Equal("9"))
+			Expect(ids()).To(Equal([]string{"5", "9"}))
+		})
+
+		It("should clear the network without a last processed ID", func() {
+			Expect(storageService.ResetNetwork("mainnet", "")).To(Succeed())
+
+			Expect(storageService.GetLastProcessedProposalID("mainnet")).To(BeEmpty())
+			Expect(ids()).To(BeEmpty())
+			Expect(storageService.GetLastProcessedProposalID("testnet")).To(Equal("3"))
+		})
+	})
+
+	It("should keep the latest deliveries of a proposal", func() {
+		for i := range storage.MaxDeliveries + 5 {
+			Expect(storageService.RecordDeliveries("mainnet", "7", []models.Delivery{
+				{Audience: "operators", Channel: fmt.Sprintf("channel-%d", i)},
+			})).To(Succeed())
+		}
+
+		record, err := storageService.GetProposal("mainnet", "7")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(record.Deliveries).To(HaveLen(storage.MaxDeliveries))
+		Expect(record.Deliveries[0].Channel).To(Equal("channel-5"))
+		Expect(record.Deliveries[storage.MaxDeliveries-1].Channel).To(Equal(fmt.Sprintf("channel-%d", storage.MaxDeliveries+4)))
+	})
+
+	It("should keep digest items across restarts until the digest is sent", func() {
+		start := time.Date(2025, 3, 6, 9, 0, 0, 0, time.UTC)
+		items := []models.DigestItem{
+			{Kind: models.DigestNewProposal, Time: start, Network: "mainnet", ProposalId: "7", Title: "Upgrade to v30"},
+			{Kind: models.DigestStatusChange, Time: start.Add(time.Hour), Network: "mainnet", ProposalId: "6", PreviousStatus: "PROPOSAL_STATUS_VOTING_PERIOD"},
+		}
+		Expect(storageService.AddDigestItems("operators", items...)).To(Succeed())
+
+		logger := zerolog.Nop()
+		restarted := storage.NewStorageService(cfg, &logger)
+
+		digest, err := restarted.GetDigest("operators")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(digest.Items).To(Equal(items))
+
+		Expect(restarted.CompleteDigest("operators", start.Add(30*time.Minute))).To(Succeed())
+
+		digest, err = storageService.GetDigest("operators")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(digest.LastSent).To(Equal(start.Add(30 * time.Minute)))
+		Expect(digest.Items).To(Equal(items[1:]))
+	})
+	It("should keep held notifications that could not be delivered ahead of newer ones", func() {
+		first := models.Notification{Network: "mainnet", ProposalId: "7", Title: "Upgrade to v30"}
+		second := models.Notification{Network: "mainnet", ProposalId: "8", Title: "Raise the gas limit"}
+		Expect(storageService.HoldNotification("operators", first)).To(Succeed())
+
+		held, err := storageService.GetHeld("operators")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(held).To(Equal([]storage.HeldNotification{{Notification: first}}))
+
+		// Held while the first one was being released
+		Expect(storageService.HoldNotification("operators", second)).To(Succeed())
+
+		failed := []models.Delivery{{Audience: "operators", Platform: "discord", Channel: "123", Error: "discord is down"}}
+		Expect(storageService.ReleaseHeld("operators", len(held), []storage.HeldNotification{{Notification: first, Failed: failed}})).To(Succeed())
+
+		state, err := os.ReadFile(cfg.Storage.Filename)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(state)).To(ContainSubstring("proposalId: \"7\""))
+
+		held, err = storageService.GetHeld("operators")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(held).To(Equal([]storage.HeldNotification{{Notification: first, Failed: failed}, {Notification: second}}))
+
+		Expect(storageService.ReleaseHeld("operators", len(held), nil)).To(Succeed())
+		Expect(storageService.GetHeld("operators")).To(BeEmpty())
+	})
+})
@@ -2,49 +2,72 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
-	"os/signal"
-	"syscall"
+	"strings"
+	_ "time/tzdata" // Audience timezones must resolve on hosts without a zoneinfo database
 
 	"github.com/hazim1093/zeta-comms/internal/comms"
 	"github.com/hazim1093/zeta-comms/internal/config"
 	"github.com/hazim1093/zeta-comms/internal/events"
+	"github.com/hazim1093/zeta-comms/internal/notifications"
+	"github.com/hazim1093/zeta-comms/internal/server"
 	"github.com/rs/zerolog"
+	"github.com/spf13/pflag"
 )
 
 func main() {
 	cfg, err := config.InitConfig()
-	if err != nil {
-		panic(err)
+
+	name, args := "run", pflag.Args()
+	if len(args) > 0 {
+		name, args = args[0], args[1:]
 	}
 
-	log := InitLogger(cfg.Logging.Format, cfg.Logging.Level)
+	cmd, ok := commands[name]
+	if !ok || len(args) < cmd.minArgs || (cmd.maxArgs >= 0 && len(args) > cmd.maxArgs) {
+		if name != "help" {
+			fmt.Fprintf(os.Stderr, "invalid command: %s\n\n", strings.Join(pflag.Args(), " "))
+		}
+
+		printUsage()
+		os.Exit(2)
+	}
 
-	log.Debug().Interface("config", cfg).Msg("config loaded")
+	if name == "validate" {
+		os.Exit(validateConfig(cfg, err))
+	}
 
-	// Create a context that can be cancelled
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	if err != nil {
+		panic(err)
+	}
 
-	// Set up signal handling for graceful shutdown
-	sigCh := make(chan os.Signal, 1)
-	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	log := InitLogger(cfg.Logging.Format, cfg.Logging.Level)
 
-	//----------------------------------------
-	startZetaComms(ctx, cfg, &log)
-	//----------------------------------------
+	if err := validate(cfg); err != nil {
+		logValidationErrors(&log, err)
 
-	// Wait for termination signal
-	sig := <-sigCh
-	log.Info().Msgf("Received signal %v, shutting down...", sig)
-	cancel() // This will propagate cancellation to the polling goroutine
+		if cmd.strict {
+			log.Fatal().Msg("Invalid configuration, refusing to start")
+		}
+	}
 
-	log.Info().Msg("Shutdown complete")
+	if err := cmd.run(cfg, &log, args); err != nil {
+		fmt.Fprintf(os.Stderr, "%s failed: %v\n", name, err)
+		os.Exit(1)
+	}
 }
 
-func startZetaComms(ctx context.Context, cfg *config.Config, log *zerolog.Logger) {
+// startZetaComms starts the service in the background until the context is cancelled. The returned
+// channel is closed once the HTTP server has shut down, it is nil if the server is disabled.
+func startZetaComms(ctx context.Context, cfg *config.Config, log *zerolog.Logger) (serverDone <-chan struct{}) {
 	govService := events.NewGovService(cfg, log)
+	nodeMonitor := events.NewNodeMonitor(cfg, log)
+	chainMonitor := events.NewChainMonitor(cfg, log)
 	commsEngine := comms.NewCommsEngine(cfg, log)
+	commsEngine.SetNodeMonitor(nodeMonitor)
+	commsEngine.SetChainMonitor(chainMonitor)
 
 	networks := cfg.Networks
 	for network := range networks {
@@ -56,18 +79,111 @@ func startZetaComms(ctx context.Context, cfg *config.Config, log *zerolog.Logger
 		}
 
 		go commsEngine.ProcessProposalUpdates(network, proposalsChannel)
+
+		if reportsChannel := nodeMonitor.StartMonitoring(ctx, network); reportsChannel != nil {
+			go commsEngine.ProcessNodeReports(network, reportsChannel)
+		}
+
+		if chainChannel := chainMonitor.StartMonitoring(ctx, network); chainChannel != nil {
+			go commsEngine.ProcessChainReports(network, chainChannel)
+		}
+	}
+
+	commsEngine.StartDigests(ctx)
+	commsEngine.StartQuietHours(ctx)
+
+	if cfg.Server.ListenAddress != "" {
+		httpServer := server.NewServer(cfg, log, map[string]server.ReadinessCheck{
+			"proposals": govService,
+			"notifiers": commsEngine,
+		})
+
+		if cfg.Server.Admin.Token != "" {
+			httpServer.EnableAdminAPI(commsEngine)
+		}
+
+		serverDone = httpServer.Start(ctx)
+	}
+
+	// Polling Telegram for updates would consume broadcast commands meant for the live instance
+	if cfg.DryRun.Enabled {
+		log.Info().Msg("Dry run enabled, not starting Telegram broadcast client")
+
+		return serverDone
 	}
 
 	broadcastChannel := events.StartTelegramBroadcastClient(log, cfg)
 	if broadcastChannel == nil {
 		log.Error().Msg("Failed to start Telegram broadcast client")
 
-		return
+		return serverDone
 	}
 
 	go commsEngine.ProcessBroadcastMessage(*broadcastChannel)
+
+	return serverDone
+}
+
+// validateConfig implements the validate subcommand and returns the process exit code
+func validateConfig(cfg *config.Config, loadErr error) int {
+	if loadErr != nil {
+		fmt.Fprintf(os.Stderr, "failed to load config: %v\n", loadErr)
+
+		return 1
+	}
+
+	if err := validate(cfg); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+
+		return 1
+	}
+
+	fmt.Printf("Configuration is valid (%s)\n", strings.Join(cfg.Sources, ", "))
+
+	return 0
+}
+
+// validate checks the configuration together with the templates it refers to
+func validate(cfg *config.Config) error {
+	var errs config.ValidationErrors
+
+	for _, err := range []error{cfg.Validate(), notifications.ValidateTemplates(cfg)} {
+		var validationErrs config.ValidationErrors
+
+		switch {
+		case err == nil:
+		case errors.As(err, &validationErrs):
+			errs = append(errs, validationErrs...)
+		default:
+			return err
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return cfg.Annotate(errs)
+}
+
+func logValidationErrors(log *zerolog.Logger, err error) {
+	var validationErrs config.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		log.Error().Err(err).Msg("Configuration problem")
+
+		return
+	}
+
+	for _, validationErr := range validationErrs {
+		log.Error().
+			Str("file", validationErr.File).
+			Str("key", validationErr.Key).
+			Msg(validationErr.Message)
+	}
 }
 
+// InitLogger creates the logger of the service. Logs go to stderr, so that they do not mix with the
+// output of subcommands, which may be piped.
 func InitLogger(logFormat string, globalLevel string) zerolog.Logger {
 	logLevel, err := zerolog.ParseLevel(globalLevel)
 	if err != nil {
@@ -80,12 +196,12 @@ func InitLogger(logFormat string, globalLevel string) zerolog.Logger {
 
 	switch logFormat {
 	case "console", "text":
-		consoleWriter := zerolog.ConsoleWriter{Out: os.Stdout}
+		consoleWriter := zerolog.ConsoleWriter{Out: os.Stderr}
 		logger = zerolog.New(consoleWriter).With().Timestamp().Logger()
 	case "json":
 		fallthrough
 	default:
-		logger = zerolog.New(os.Stdout).With().Timestamp().Logger()
+		logger = zerolog.New(os.Stderr).With().Timestamp().Logger()
 	}
 
 	return logger
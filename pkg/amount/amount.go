@@ -0,0 +1,122 @@
+// Package amount formats on-chain integer amounts as exact decimals, without the rounding errors
+// of floating point arithmetic
+package amount
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Format controls how amounts are displayed
+type Format struct {
+	Precision          int    // Number of decimals, rounded half up
+	ThousandsSeparator string // Inserted between groups of three integer digits, may be empty
+}
+
+// Parse parses an integer amount as returned by the Cosmos SDK REST API
+func Parse(value string) (*big.Int, error) {
+	amount, ok := new(big.Int).SetString(strings.TrimSpace(value), 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid amount %q", value)
+	}
+
+	return amount, nil
+}
+
+// Sum returns the sum of the given amounts
+func Sum(values ...*big.Int) *big.Int {
+	sum := new(big.Int)
+	for _, value := range values {
+		sum.Add(sum, value)
+	}
+
+	return sum
+}
+
+// Decimal formats value / 10^exponent, e.g. an amount in azeta with exponent 18 as ZETA
+func Decimal(value *big.Int, exponent int, format Format) string {
+	// value * 10^precision / 10^exponent is the result without its decimal point
+	scaled := new(big.Int).Set(value)
+
+	shift := exponent - format.Precision
+	if shift < 0 {
+		scaled.Mul(scaled, pow10(-shift))
+	} else {
+		scaled = divRound(scaled, pow10(shift))
+	}
+
+	return formatScaled(scaled, format)
+}
+
+// Percent formats part as a percentage of total, without the percent sign. A zero total yields zero.
+func Percent(part *big.Int, total *big.Int, format Format) string {
+	if total.Sign() == 0 {
+		return formatScaled(new(big.Int), format)
+	}
+
+	scaled := new(big.Int).Mul(part, pow10(format.Precision+2))
+
+	return formatScaled(divRound(scaled, total), format)
+}
+
+// formatScaled formats an integer that holds format.Precision decimals
+func formatScaled(scaled *big.Int, format Format) string {
+	sign := ""
+	if scaled.Sign() < 0 {
+		sign = "-"
+		scaled = new(big.Int).Neg(scaled)
+	}
+
+	digits := scaled.String()
+	if len(digits) <= format.Precision {
+		digits = strings.Repeat("0", format.Precision-len(digits)+1) + digits
+	}
+
+	integer := digits[:len(digits)-format.Precision]
+	fraction := digits[len(digits)-format.Precision:]
+
+	if format.ThousandsSeparator != "" {
+		integer = group(integer, format.ThousandsSeparator)
+	}
+
+	if fraction == "" {
+		return sign + integer
+	}
+
+	return sign + integer + "." + fraction
+}
+
+// group inserts separator between groups of three digits
+func group(digits string, separator string) string {
+	var b strings.Builder
+
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteString(separator)
+		}
+
+		b.WriteRune(digit)
+	}
+
+	return b.String()
+}
+
+// divRound divides and rounds half away from zero
+func divRound(dividend *big.Int, divisor *big.Int) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(dividend, divisor, new(big.Int))
+
+	if new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2)).Cmp(new(big.Int).Abs(divisor)) >= 0 {
+		if dividend.Sign()*divisor.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+
+	return quotient
+}
+
+func pow10(exponent int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil)
+}
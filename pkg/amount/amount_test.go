@@ -0,0 +1,63 @@
+package amount_test
+
+import (
+	"math/big"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/hazim1093/zeta-comms/pkg/amount"
+)
+
+func TestAmount(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Amount Suite")
+}
+
+func mustParse(value string) *big.Int {
+	parsed, err := amount.Parse(value)
+	Expect(err).NotTo(HaveOccurred())
+
+	return parsed
+}
+
+var _ = Describe("Amount", func() {
+	It("should reject values that are not integers", func() {
+		for _, value := range []string{"", "1.5", "1e18", "abc"} {
+			_, err := amount.Parse(value)
+			Expect(err).To(HaveOccurred(), value)
+		}
+	})
+
+	DescribeTable("Decimal",
+		func(value string, exponent int, format amount.Format, expected string) {
+			Expect(amount.Decimal(mustParse(value), exponent, format)).To(Equal(expected))
+		},
+		Entry("converts base units", "5000000000000000000", 18, amount.Format{Precision: 2}, "5.00"),
+		Entry("rounds half up", "1005000000000000000", 18, amount.Format{Precision: 2}, "1.01"),
+		Entry("rounds down below half", "1004999999999999999", 18, amount.Format{Precision: 2}, "1.00"),
+		Entry("pads small values", "1", 18, amount.Format{Precision: 3}, "0.000"),
+		Entry("keeps exact digits beyond float64", "123456789012345678901234567890", 18, amount.Format{Precision: 18},
+			"123456789012.345678901234567890"),
+		Entry("supports more decimals than the exponent", "15", 1, amount.Format{Precision: 3}, "1.500"),
+		Entry("omits the decimal point without precision", "2500000", 6, amount.Format{}, "3"),
+		Entry("groups thousands", "1234567890000000000000000", 18, amount.Format{Precision: 1, ThousandsSeparator: ","}, "1,234,567.9"),
+		Entry("formats negative values", "-1500", 3, amount.Format{Precision: 0, ThousandsSeparator: " "}, "-2"),
+	)
+
+	DescribeTable("Percent",
+		func(part string, total string, precision int, expected string) {
+			Expect(amount.Percent(mustParse(part), mustParse(total), amount.Format{Precision: precision})).To(Equal(expected))
+		},
+		Entry("rounds to the precision", "1", "3", 2, "33.33"),
+		Entry("rounds half up", "5", "9", 2, "55.56"),
+		Entry("handles a zero total", "0", "0", 2, "0.00"),
+		Entry("is exact for huge totals", "1", "100000000000000000000000000000", 30, "0.000000000000000000000000001000"),
+	)
+
+	It("should sum amounts", func() {
+		Expect(amount.Sum(mustParse("1"), mustParse("99999999999999999999")).String()).To(Equal("100000000000000000000"))
+		Expect(amount.Sum().Sign()).To(Equal(0))
+	})
+})
@@ -0,0 +1,49 @@
+package i18n
+
+// english is the default catalog, every message must be defined here
+var english = map[string]string{
+	"proposal":                  "Proposal",
+	"governance_message":        "Message from ZetaChain Governance",
+	"governance":                "ZetaChain Governance",
+	"id":                        "ID",
+	"status":                    "Status",
+	"upgrade":                   "Upgrade",
+	"target_height":             "Target Height",
+	"deposits":                  "Deposits",
+	"messages":                  "Messages",
+	"not_set":                   "not set",
+	"no_param_changes":          "No changes to the current params",
+	"params_unavailable":        "Diff unavailable, the current params could not be fetched",
+	"voting_results":            "Voting Results",
+	"yes":                       "Yes",
+	"no":                        "No",
+	"abstain":                   "Abstain",
+	"veto":                      "Veto",
+	"total_votes":               "Total Votes",
+	"submitted":                 "Submitted",
+	"voting_ends":               "Voting Ends",
+	"expedited":                 "Expedited",
+	"failed_reason":             "Failed Reason",
+	"summary":                   "Summary",
+	"read_more":                 "Read more",
+	"updated_at":                "Updated at",
+	"view_proposal":             "View proposal",
+	"vote":                      "Vote",
+	"part":                      "Part %d/%d",
+	"new_proposal_update":       "New proposal update: %s",
+	"new_governance_message":    "New message from ZetaChain Governance",
+	"new_proposal_notification": "New proposal notification for %s",
+	"status_voting_period":      "🗳️ Voting Period",
+	"status_passed":             "✅ Passed",
+	"status_rejected":           "❌ Rejected",
+	"relative_future":           "in %s",
+	"relative_past":             "%s ago",
+	"relative_now":              "now",
+	"digest_title":              "Governance Digest",
+	"new_proposals":             "New Proposals",
+	"status_changes":            "Status Changes",
+	"voting_deadlines":          "Voting Ends Soon",
+	"upgrades":                  "Upgrades",
+	"at_height":                 "at height %s",
+	"ops_alert":                 "Operational alert for %s",
+}
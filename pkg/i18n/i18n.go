@@ -0,0 +1,60 @@
+// Package i18n holds the message catalog used to localize notification text
+package i18n
+
+import "fmt"
+
+// DefaultLanguage is used for audiences without a language and for messages missing from a catalog
+const DefaultLanguage = "en"
+
+// Languages lists the supported language codes
+var Languages = []string{"en", "zh", "ko"}
+
+// catalogs maps language codes to messages keyed by message ID
+var catalogs = map[string]map[string]string{
+	"en": english,
+	"zh": chinese,
+	"ko": korean,
+}
+
+// Supported reports whether a language has a catalog, an empty language means DefaultLanguage
+func Supported(language string) bool {
+	_, ok := catalogs[language]
+
+	return ok || language == ""
+}
+
+// Translate returns the message with the given ID in a language, formatted with args if any are
+// given. Messages missing from the language's catalog fall back to English, unknown IDs to the ID.
+func Translate(language string, id string, args ...interface{}) string {
+	message, ok := catalogs[language][id]
+	if !ok {
+		message, ok = english[id]
+	}
+
+	if !ok {
+		message = id
+	}
+
+	if len(args) > 0 {
+		return fmt.Sprintf(message, args...)
+	}
+
+	return message
+}
+
+// IDs returns the IDs of all messages of the default language
+func IDs() []string {
+	ids := make([]string, 0, len(english))
+	for id := range english {
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
+// Has reports whether a language's catalog defines a message
+func Has(language string, id string) bool {
+	_, ok := catalogs[language][id]
+
+	return ok
+}
@@ -0,0 +1,39 @@
+package i18n_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/hazim1093/zeta-comms/pkg/i18n"
+)
+
+func TestI18n(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "I18n Suite")
+}
+
+var _ = Describe("Translate", func() {
+	It("should define every message in every language with the same arguments", func() {
+		for _, language := range i18n.Languages {
+			for _, id := range i18n.IDs() {
+				Expect(i18n.Has(language, id)).To(BeTrue(), "%s is missing %s", language, id)
+				Expect(strings.Count(i18n.Translate(language, id), "%")).To(Equal(strings.Count(i18n.Translate("en", id), "%")),
+					"%s has different arguments for %s", language, id)
+			}
+		}
+	})
+
+	It("should format arguments", func() {
+		Expect(i18n.Translate("zh", "part", 1, 3)).To(Equal("第 1/3 部分"))
+		Expect(i18n.Translate("ko", "relative_future", "2d 4h")).To(Equal("2d 4h 후"))
+	})
+
+	It("should fall back to English and to the message ID", func() {
+		Expect(i18n.Translate("", "voting_results")).To(Equal("Voting Results"))
+		Expect(i18n.Translate("fr", "voting_results")).To(Equal("Voting Results"))
+		Expect(i18n.Translate("ko", "unknown_message")).To(Equal("unknown_message"))
+	})
+})
@@ -0,0 +1,49 @@
+package i18n
+
+// korean is the Korean catalog
+var korean = map[string]string{
+	"proposal":                  "제안",
+	"governance_message":        "ZetaChain 거버넌스 메시지",
+	"governance":                "ZetaChain 거버넌스",
+	"id":                        "ID",
+	"status":                    "상태",
+	"upgrade":                   "업그레이드",
+	"target_height":             "목표 블록 높이",
+	"deposits":                  "예치금",
+	"messages":                  "메시지",
+	"not_set":                   "설정 안 됨",
+	"no_param_changes":          "현재 파라미터와 차이 없음",
+	"params_unavailable":        "현재 파라미터를 가져올 수 없어 차이를 표시할 수 없음",
+	"voting_results":            "투표 결과",
+	"yes":                       "찬성",
+	"no":                        "반대",
+	"abstain":                   "기권",
+	"veto":                      "거부권 행사",
+	"total_votes":               "총 투표수",
+	"submitted":                 "제출 시각",
+	"voting_ends":               "투표 마감",
+	"expedited":                 "긴급 처리",
+	"failed_reason":             "실패 사유",
+	"summary":                   "요약",
+	"read_more":                 "더 보기",
+	"updated_at":                "업데이트 시각",
+	"view_proposal":             "제안 보기",
+	"vote":                      "투표하기",
+	"part":                      "%d/%d 부분",
+	"new_proposal_update":       "제안 업데이트: %s",
+	"new_governance_message":    "ZetaChain 거버넌스의 새 메시지",
+	"new_proposal_notification": "%s 새 제안 알림",
+	"status_voting_period":      "🗳️ 투표 기간",
+	"status_passed":             "✅ 통과",
+	"status_rejected":           "❌ 부결",
+	"relative_future":           "%s 후",
+	"relative_past":             "%s 전",
+	"relative_now":              "지금",
+	"digest_title":              "거버넌스 요약",
+	"new_proposals":             "새 제안",
+	"status_changes":            "상태 변경",
+	"voting_deadlines":          "곧 마감되는 투표",
+	"upgrades":                  "업그레이드",
+	"at_height":                 "블록 높이 %s",
+	"ops_alert":                 "%s 운영 알림",
+}
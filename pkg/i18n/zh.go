@@ -0,0 +1,49 @@
+package i18n
+
+// chinese is the Simplified Chinese catalog
+var chinese = map[string]string{
+	"proposal":                  "提案",
+	"governance_message":        "ZetaChain 治理消息",
+	"governance":                "ZetaChain 治理",
+	"id":                        "编号",
+	"status":                    "状态",
+	"upgrade":                   "升级",
+	"target_height":             "目标高度",
+	"deposits":                  "押金",
+	"messages":                  "提案消息",
+	"not_set":                   "未设置",
+	"no_param_changes":          "与当前参数相同",
+	"params_unavailable":        "无法获取当前参数，差异不可用",
+	"voting_results":            "投票结果",
+	"yes":                       "赞成",
+	"no":                        "反对",
+	"abstain":                   "弃权",
+	"veto":                      "否决",
+	"total_votes":               "总票数",
+	"submitted":                 "提交时间",
+	"voting_ends":               "投票截止",
+	"expedited":                 "加急",
+	"failed_reason":             "失败原因",
+	"summary":                   "摘要",
+	"read_more":                 "阅读全文",
+	"updated_at":                "更新于",
+	"view_proposal":             "查看提案",
+	"vote":                      "投票",
+	"part":                      "第 %d/%d 部分",
+	"new_proposal_update":       "提案更新：%s",
+	"new_governance_message":    "来自 ZetaChain 治理的新消息",
+	"new_proposal_notification": "%s 新提案通知",
+	"status_voting_period":      "🗳️ 投票期",
+	"status_passed":             "✅ 已通过",
+	"status_rejected":           "❌ 已否决",
+	"relative_future":           "%s后",
+	"relative_past":             "%s前",
+	"relative_now":              "现在",
+	"digest_title":              "治理摘要",
+	"new_proposals":             "新提案",
+	"status_changes":            "状态变更",
+	"voting_deadlines":          "投票即将截止",
+	"upgrades":                  "升级",
+	"at_height":                 "于高度 %s",
+	"ops_alert":                 "%s 运维告警",
+}
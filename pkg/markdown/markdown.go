@@ -0,0 +1,332 @@
+// Package markdown converts the CommonMark written by proposal authors into the markup dialects
+// of the messaging platforms
+package markdown
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	extast "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// parser parses CommonMark with the GitHub extensions commonly used in release notes
+var parser = goldmark.New(
+	goldmark.WithExtensions(extension.Strikethrough, extension.Table, extension.Linkify),
+).Parser()
+
+// dialect describes how a platform marks up each element
+type dialect struct {
+	escape    func(text string) string
+	bold      func(text string) string
+	italic    func(text string) string
+	strike    func(text string) string
+	code      func(raw string) string
+	codeBlock func(language string, raw string) string
+	link      func(label string, url string) string
+	heading   func(text string) string
+	quote     func(text string) string
+}
+
+// slackEscape escapes the control characters of Slack mrkdwn, the only escaping Slack requires
+var slackEscape = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace
+
+var slack = dialect{
+	escape: slackEscape,
+	bold:   wrap("*", "*"),
+	italic: wrap("_", "_"),
+	strike: wrap("~", "~"),
+	code: func(raw string) string {
+		return "`" + slackEscape(raw) + "`"
+	},
+	codeBlock: func(_ string, raw string) string {
+		return "```\n" + slackEscape(raw) + "\n```"
+	},
+	link: func(label string, url string) string {
+		if label == "" || label == url {
+			return "<" + url + ">"
+		}
+
+		return "<" + url + "|" + label + ">"
+	},
+	heading: wrap("*", "*"),
+	quote:   prefixLines("> "),
+}
+
+var discord = dialect{
+	escape: strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "~", `\~`, "`", "\\`", "|", `\|`).Replace,
+	bold:   wrap("**", "**"),
+	italic: wrap("*", "*"),
+	strike: wrap("~~", "~~"),
+	code: func(raw string) string {
+		return "`" + raw + "`"
+	},
+	codeBlock: func(language string, raw string) string {
+		return "```" + language + "\n" + raw + "\n```"
+	},
+	link: func(label string, url string) string {
+		if label == "" || label == url {
+			return url
+		}
+
+		return "[" + label + "](" + escapeLinkURL(url) + ")"
+	},
+	heading: wrap("**", "**"),
+	quote:   prefixLines("> "),
+}
+
+// escapeLinkURL percent-encodes the characters that would end the URL of a Discord link early
+var escapeLinkURL = strings.NewReplacer("(", "%28", ")", "%29", " ", "%20").Replace
+
+var telegram = dialect{
+	escape: html.EscapeString,
+	bold:   wrap("<b>", "</b>"),
+	italic: wrap("<i>", "</i>"),
+	strike: wrap("<s>", "</s>"),
+	code: func(raw string) string {
+		return "<code>" + html.EscapeString(raw) + "</code>"
+	},
+	codeBlock: func(language string, raw string) string {
+		if language != "" {
+			return fmt.Sprintf(`<pre><code class="language-%s">%s</code></pre>`, html.EscapeString(language), html.EscapeString(raw))
+		}
+
+		return "<pre>" + html.EscapeString(raw) + "</pre>"
+	},
+	link: func(label string, url string) string {
+		return `<a href="` + html.EscapeString(url) + `">` + label + "</a>"
+	},
+	heading: wrap("<b>", "</b>"),
+	quote:   wrap("<blockquote>", "</blockquote>"),
+}
+
+// ToSlack converts Markdown to Slack mrkdwn
+func ToSlack(markdown string) string {
+	return convert(markdown, slack)
+}
+
+// ToDiscord converts Markdown to the Markdown dialect of Discord messages and embeds
+func ToDiscord(markdown string) string {
+	return convert(markdown, discord)
+}
+
+// ToTelegramHTML converts Markdown to the HTML subset supported by Telegram's HTML parse mode.
+// All text is escaped, so the result can be sent as is.
+func ToTelegramHTML(markdown string) string {
+	return convert(markdown, telegram)
+}
+
+func convert(markdown string, d dialect) string {
+	source := []byte(markdown)
+	document := parser.Parse(text.NewReader(source))
+
+	r := &converter{source: source, dialect: d}
+
+	return strings.TrimSpace(r.blocks(document, "\n\n"))
+}
+
+type converter struct {
+	source  []byte
+	dialect dialect
+}
+
+// blocks renders the block children of a node, separated by separator
+func (c *converter) blocks(node ast.Node, separator string) string {
+	var parts []string
+
+	for child := node.FirstChild(); child != nil; child = child.NextSibling() {
+		if part := c.block(child); part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	return strings.Join(parts, separator)
+}
+
+func (c *converter) block(node ast.Node) string {
+	d := c.dialect
+
+	switch n := node.(type) {
+	case *ast.Paragraph, *ast.TextBlock:
+		return c.inlines(n)
+	case *ast.Heading:
+		return d.heading(c.inlines(n))
+	case *ast.ThematicBreak:
+		return "———"
+	case *ast.CodeBlock:
+		return d.codeBlock("", c.lines(n))
+	case *ast.FencedCodeBlock:
+		return d.codeBlock(string(n.Language(c.source)), c.lines(n))
+	case *ast.Blockquote:
+		return d.quote(c.blocks(n, "\n\n"))
+	case *ast.List:
+		return c.list(n)
+	case *ast.HTMLBlock:
+		return d.escape(strings.TrimRight(c.lines(n), "\n"))
+	case *extast.Table:
+		return c.table(n)
+	default:
+		return c.blocks(n, "\n\n")
+	}
+}
+
+// list renders list items one per line, with nested content indented below its item
+func (c *converter) list(list *ast.List) string {
+	var lines []string
+
+	number := list.Start
+
+	for item := list.FirstChild(); item != nil; item = item.NextSibling() {
+		marker := "•"
+		if list.IsOrdered() {
+			marker = fmt.Sprintf("%d.", number)
+			number++
+		}
+
+		content := c.blocks(item, "\n")
+		indent := strings.Repeat(" ", len([]rune(marker))+1)
+
+		lines = append(lines, marker+" "+strings.ReplaceAll(content, "\n", "\n"+indent))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// table renders each row as a line of cells separated by vertical bars
+func (c *converter) table(table *extast.Table) string {
+	var rows []string
+
+	for row := table.FirstChild(); row != nil; row = row.NextSibling() {
+		var cells []string
+
+		for cell := row.FirstChild(); cell != nil; cell = cell.NextSibling() {
+			cells = append(cells, c.inlines(cell))
+		}
+
+		line := strings.Join(cells, " | ")
+		if _, ok := row.(*extast.TableHeader); ok {
+			line = c.dialect.bold(line)
+		}
+
+		rows = append(rows, line)
+	}
+
+	return strings.Join(rows, "\n")
+}
+
+// inlines renders the inline children of a node
+func (c *converter) inlines(node ast.Node) string {
+	var b strings.Builder
+
+	for child := node.FirstChild(); child != nil; child = child.NextSibling() {
+		b.WriteString(c.inline(child))
+	}
+
+	return b.String()
+}
+
+func (c *converter) inline(node ast.Node) string {
+	d := c.dialect
+
+	switch n := node.(type) {
+	case *ast.Text:
+		value := n.Segment.Value(c.source)
+		if !n.IsRaw() {
+			value = unescape(value)
+		}
+
+		value = []byte(d.escape(string(value)))
+		if n.SoftLineBreak() || n.HardLineBreak() {
+			value = append(value, '\n')
+		}
+
+		return string(value)
+	case *ast.String:
+		return d.escape(string(n.Value))
+	case *ast.Emphasis:
+		if n.Level >= 2 {
+			return d.bold(c.inlines(n))
+		}
+
+		return d.italic(c.inlines(n))
+	case *extast.Strikethrough:
+		return d.strike(c.inlines(n))
+	case *ast.CodeSpan:
+		return d.code(c.raw(n))
+	case *ast.Link:
+		return d.link(c.inlines(n), string(n.Destination))
+	case *ast.Image:
+		return d.link(c.inlines(n), string(n.Destination))
+	case *ast.AutoLink:
+		url := string(n.URL(c.source))
+
+		return d.link(d.escape(string(n.Label(c.source))), url)
+	case *ast.RawHTML:
+		var b strings.Builder
+		for i := 0; i < n.Segments.Len(); i++ {
+			segment := n.Segments.At(i)
+			b.Write(segment.Value(c.source))
+		}
+
+		return d.escape(b.String())
+	default:
+		return c.inlines(n)
+	}
+}
+
+// raw returns the unescaped text of a node's children, e.g. the content of a code span
+func (c *converter) raw(node ast.Node) string {
+	var b strings.Builder
+
+	for child := node.FirstChild(); child != nil; child = child.NextSibling() {
+		switch n := child.(type) {
+		case *ast.Text:
+			b.Write(n.Segment.Value(c.source))
+		case *ast.String:
+			b.Write(n.Value)
+		default:
+			b.WriteString(c.raw(n))
+		}
+	}
+
+	return b.String()
+}
+
+// lines returns the raw content of a block such as a code block
+func (c *converter) lines(node ast.Node) string {
+	var b strings.Builder
+
+	lines := node.Lines()
+	for i := 0; i < lines.Len(); i++ {
+		segment := lines.At(i)
+		b.Write(segment.Value(c.source))
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// unescape resolves backslash escapes and entity references, which goldmark leaves in the source text
+func unescape(value []byte) []byte {
+	return util.ResolveEntityNames(util.ResolveNumericReferences(util.UnescapePunctuations(value)))
+}
+
+func wrap(prefix string, suffix string) func(text string) string {
+	return func(text string) string {
+		if text == "" {
+			return ""
+		}
+
+		return prefix + text + suffix
+	}
+}
+
+func prefixLines(prefix string) func(text string) string {
+	return func(text string) string {
+		return prefix + strings.ReplaceAll(text, "\n", "\n"+prefix)
+	}
+}
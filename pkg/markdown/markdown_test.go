@@ -0,0 +1,89 @@
+package markdown_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/hazim1093/zeta-comms/pkg/markdown"
+)
+
+func TestMarkdown(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Markdown Suite")
+}
+
+const releaseNotes = `# Release v30
+
+This **upgrade** brings _new_ features, see [the notes](https://example.com/v30?a=1&b=2).
+
+- Fix ` + "`gas_price`" + ` <calc>
+- Drop ~~legacy~~ fees
+  1. nested
+
+> Upgrade before block 5000000
+
+` + "```go\nfunc main() {}\n```"
+
+var _ = Describe("Markdown", func() {
+	It("should convert to Slack mrkdwn", func() {
+		Expect(markdown.ToSlack(releaseNotes)).To(Equal(`*Release v30*
+
+This *upgrade* brings _new_ features, see <https://example.com/v30?a=1&b=2|the notes>.
+
+• Fix ` + "`gas_price`" + ` &lt;calc&gt;
+• Drop ~legacy~ fees
+  1. nested
+
+> Upgrade before block 5000000
+
+` + "```\nfunc main() {}\n```"))
+	})
+
+	It("should convert to Discord markdown", func() {
+		Expect(markdown.ToDiscord(releaseNotes)).To(Equal(`**Release v30**
+
+This **upgrade** brings *new* features, see [the notes](https://example.com/v30?a=1&b=2).
+
+• Fix ` + "`gas_price`" + ` <calc>
+• Drop ~~legacy~~ fees
+  1. nested
+
+> Upgrade before block 5000000
+
+` + "```go\nfunc main() {}\n```"))
+	})
+
+	It("should convert to Telegram HTML", func() {
+		Expect(markdown.ToTelegramHTML(releaseNotes)).To(Equal(`<b>Release v30</b>
+
+This <b>upgrade</b> brings <i>new</i> features, see <a href="https://example.com/v30?a=1&amp;b=2">the notes</a>.
+
+• Fix <code>gas_price</code> &lt;calc&gt;
+• Drop <s>legacy</s> fees
+  1. nested
+
+<blockquote>Upgrade before block 5000000</blockquote>
+
+<pre><code class="language-go">func main() {}</code></pre>`))
+	})
+
+	It("should escape literal markup characters for Discord", func() {
+		Expect(markdown.ToDiscord(`Set block\_max\_gas to 2\*10^7`)).To(Equal(`Set block\_max\_gas to 2\*10^7`))
+	})
+
+	It("should escape parentheses and spaces in Discord link URLs", func() {
+		Expect(markdown.ToDiscord("See [the spec](<https://example.com/Upgrade (v30)>)")).
+			To(Equal("See [the spec](https://example.com/Upgrade%20%28v30%29)"))
+		Expect(markdown.ToDiscord("See [the wiki](https://en.wikipedia.org/wiki/Fork_(blockchain))")).
+			To(Equal("See [the wiki](https://en.wikipedia.org/wiki/Fork_%28blockchain%29)"))
+	})
+
+	It("should keep plain text unchanged", func() {
+		text := "Upgrade the chain to v30.\nNo action is required."
+
+		Expect(markdown.ToSlack(text)).To(Equal(text))
+		Expect(markdown.ToTelegramHTML(text)).To(Equal(text))
+	})
+})
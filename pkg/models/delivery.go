@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// Delivery records the outcome of sending a notification to a single destination
+type Delivery struct {
+	Audience string    `json:"audience" yaml:"audience"`
+	Platform string    `json:"platform" yaml:"platform"`
+	Channel  string    `json:"channel" yaml:"channel"`
+	Time     time.Time `json:"time" yaml:"time"`
+	Error    string    `json:"error,omitempty" yaml:"error,omitempty"`
+	Manual   bool      `json:"manual,omitempty" yaml:"manual,omitempty"` // Triggered by an operator rather than by polling
+}
+
+// Succeeded reports whether the notification was delivered
+func (d Delivery) Succeeded() bool {
+	return d.Error == ""
+}
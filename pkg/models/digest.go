@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// Kinds of events collected for digests
+const (
+	DigestNewProposal  = "new_proposal"
+	DigestStatusChange = "status_change"
+)
+
+// DigestItem is a proposal event waiting to be sent with the next digest of an audience
+type DigestItem struct {
+	Kind           string    `json:"kind" yaml:"kind"`
+	Time           time.Time `json:"time" yaml:"time"`
+	Network        string    `json:"network" yaml:"network"`
+	ProposalId     string    `json:"proposal_id" yaml:"proposalId"`
+	Title          string    `json:"title" yaml:"title"`
+	Status         string    `json:"status" yaml:"status"`
+	PreviousStatus string    `json:"previous_status,omitempty" yaml:"previousStatus,omitempty"`
+	UpgradeName    string    `json:"upgrade_name,omitempty" yaml:"upgradeName,omitempty"`
+	TargetHeight   string    `json:"target_height,omitempty" yaml:"targetHeight,omitempty"`
+	VotingEndTime  time.Time `json:"voting_end_time,omitempty" yaml:"votingEndTime,omitempty"`
+	ProposalURL    string    `json:"proposal_url,omitempty" yaml:"proposalUrl,omitempty"`
+}
+
+// Digest summarizes the proposal events of a period for one audience
+type Digest struct {
+	Since time.Time `yaml:"since,omitempty"` // Time of the previous digest, zero for the first one
+	Until time.Time `yaml:"until"`
+
+	NewProposals  []DigestItem `yaml:"newProposals,omitempty"`
+	StatusChanges []DigestItem `yaml:"statusChanges,omitempty"`
+	Deadlines     []DigestItem `yaml:"deadlines,omitempty"` // Proposals whose voting period ends before the next digest
+	Upgrades      []DigestItem `yaml:"upgrades,omitempty"`  // Software upgrades among the new proposals and status changes
+}
+
+// Empty reports whether the digest has nothing to report
+func (d Digest) Empty() bool {
+	return len(d.NewProposals) == 0 && len(d.StatusChanges) == 0 && len(d.Deadlines) == 0
+}
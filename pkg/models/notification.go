@@ -6,37 +6,118 @@ import (
 	"github.com/hazim1093/zeta-comms/pkg/zetachain"
 )
 
+// Event types a notification can describe, each rendered with its own template
+const (
+	EventProposal  = "proposal"
+	EventBroadcast = "broadcast"
+	EventDigest    = "digest"
+	EventAlert     = "alert" // Operational problems of zeta-comms itself, sent to the alerts audience
+)
+
+// Severities of notifications, critical notifications are delivered even during quiet hours
+const (
+	SeverityNormal   = "normal"
+	SeverityCritical = "critical"
+)
+
+// EventTypes lists all event types that can be rendered
+var EventTypes = []string{EventProposal, EventBroadcast, EventDigest, EventAlert}
+
 // Notification represents a formatted notification about a proposal
 type Notification struct {
-	Network string
+	EventType string `yaml:"eventType,omitempty"`
+	Network   string `yaml:"network,omitempty"`
+	Severity  string `yaml:"severity,omitempty"` // SeverityNormal or SeverityCritical, empty means normal
+
+	// SeverityReason explains why a notification is critical
+	SeverityReason string `yaml:"severityReason,omitempty"`
 
 	// Core proposal data
-	ProposalId string
-	Title      string
-	Summary    string
-	Status     string
+	ProposalId string `yaml:"proposalId,omitempty"`
+	Title      string `yaml:"title,omitempty"`
+	Summary    string `yaml:"summary,omitempty"`
+	Status     string `yaml:"status,omitempty"`
+
+	// ProposalURL links to the proposal in an explorer, empty if no explorer is configured
+	ProposalURL string `yaml:"proposalUrl,omitempty"`
+	// VoteURL links to the proposal in a governance portal, empty if no portal is configured
+	VoteURL string `yaml:"voteUrl,omitempty"`
+	// Truncated is set when the summary was shortened to fit the platform's length limit
+	Truncated bool `yaml:"truncated,omitempty"`
 
 	// Software upgrade specific
-	UpgradeName  string
-	TargetHeight string
-	BinaryURLs   map[string]string
-	Checksums    map[string]string
+	UpgradeName  string            `yaml:"upgradeName,omitempty"`
+	TargetHeight string            `yaml:"targetHeight,omitempty"`
+	BinaryURLs   map[string]string `yaml:"binaryUrls,omitempty"`
+	Checksums    map[string]string `yaml:"checksums,omitempty"`
 
 	// Voting data
-	YesVotes     string
-	NoVotes      string
-	AbstainVotes string
-	VetoVotes    string
-	TotalVotes   string
+	YesVotes     string `yaml:"yesVotes,omitempty"`
+	NoVotes      string `yaml:"noVotes,omitempty"`
+	AbstainVotes string `yaml:"abstainVotes,omitempty"`
+	VetoVotes    string `yaml:"vetoVotes,omitempty"`
+	TotalVotes   string `yaml:"totalVotes,omitempty"`
 
 	// Timeline
-	SubmitTime    time.Time
-	VotingEndTime time.Time
+	SubmitTime    time.Time `yaml:"submitTime,omitempty"`
+	VotingEndTime time.Time `yaml:"votingEndTime,omitempty"`
 
 	// Status flags
-	Expedited    bool
-	FailedReason string
+	Expedited    bool   `yaml:"expedited,omitempty"`
+	FailedReason string `yaml:"failedReason,omitempty"`
 
 	// Deposit info
-	TotalDeposit []zetachain.Deposit
+	TotalDeposit []zetachain.Deposit `yaml:"totalDeposit,omitempty"`
+
+	// Messages lists the messages of the proposal with the key fields of their types
+	Messages []ProposalMessage `yaml:"messages,omitempty"`
+
+	// Digest holds the collected events of digest notifications
+	Digest *Digest `yaml:"digest,omitempty"`
+}
+
+// ProposalMessage is a message of a proposal, e.g. /cosmos.gov.v1.MsgUpdateParams
+type ProposalMessage struct {
+	Type   string         `yaml:"type"`
+	Fields []MessageField `yaml:"fields,omitempty"`
+
+	// ParamsCompared is set if the params of a MsgUpdateParams were compared to the current params
+	// of the module, Changes then lists the params that differ
+	ParamsCompared bool          `yaml:"paramsCompared,omitempty"`
+	Changes        []ParamChange `yaml:"changes,omitempty"`
+
+	// ParamsUnavailable is set if the params of a MsgUpdateParams could not be compared
+	ParamsUnavailable bool `yaml:"paramsUnavailable,omitempty"`
+}
+
+// MessageField is a key field of a proposal message, e.g. the new value of a param
+type MessageField struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
+}
+
+// ParamChange is a param whose value a proposal changes, Old or New is empty if the param is added or removed
+type ParamChange struct {
+	Name string `yaml:"name"`
+	Old  string `yaml:"old,omitempty"`
+	New  string `yaml:"new,omitempty"`
+}
+
+// Critical reports whether the notification must be delivered even during quiet hours
+func (n Notification) Critical() bool {
+	return n.Severity == SeverityCritical
+}
+
+// Event returns the event type of the notification. Notifications without an explicit
+// type are treated as proposals if they reference one and as broadcasts otherwise.
+func (n Notification) Event() string {
+	if n.EventType != "" {
+		return n.EventType
+	}
+
+	if n.ProposalId != "" {
+		return EventProposal
+	}
+
+	return EventBroadcast
 }
@@ -1,15 +1,24 @@
 package notifiers
 
+import "github.com/hazim1093/zeta-comms/pkg/i18n"
+
+// statusMessages maps proposal statuses to their message IDs in the i18n catalog
+var statusMessages = map[string]string{
+	"PROPOSAL_STATUS_VOTING_PERIOD": "status_voting_period",
+	"PROPOSAL_STATUS_PASSED":        "status_passed",
+	"PROPOSAL_STATUS_REJECTED":      "status_rejected",
+}
+
 // FormatStatus returns a human-readable version of the proposal status
 func FormatStatus(status string) string {
-	switch status {
-	case "PROPOSAL_STATUS_VOTING_PERIOD":
-		return "🗳️ Voting Period"
-	case "PROPOSAL_STATUS_PASSED":
-		return "✅ Passed"
-	case "PROPOSAL_STATUS_REJECTED":
-		return "❌ Rejected"
-	default:
-		return status
+	return StatusText(i18n.DefaultLanguage, status)
+}
+
+// StatusText returns a human-readable version of the proposal status in a language
+func StatusText(language string, status string) string {
+	if id, ok := statusMessages[status]; ok {
+		return i18n.Translate(language, id)
 	}
+
+	return status
 }
@@ -1,6 +1,7 @@
 package discord
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/bwmarrin/discordgo"
@@ -42,17 +43,97 @@ func InitializeDiscordClient(logger *zerolog.Logger, botToken string) (*DiscordC
 }
 
 // Send implements the notifier.Notifier interface
-func (c *DiscordClient) Send(destination string, notification models.Notification) error {
+func (c *DiscordClient) Send(destination string, notification models.Notification, options notifiers.RenderOptions) error {
 	if c.session == nil {
 		return fmt.Errorf("discord client not initialized")
 	}
 
 	c.log.Debug().Msg("Sending Discord notification to channel: " + destination)
 
-	embed := formatNotification(notification)
-	content := fmt.Sprintf("New proposal update: %s", notification.Title)
+	messages, err := buildMessages(notification, options)
+	if err != nil {
+		return err
+	}
+
+	for i, message := range messages {
+		if err := c.SendMessage(destination, message); err != nil {
+			return fmt.Errorf("failed to send message %d of %d: %w", i+1, len(messages), err)
+		}
+	}
+
+	return nil
+}
+
+// Render returns the message payload that would be sent for a notification, or a list of
+// payloads if the notification is split into several messages
+func Render(notification models.Notification, options notifiers.RenderOptions) ([]byte, error) {
+	messages, err := buildMessages(notification, options)
+	if err != nil {
+		return nil, err
+	}
 
-	return c.SendChannelMessage(destination, content, embed)
+	if len(messages) == 1 {
+		return json.MarshalIndent(messages[0], "", "  ")
+	}
+
+	return json.MarshalIndent(messages, "", "  ")
+}
+
+// buildMessages creates the complete Discord messages for a notification, usually just one
+func buildMessages(notification models.Notification, options notifiers.RenderOptions) ([]*discordgo.MessageSend, error) {
+	embeds, err := formatNotification(notification, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var content string
+
+	switch notification.Event() {
+	case models.EventBroadcast:
+		content = options.T("new_governance_message")
+	case models.EventDigest:
+		content = options.T("digest_title")
+	case models.EventAlert:
+		content = options.T("ops_alert", notification.Network)
+	default:
+		content = options.T("new_proposal_update", notification.Title)
+	}
+
+	messages := make([]*discordgo.MessageSend, 0, len(embeds))
+	for i, embed := range embeds {
+		message := &discordgo.MessageSend{Embeds: []*discordgo.MessageEmbed{embed}, AllowedMentions: noMentions()}
+		if i == 0 {
+			message.Content = notifiers.TruncateText(content, MaxContentLength)
+		}
+
+		// Link buttons go below the last part
+		if i == len(embeds)-1 {
+			message.Components = linkButtons(notification, options)
+		}
+
+		messages = append(messages, message)
+	}
+
+	return messages, nil
+}
+
+// linkButtons returns a row of link buttons for the notification's links, if it has any
+func linkButtons(notification models.Notification, options notifiers.RenderOptions) []discordgo.MessageComponent {
+	links := notifiers.Links(notification, options)
+	if len(links) == 0 {
+		return nil
+	}
+
+	buttons := make([]discordgo.MessageComponent, 0, len(links))
+	for _, link := range links {
+		buttons = append(buttons, discordgo.Button{
+			Label: link.Label,
+			Style: discordgo.LinkButton,
+			URL:   link.URL,
+		})
+	}
+
+	return []discordgo.MessageComponent{discordgo.ActionsRow{Components: buttons}}
 }
 
 // Name implements the notifier.Notifier interface
@@ -92,10 +173,22 @@ func (c *DiscordClient) Close() error {
 
 // SendChannelMessage sends a message to a Discord channel
 func (c *DiscordClient) SendChannelMessage(channelID string, content string, embed *discordgo.MessageEmbed) error {
-	_, err := c.session.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
-		Content: content,
-		Embeds:  []*discordgo.MessageEmbed{embed},
+	return c.SendMessage(channelID, &discordgo.MessageSend{
+		Content:         content,
+		Embeds:          []*discordgo.MessageEmbed{embed},
+		AllowedMentions: noMentions(),
 	})
+}
+
+// noMentions keeps Discord from resolving mentions in the text of a message, such as @everyone or
+// <@&role> in a proposal title
+func noMentions() *discordgo.MessageAllowedMentions {
+	return &discordgo.MessageAllowedMentions{Parse: []discordgo.AllowedMentionType{}}
+}
+
+// SendMessage sends a complete message to a Discord channel
+func (c *DiscordClient) SendMessage(channelID string, message *discordgo.MessageSend) error {
+	_, err := c.session.ChannelMessageSendComplex(channelID, message)
 	if err != nil {
 		return fmt.Errorf("error sending message to Discord channel: %w", err)
 	}
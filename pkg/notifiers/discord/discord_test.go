@@ -0,0 +1,121 @@
+package discord_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/hazim1093/zeta-comms/pkg/models"
+	"github.com/hazim1093/zeta-comms/pkg/notifiers"
+	"github.com/hazim1093/zeta-comms/pkg/notifiers/discord"
+)
+
+func TestDiscord(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Discord Suite")
+}
+
+// renderedMessage is a rendered Discord message, whose components cannot be unmarshalled into discordgo types
+type renderedMessage struct {
+	Embeds     []*discordgo.MessageEmbed `json:"embeds"`
+	Components []json.RawMessage         `json:"components"`
+}
+
+var _ = Describe("Render", func() {
+	It("should use Discord timestamps, which readers see in their own timezone", func() {
+		payload, err := discord.Render(models.Notification{
+			EventType:     models.EventProposal,
+			Network:       "mainnet",
+			ProposalId:    "42",
+			Title:         "Upgrade v30",
+			VotingEndTime: time.Date(2025, 3, 6, 14, 30, 0, 0, time.UTC),
+		}, notifiers.RenderOptions{
+			Time: notifiers.TimeOptions{Location: time.FixedZone("UTC+9", 9*60*60), Layout: time.Kitchen},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		var message discordgo.MessageSend
+		Expect(json.Unmarshal(payload, &message)).To(Succeed())
+		Expect(message.Embeds).To(HaveLen(1))
+		Expect(message.Embeds[0].Description).To(ContainSubstring("**Voting Ends:** <t:1741271400:f> (<t:1741271400:R>)\n"))
+	})
+
+	It("should not let the title mention anyone", func() {
+		payload, err := discord.Render(models.Notification{
+			EventType:  models.EventProposal,
+			Network:    "mainnet",
+			ProposalId: "42",
+			Title:      "@everyone <@&123> Upgrade v30",
+		}, notifiers.RenderOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		var message discordgo.MessageSend
+		Expect(json.Unmarshal(payload, &message)).To(Succeed())
+		Expect(message.Content).To(ContainSubstring("@everyone"))
+		Expect(message.AllowedMentions).NotTo(BeNil())
+		Expect(message.AllowedMentions.Parse).To(BeEmpty())
+		Expect(string(payload)).To(ContainSubstring(`"parse": []`))
+	})
+
+	Context("with a summary longer than the description limit", func() {
+		var notification models.Notification
+
+		BeforeEach(func() {
+			notification = models.Notification{
+				EventType:   models.EventProposal,
+				Network:     "mainnet",
+				ProposalId:  "42",
+				Title:       "Upgrade v30",
+				Status:      "PROPOSAL_STATUS_VOTING_PERIOD",
+				Summary:     strings.Repeat("The upgrade migrates the observer state. ", 30),
+				ProposalURL: "https://explorer.example.com/proposals/42",
+				VoteURL:     "https://governance.example.com/proposals/42",
+			}
+		})
+
+		It("should truncate the summary and link to the proposal", func() {
+			payload, err := discord.Render(notification, notifiers.RenderOptions{
+				Overflow: notifiers.Overflow{MaxLength: 500},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			var message renderedMessage
+			Expect(json.Unmarshal(payload, &message)).To(Succeed())
+			Expect(message.Embeds).To(HaveLen(1))
+			Expect(notifiers.TextLength(message.Embeds[0].Description)).To(BeNumerically("<=", 500))
+			Expect(message.Embeds[0].Description).To(HaveSuffix("… [Read more](https://explorer.example.com/proposals/42)"))
+		})
+
+		It("should split the description over several messages with the buttons below the last", func() {
+			payload, err := discord.Render(notification, notifiers.RenderOptions{
+				Overflow: notifiers.Overflow{Mode: notifiers.OverflowSplit, MaxLength: 500},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			var messages []renderedMessage
+			Expect(json.Unmarshal(payload, &messages)).To(Succeed())
+			Expect(len(messages)).To(BeNumerically(">", 2))
+
+			var description string
+
+			for i, message := range messages {
+				Expect(message.Embeds).To(HaveLen(1))
+				Expect(notifiers.TextLength(message.Embeds[0].Description)).To(BeNumerically("<=", 500))
+				Expect(message.Embeds[0].Footer.Text).To(HaveSuffix(fmt.Sprintf("Part %d/%d", i+1, len(messages))))
+				Expect(message.Components != nil).To(Equal(i == len(messages)-1))
+
+				description += message.Embeds[0].Description + " "
+			}
+
+			Expect(messages[0].Embeds[0].Title).To(Equal("[mainnet] Proposal #42: Upgrade v30"))
+			Expect(messages[1].Embeds[0].Title).To(BeEmpty())
+			Expect(strings.Count(description, "The upgrade migrates the observer state.")).To(Equal(30))
+		})
+	})
+})
@@ -1,92 +1,112 @@
 package discord
 
 import (
+	"embed"
 	"fmt"
+	"text/template"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/hazim1093/zeta-comms/pkg/markdown"
 	"github.com/hazim1093/zeta-comms/pkg/models"
 	"github.com/hazim1093/zeta-comms/pkg/notifiers"
 )
 
-// formatNotification creates a formatted Discord embed for a notification
-func formatNotification(notification models.Notification) *discordgo.MessageEmbed {
-	// Create a rich embed for the notification
-	color := getColorForStatus(notification.Status)
+//go:embed templates/*.tmpl
+var templateFS embed.FS
 
-	title := "Message from ZetaChain Governance"
-	if notification.Title != "" {
-		title = fmt.Sprintf("[%s] Proposal #%s: %s", notification.Network, notification.ProposalId, notification.Title)
-	}
+var renderer = notifiers.NewTemplateRenderer("discord", templateFS, timestampFuncs)
 
-	embed := &discordgo.MessageEmbed{
-		Title:     title,
-		Color:     color,
-		Timestamp: time.Now().Format(time.RFC3339),
-		Footer: &discordgo.MessageEmbedFooter{
-			Text: "ZetaChain Governance",
+// timestampFuncs renders timestamps as Discord timestamp markup, which every reader sees in
+// their own timezone and locale
+func timestampFuncs(_ notifiers.RenderOptions) template.FuncMap {
+	return template.FuncMap{
+		"time": func(t time.Time) string {
+			return fmt.Sprintf("<t:%d:f>", t.Unix())
+		},
+		"relative": func(t time.Time) string {
+			return fmt.Sprintf("<t:%d:R>", t.Unix())
 		},
 	}
+}
 
-	// Build description
-	description := ""
+// ParseTemplate parses a custom Discord template, which renders the embed description.
+// It may define a "title" template for the embed title.
+func ParseTemplate(name string, text string) (*template.Template, error) {
+	return renderer.Parse(name, text)
+}
 
-	if notification.ProposalId != "" {
-		description = fmt.Sprintf("**ID:** %s\n**Status:** %s\n\n", notification.ProposalId, notifiers.FormatStatus(notification.Status))
-	}
+// Length limits of Discord messages
+const (
+	MaxContentLength     = 2000
+	MaxTitleLength       = 256
+	MaxDescriptionLength = 4096
+)
 
-	// Add software upgrade info if available
-	if notification.UpgradeName != "" {
-		description += fmt.Sprintf("**Upgrade:** %s\n**Target Height:** %s\n\n", notification.UpgradeName, notification.TargetHeight)
+// formatNotification creates the formatted Discord embeds for a notification. It returns several
+// embeds, each to be sent as its own message, only if the description is too long and the
+// overflow mode is split.
+func formatNotification(notification models.Notification, options notifiers.RenderOptions) ([]*discordgo.MessageEmbed, error) {
+	title, err := renderer.RenderTitle(notification, options)
+	if err != nil {
+		return nil, err
 	}
 
-	// Add deposit information if available
-	if len(notification.TotalDeposit) > 0 {
-		description += "**Deposits:**\n"
-		for _, deposit := range notification.TotalDeposit {
-			description += fmt.Sprintf("• %s %s\n", deposit.Amount, deposit.Denom)
-		}
+	render := func(notification models.Notification) (string, error) {
+		notification.Summary = markdown.ToDiscord(notification.Summary)
 
-		description += "\n"
+		return renderer.Render(notification, options)
 	}
 
-	if notification.TotalVotes != "" {
-		description += "*Voting Results:*\n"
-		description += fmt.Sprintf("• Yes: %s\n", notification.YesVotes)
-		description += fmt.Sprintf("• No: %s\n", notification.NoVotes)
-		description += fmt.Sprintf("• Abstain: %s\n", notification.AbstainVotes)
-		description += fmt.Sprintf("• Veto: %s\n", notification.VetoVotes)
-		description += "\n"
-		description += "*Total Votes:* " + notification.TotalVotes + "\n"
-	}
+	maxLength := options.Overflow.Limit(MaxDescriptionLength)
 
-	description += "\n"
+	var descriptions []string
 
-	// Add timeline information
-	if !notification.SubmitTime.IsZero() {
-		description += fmt.Sprintf("**Submitted:** %s\n", notification.SubmitTime.Format(time.RFC1123))
-	}
+	if options.Overflow.Split() {
+		description, err := render(notification)
+		if err != nil {
+			return nil, err
+		}
 
-	if !notification.VotingEndTime.IsZero() {
-		description += fmt.Sprintf("**Voting Ends:** %s\n", notification.VotingEndTime.Format(time.RFC1123))
-	}
+		descriptions = notifiers.SplitText(description, maxLength, nil)
+		if len(descriptions) == 0 {
+			descriptions = []string{""}
+		}
+	} else {
+		description, err := notifiers.FitSummary(notification, maxLength, render)
+		if err != nil {
+			return nil, err
+		}
 
-	// Add expedited flag if true
-	if notification.Expedited {
-		description += "**Expedited:** Yes\n"
+		descriptions = []string{description}
 	}
 
-	// Add failed reason if available
-	if notification.FailedReason != "" {
-		description += fmt.Sprintf("**Failed Reason:** %s\n", notification.FailedReason)
-	}
+	embeds := make([]*discordgo.MessageEmbed, 0, len(descriptions))
+
+	for i, description := range descriptions {
+		// Create a rich embed for the notification
+		embed := &discordgo.MessageEmbed{
+			Description: description,
+			Color:       getColorForStatus(notification.Status),
+			Timestamp:   time.Now().Format(time.RFC3339),
+			Footer: &discordgo.MessageEmbedFooter{
+				Text: options.T("governance"),
+			},
+		}
 
-	// Add summary with a separator
-	description += "\n**Summary:**\n" + notification.Summary
+		if i == 0 {
+			embed.Title = notifiers.TruncateText(title, MaxTitleLength)
+			embed.URL = notification.ProposalURL
+		}
+
+		if len(descriptions) > 1 {
+			embed.Footer.Text = options.T("governance") + " • " + options.T("part", i+1, len(descriptions))
+		}
 
-	embed.Description = description
+		embeds = append(embeds, embed)
+	}
 
-	return embed
+	return embeds, nil
 }
 
 // getColorForStatus returns a color integer based on proposal status
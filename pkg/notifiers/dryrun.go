@@ -0,0 +1,110 @@
+package notifiers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hazim1093/zeta-comms/pkg/models"
+	"github.com/rs/zerolog"
+)
+
+// RenderFunc renders a notification into the payload that would be sent to a platform
+type RenderFunc func(notification models.Notification, options RenderOptions) ([]byte, error)
+
+// unsafeFilenameChars matches characters that are replaced when building output file names
+var unsafeFilenameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// DryRunNotifier renders notifications like a platform notifier would, but writes the
+// result to the log or to an output directory instead of sending it
+type DryRunNotifier struct {
+	log       *zerolog.Logger
+	name      string
+	extension string
+	render    RenderFunc
+	outputDir string
+}
+
+// interface implementation check
+var _ Notifier = (*DryRunNotifier)(nil)
+
+// NewDryRunNotifier creates a dry-run notifier for a platform. If outputDir is empty the
+// rendered payloads are logged.
+func NewDryRunNotifier(logger *zerolog.Logger, name string, extension string, render RenderFunc, outputDir string) *DryRunNotifier {
+	log := logger.With().Str("service", name+"DryRun").Logger()
+
+	return &DryRunNotifier{
+		log:       &log,
+		name:      name,
+		extension: extension,
+		render:    render,
+		outputDir: outputDir,
+	}
+}
+
+// Send implements the notifier.Notifier interface
+func (d *DryRunNotifier) Send(destination string, notification models.Notification, options RenderOptions) error {
+	payload, err := d.render(notification, options)
+	if err != nil {
+		return fmt.Errorf("failed to render %s notification: %w", d.name, err)
+	}
+
+	if d.outputDir == "" {
+		d.log.Info().
+			Str("platform", d.name).
+			Str("proposal_id", notification.ProposalId).
+			Str("payload", string(payload)).
+			Msg("Dry run: notification not sent")
+
+		return nil
+	}
+
+	if err := os.MkdirAll(d.outputDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create dry-run output directory: %w", err)
+	}
+
+	filename := filepath.Join(d.outputDir, d.filename(destination, notification))
+	if err := os.WriteFile(filename, payload, 0o644); err != nil {
+		return fmt.Errorf("failed to write dry-run output: %w", err)
+	}
+
+	d.log.Info().
+		Str("platform", d.name).
+		Str("proposal_id", notification.ProposalId).
+		Str("file", filename).
+		Msg("Dry run: notification written to file")
+
+	return nil
+}
+
+// Name implements the notifier.Notifier interface
+func (d *DryRunNotifier) Name() string {
+	return d.name
+}
+
+// filename builds a unique, filesystem safe name for a rendered notification
+func (d *DryRunNotifier) filename(destination string, notification models.Notification) string {
+	subject := "broadcast"
+	if notification.ProposalId != "" {
+		subject = notification.Network + "-" + notification.ProposalId
+	}
+
+	// Destinations can be webhook URLs with a secret token, so they are only told apart by a short hash
+	hash := sha256.Sum256([]byte(destination))
+
+	parts := []string{
+		time.Now().UTC().Format("20060102T150405.000"),
+		subject,
+		d.name,
+		hex.EncodeToString(hash[:4]),
+	}
+
+	name := unsafeFilenameChars.ReplaceAllString(strings.Join(parts, "_"), "-")
+
+	return name + "." + d.extension
+}
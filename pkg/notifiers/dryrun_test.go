@@ -0,0 +1,42 @@
+package notifiers_test
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rs/zerolog"
+
+	"github.com/hazim1093/zeta-comms/pkg/models"
+	"github.com/hazim1093/zeta-comms/pkg/notifiers"
+)
+
+var _ = Describe("DryRunNotifier", func() {
+	It("should write each notification to its own file without the secrets of the destination", func() {
+		outputDir := GinkgoT().TempDir()
+		logger := zerolog.Nop()
+
+		render := func(notification models.Notification, _ notifiers.RenderOptions) ([]byte, error) {
+			return []byte(notification.Title), nil
+		}
+
+		notifier := notifiers.NewDryRunNotifier(&logger, "slack", "json", render, outputDir)
+		notification := models.Notification{Network: "mainnet", ProposalId: "42", Title: "Upgrade v30"}
+
+		webhooks := []string{
+			"https://hooks.slack.com/services/T000/B000/XXXXXXXXXXXXXXXXXXXXXXXX",
+			"https://hooks.slack.com/services/T000/B000/YYYYYYYYYYYYYYYYYYYYYYYY",
+		}
+		for _, webhook := range webhooks {
+			Expect(notifier.Send(webhook, notification, notifiers.RenderOptions{})).To(Succeed())
+		}
+
+		entries, err := os.ReadDir(outputDir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(2))
+
+		for _, entry := range entries {
+			Expect(entry.Name()).To(MatchRegexp(`^[0-9T.]+_mainnet-42_slack_[0-9a-f]{8}\.json$`))
+		}
+	})
+})
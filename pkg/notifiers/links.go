@@ -0,0 +1,25 @@
+package notifiers
+
+import "github.com/hazim1093/zeta-comms/pkg/models"
+
+// Link is a labelled URL shown as a button below a notification
+type Link struct {
+	Label string
+	URL   string
+}
+
+// Links returns the buttons to show below a notification: the proposal in an explorer and,
+// while the proposal is open for voting, the governance portal to vote in
+func Links(notification models.Notification, options RenderOptions) []Link {
+	var links []Link
+
+	if notification.ProposalURL != "" {
+		links = append(links, Link{Label: options.T("view_proposal"), URL: notification.ProposalURL})
+	}
+
+	if notification.VoteURL != "" && notification.Status == "PROPOSAL_STATUS_VOTING_PERIOD" {
+		links = append(links, Link{Label: options.T("vote"), URL: notification.VoteURL})
+	}
+
+	return links
+}
@@ -6,8 +6,8 @@ import (
 
 // Notifier defines the interface for sending notifications
 type Notifier interface {
-	// Send sends a notification to the specified destination
-	Send(destination string, notification models.Notification) error
+	// Send sends a notification to the specified destination, rendered with the audience's options
+	Send(destination string, notification models.Notification, options RenderOptions) error
 
 	// Name returns the name of the notifier (e.g., "slack", "discord")
 	Name() string
@@ -0,0 +1,200 @@
+package notifiers
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/hazim1093/zeta-comms/pkg/models"
+)
+
+// Overflow modes decide what happens to messages that exceed a platform's length limit
+const (
+	// OverflowTruncate shortens the summary and links to the full proposal
+	OverflowTruncate = "truncate"
+	// OverflowSplit sends the complete text as several ordered messages
+	OverflowSplit = "split"
+)
+
+// PartSeparator separates the parts of a split message in rendered previews
+const PartSeparator = "-----"
+
+// OverflowModes lists the supported overflow modes
+var OverflowModes = []string{OverflowTruncate, OverflowSplit}
+
+// Overflow configures how a platform handles messages that are too long
+type Overflow struct {
+	Mode      string // Empty means OverflowTruncate
+	MaxLength int    // Zero or values above the platform limit mean the platform limit
+}
+
+// Split reports whether overlong messages should be split instead of truncated
+func (o Overflow) Split() bool {
+	return o.Mode == OverflowSplit
+}
+
+// Limit returns the effective maximum length given the platform limit
+func (o Overflow) Limit(platformLimit int) int {
+	if o.MaxLength <= 0 || o.MaxLength > platformLimit {
+		return platformLimit
+	}
+
+	return o.MaxLength
+}
+
+// TextLength returns the length of a text as counted by the messaging platforms, in UTF-16 code units
+func TextLength(text string) int {
+	length := 0
+	for _, r := range text {
+		length += utf16.RuneLen(r)
+	}
+
+	return length
+}
+
+// TruncateText cuts a text to at most maxLength, ending it with an ellipsis if it was cut
+func TruncateText(text string, maxLength int) string {
+	if TextLength(text) <= maxLength {
+		return text
+	}
+
+	return string(prefixWithin([]rune(text), maxLength-1)) + "…"
+}
+
+// FitSummary renders a notification and shortens its summary until the rendered text fits
+// into maxLength. Notifications with a shortened summary are marked as Truncated, so that
+// templates can link to the full proposal. If even an empty summary does not fit, the
+// rendered text itself is cut.
+func FitSummary(notification models.Notification, maxLength int, render func(models.Notification) (string, error)) (string, error) {
+	text, err := render(notification)
+	if err != nil || TextLength(text) <= maxLength {
+		return text, err
+	}
+
+	summary := []rune(notification.Summary)
+	notification.Truncated = true
+
+	renderPrefix := func(keep int) (string, error) {
+		notification.Summary = strings.TrimRightFunc(string(summary[:keep]), unicode.IsSpace)
+
+		return render(notification)
+	}
+
+	// Binary search for the longest summary that fits, escaping and markup make the rendered
+	// length grow unevenly with the summary length
+	fits, tooLong := -1, len(summary)
+	for tooLong-fits > 1 {
+		keep := (fits + tooLong) / 2
+
+		text, err := renderPrefix(keep)
+		if err != nil {
+			return "", err
+		}
+
+		if TextLength(text) <= maxLength {
+			fits = keep
+		} else {
+			tooLong = keep
+		}
+	}
+
+	if fits < 0 {
+		text, err := renderPrefix(0)
+		if err != nil {
+			return "", err
+		}
+
+		return TruncateText(text, maxLength), nil
+	}
+
+	return renderPrefix(wordBoundary(summary, fits))
+}
+
+// SplitText splits a text into parts of at most maxLength. Parts end at paragraph breaks,
+// line breaks or spaces where possible. canSplit may veto split positions, e.g. inside markup.
+func SplitText(text string, maxLength int, canSplit func(text string, i int) bool) []string {
+	var parts []string
+
+	for TextLength(text) > maxLength {
+		limit := len(string(prefixWithin([]rune(text), maxLength)))
+		if limit == 0 {
+			// Always make progress, even if a single character exceeds the limit
+			_, limit = utf8.DecodeRuneInString(text)
+		}
+
+		cut := splitPoint(text, limit, canSplit)
+
+		part := strings.TrimRightFunc(text[:cut], unicode.IsSpace)
+		if part != "" {
+			parts = append(parts, part)
+		}
+
+		text = strings.TrimLeftFunc(text[cut:], unicode.IsSpace)
+	}
+
+	if text != "" {
+		parts = append(parts, text)
+	}
+
+	return parts
+}
+
+// splitPoint returns the byte offset within text[:limit] at which to split, preferring
+// paragraph breaks over line breaks over spaces over arbitrary characters
+func splitPoint(text string, limit int, canSplit func(text string, i int) bool) int {
+	allowed := func(i int) bool {
+		return i > 0 && (canSplit == nil || canSplit(text, i))
+	}
+
+	// Natural breaks are only used if they do not leave a part much shorter than necessary
+	minimum := limit / 2
+
+	for _, separator := range []string{"\n\n", "\n", " "} {
+		for i := strings.LastIndex(text[:limit], separator); i > minimum; i = strings.LastIndex(text[:i], separator) {
+			if allowed(i) {
+				return i
+			}
+		}
+	}
+
+	for i := limit; i > 0; i-- {
+		if isRuneStart(text, i) && allowed(i) {
+			return i
+		}
+	}
+
+	// Nothing may be split, give up on the markup rather than loop forever
+	return limit
+}
+
+// prefixWithin returns the longest prefix of runes whose text length is at most maxLength
+func prefixWithin(runes []rune, maxLength int) []rune {
+	length := 0
+
+	for i, r := range runes {
+		length += utf16.RuneLen(r)
+		if length > maxLength {
+			return runes[:i]
+		}
+	}
+
+	return runes
+}
+
+// wordBoundary moves a cut position back to the previous space if one is close by
+func wordBoundary(runes []rune, keep int) int {
+	const maxLookBack = 20
+
+	for i := keep; i > 0 && i > keep-maxLookBack; i-- {
+		if unicode.IsSpace(runes[i-1]) {
+			return i
+		}
+	}
+
+	return keep
+}
+
+func isRuneStart(text string, i int) bool {
+	return i >= len(text) || text[i]&0xC0 != 0x80
+}
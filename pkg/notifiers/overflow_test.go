@@ -0,0 +1,79 @@
+package notifiers_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/hazim1093/zeta-comms/pkg/models"
+	"github.com/hazim1093/zeta-comms/pkg/notifiers"
+)
+
+func TestNotifiers(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Notifiers Suite")
+}
+
+var _ = Describe("FitSummary", func() {
+	// render mimics a template that escapes ampersands and links to the proposal if the summary was cut
+	render := func(notification models.Notification) (string, error) {
+		text := "Title\n" + strings.ReplaceAll(notification.Summary, "&", "&amp;")
+		if notification.Truncated {
+			text += "\nRead more"
+		}
+
+		return text, nil
+	}
+
+	DescribeTable("should shorten the summary until the rendered text fits",
+		func(summary string, maxLength int, expected string) {
+			text, err := notifiers.FitSummary(models.Notification{Summary: summary}, maxLength, render)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(text).To(Equal(expected))
+			Expect(notifiers.TextLength(text)).To(BeNumerically("<=", maxLength))
+		},
+		Entry("keeps a summary that fits",
+			"Upgrade to v30", 30, "Title\nUpgrade to v30"),
+		Entry("cuts at a word boundary and links to the proposal",
+			"Upgrade the network to v30 at height 1000", 40, "Title\nUpgrade the network to\nRead more"),
+		Entry("accounts for escaping that makes the text longer",
+			"A & B & C & D & E & F", 35, "Title\nA &amp; B &amp; C\nRead more"),
+		Entry("counts characters outside the BMP twice",
+			"🚀🚀🚀🚀🚀🚀🚀🚀🚀🚀", 24, "Title\n🚀🚀🚀🚀\nRead more"),
+		Entry("cuts the rendered text if even an empty summary does not fit",
+			"Upgrade to v30", 10, "Title\n\nRe…"),
+	)
+})
+
+var _ = Describe("SplitText", func() {
+	DescribeTable("should split into parts within the limit at the most natural break",
+		func(text string, maxLength int, canSplit func(string, int) bool, expected []string) {
+			parts := notifiers.SplitText(text, maxLength, canSplit)
+			Expect(parts).To(Equal(expected))
+
+			for _, part := range parts {
+				Expect(notifiers.TextLength(part)).To(BeNumerically("<=", maxLength))
+			}
+		},
+		Entry("keeps a text that fits",
+			"Short text", 20, nil, []string{"Short text"}),
+		Entry("prefers paragraph breaks",
+			"First paragraph\n\nSecond one here", 20, nil, []string{"First paragraph", "Second one here"}),
+		Entry("prefers line breaks over spaces",
+			"First line\nsecond line here", 18, nil, []string{"First line", "second line here"}),
+		Entry("falls back to spaces",
+			"one two three four five six", 12, nil, []string{"one two", "three four", "five six"}),
+		Entry("ignores breaks that leave a part much shorter than necessary",
+			"a bcdefghijklmnopqrstuvwxyz", 10, nil, []string{"a bcdefghi", "jklmnopqrs", "tuvwxyz"}),
+		Entry("cuts words without a natural break",
+			"abcdefghijklmnopqrstuvwxyz", 10, nil, []string{"abcdefghij", "klmnopqrst", "uvwxyz"}),
+		Entry("does not split characters outside the BMP",
+			"🚀🚀🚀🚀🚀", 5, nil, []string{"🚀🚀", "🚀🚀", "🚀"}),
+		Entry("skips positions vetoed by canSplit",
+			"<b>bold text</b> plain", 18, func(text string, i int) bool {
+				return strings.Count(text[:i], "<b>") == strings.Count(text[:i], "</b>")
+			}, []string{"<b>bold text</b>", "plain"}),
+	)
+})
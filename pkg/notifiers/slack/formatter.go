@@ -1,109 +1,170 @@
 package slack
 
 import (
-	"fmt"
-	"time"
+	"embed"
+	"strings"
+	"text/template"
 
+	"github.com/hazim1093/zeta-comms/pkg/markdown"
 	"github.com/hazim1093/zeta-comms/pkg/models"
 	"github.com/hazim1093/zeta-comms/pkg/notifiers"
 )
 
-// FormatNotification creates a formatted Slack message for a notification
-func formatNotification(notification models.Notification) Message {
-	// Determine color based on status
-	color := getColorForStatus(notification.Status)
-
-	// Create header section
-	headerText := fmt.Sprintf("*[%s]* *Proposal* %s: %s", notification.Network, notification.ProposalId, notification.Title)
-	headerBlock := Block{
-		Type: "section",
-		Text: &Text{
-			Type: "mrkdwn",
-			Text: headerText,
-		},
-	}
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+var renderer = notifiers.NewTemplateRenderer("slack", templateFS, escapeFuncs)
 
-	// Build message content
-	messageContent := ""
+// escape escapes the control characters of Slack mrkdwn, so that chain-sourced text cannot
+// mention @channel or add links, e.g. <!channel> or <https://example.com|Click>
+var escape = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace
 
-	// Add software upgrade info if available
-	if notification.UpgradeName != "" {
-		messageContent += fmt.Sprintf("*Upgrade:* %s\n*Target Height:* %s\n",
-			notification.UpgradeName, notification.TargetHeight)
+// escapeFuncs adds the escape helper for the values of notifications
+func escapeFuncs(_ notifiers.RenderOptions) template.FuncMap {
+	return template.FuncMap{
+		"escape": escape,
 	}
+}
 
-	// Add deposit information if available
-	if len(notification.TotalDeposit) > 0 {
-		messageContent += "*Deposits:*\n"
-		for _, deposit := range notification.TotalDeposit {
-			messageContent += fmt.Sprintf("• %s %s\n", deposit.Amount, deposit.Denom)
-		}
+// ParseTemplate parses a custom Slack template, which renders the mrkdwn text of the message
+// body. It may define a "title" template for the header section. Values from the chain should
+// go through escape, e.g. {{ escape .Title }}.
+func ParseTemplate(name string, text string) (*template.Template, error) {
+	return renderer.Parse(name, text)
+}
 
-		messageContent += "\n"
-	}
+const (
+	// MaxSectionLength is the maximum length of the text of a Slack section block
+	MaxSectionLength = 3000
+	// MaxBlocks is the maximum number of blocks Slack accepts in a message
+	MaxBlocks = 50
+)
 
-	// Add voting results using the common formatter
-	// Format the voting results section
-
-	if notification.TotalVotes != "" {
-		messageContent += "*Voting Results:*\n"
-		messageContent += fmt.Sprintf("• Yes: %s\n", notification.YesVotes)
-		messageContent += fmt.Sprintf("• No: %s\n", notification.NoVotes)
-		messageContent += fmt.Sprintf("• Abstain: %s\n", notification.AbstainVotes)
-		messageContent += fmt.Sprintf("• Veto: %s\n", notification.VetoVotes)
-		messageContent += "\n"
-		messageContent += "*Total Votes:* " + notification.TotalVotes + "\n"
+// formatNotification creates the Slack messages for a notification. It returns several messages,
+// each with a part of the details, only if the details are too long for one section and the
+// overflow mode is split.
+func formatNotification(notification models.Notification, options notifiers.RenderOptions) ([]Message, error) {
+	headerText, err := renderer.RenderTitle(notification, options)
+	if err != nil {
+		return nil, err
 	}
 
-	messageContent += "\n"
+	render := func(notification models.Notification) (string, error) {
+		notification.Summary = markdown.ToSlack(notification.Summary)
 
-	// Add timeline information
-	if !notification.SubmitTime.IsZero() {
-		messageContent += fmt.Sprintf("*Submitted:* %s\n", notification.SubmitTime.Format(time.RFC1123))
+		return renderer.Render(notification, options)
 	}
 
-	if !notification.VotingEndTime.IsZero() {
-		messageContent += fmt.Sprintf("*Voting Ends:* %s\n", notification.VotingEndTime.Format(time.RFC1123))
-	}
+	maxLength := options.Overflow.Limit(MaxSectionLength)
 
-	// Add expedited flag if true
-	if notification.Expedited {
-		messageContent += "*Expedited:* Yes\n"
+	var details []string
+
+	if options.Overflow.Split() {
+		detailsText, err := render(notification)
+		if err != nil {
+			return nil, err
+		}
+
+		details = notifiers.SplitText(detailsText, maxLength, nil)
+		if len(details) == 0 {
+			details = []string{""}
+		}
+	} else {
+		detailsText, err := notifiers.FitSummary(notification, maxLength, render)
+		if err != nil {
+			return nil, err
+		}
+
+		details = []string{detailsText}
 	}
 
-	// Add failed reason if available
-	if notification.FailedReason != "" {
-		messageContent += fmt.Sprintf("*Failed Reason:* %s\n", notification.FailedReason)
+	// Fallback text, shown above the attachment and in notifications
+	var fallbackText string
+
+	switch notification.Event() {
+	case models.EventBroadcast:
+		fallbackText = options.T("new_governance_message")
+	case models.EventDigest:
+		fallbackText = options.T("digest_title")
+	case models.EventAlert:
+		fallbackText = options.T("ops_alert", notification.Network)
+	default:
+		fallbackText = options.T("new_proposal_notification", notification.Network)
 	}
 
-	// Add summary with a separator
-	messageContent += "\n*Summary:*\n" + notification.Summary
-
-	// Create details section
-	detailsText := fmt.Sprintf("*ID:* %s\n*Status:* %s\n\n%s",
-		notification.ProposalId, notifiers.FormatStatus(notification.Status), messageContent)
-	detailsBlock := Block{
-		Type: "section",
-		Text: &Text{
-			Type: "mrkdwn",
-			Text: detailsText,
-		},
+	messages := make([]Message, 0, len(details))
+
+	for i, detailsText := range details {
+		var blocks []Block
+
+		// Create header section
+		if i == 0 && headerText != "" {
+			blocks = append(blocks, Block{
+				Type: "section",
+				Text: &Text{
+					Type: "mrkdwn",
+					Text: notifiers.TruncateText(headerText, MaxSectionLength),
+				},
+			})
+		}
+
+		// Create details section
+		if detailsText != "" {
+			blocks = append(blocks, Block{
+				Type: "section",
+				Text: &Text{
+					Type: "mrkdwn",
+					Text: detailsText,
+				},
+			})
+		}
+
+		// Link buttons go below the last part
+		if i == len(details)-1 {
+			if actions, ok := linkButtons(notification, options); ok {
+				blocks = append(blocks, actions)
+			}
+		}
+
+		text := fallbackText
+		if len(details) > 1 {
+			text += " • " + options.T("part", i+1, len(details))
+		}
+
+		// Slack rejects messages with more than MaxBlocks blocks
+		blocks = blocks[:min(len(blocks), MaxBlocks)]
+
+		// Create message with fallback text and an attachment with color
+		messages = append(messages, Message{
+			Text: text,
+			Attachments: []Attachment{{
+				Color:  getColorForStatus(notification.Status),
+				Blocks: blocks,
+			}},
+		})
 	}
 
-	// Create blocks array
-	blocks := []Block{headerBlock, detailsBlock}
+	return messages, nil
+}
 
-	// Create attachment with color
-	attachment := Attachment{
-		Color:  color,
-		Blocks: blocks,
+// linkButtons returns an actions block with a button per link of the notification, if it has any
+func linkButtons(notification models.Notification, options notifiers.RenderOptions) (Block, bool) {
+	links := notifiers.Links(notification, options)
+	if len(links) == 0 {
+		return Block{}, false
 	}
 
-	// Create message with fallback text and attachment
-	return Message{
-		Text:        fmt.Sprintf("New proposal notification for %s", notification.Network),
-		Attachments: []Attachment{attachment},
+	actions := Block{Type: "actions"}
+
+	for _, link := range links {
+		actions.Elements = append(actions.Elements, Element{
+			Type: "button",
+			Text: &Text{Type: "plain_text", Text: link.Label},
+			URL:  link.URL,
+		})
 	}
+
+	return actions, true
 }
 
 // getColorForStatus returns a color hex code based on proposal status
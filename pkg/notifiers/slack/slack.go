@@ -22,8 +22,16 @@ type Message struct {
 
 // Block represents a Slack block element
 type Block struct {
+	Type     string    `json:"type"`
+	Text     *Text     `json:"text,omitempty"`
+	Elements []Element `json:"elements,omitempty"` // For actions blocks
+}
+
+// Element represents an interactive element of an actions block, e.g. a link button
+type Element struct {
 	Type string `json:"type"`
 	Text *Text  `json:"text,omitempty"`
+	URL  string `json:"url,omitempty"`
 }
 
 // Text represents text content within a Slack block
@@ -54,12 +62,36 @@ func NewSlackClient(logger *zerolog.Logger) *SlackClient {
 }
 
 // Send implements the notifier.Notifier interface
-func (c *SlackClient) Send(destination string, notification models.Notification) error {
+func (c *SlackClient) Send(destination string, notification models.Notification, options notifiers.RenderOptions) error {
 	c.log.Debug().Msg("Sending Slack notification to webhook")
 
-	message := formatNotification(notification)
+	messages, err := formatNotification(notification, options)
+	if err != nil {
+		return err
+	}
+
+	for i, message := range messages {
+		if err := c.SendWebhookMessage(destination, message); err != nil {
+			return fmt.Errorf("failed to send message %d of %d: %w", i+1, len(messages), err)
+		}
+	}
+
+	return nil
+}
+
+// Render returns the webhook payload that would be sent for a notification, or a list of
+// payloads if the notification is split into several messages
+func Render(notification models.Notification, options notifiers.RenderOptions) ([]byte, error) {
+	messages, err := formatNotification(notification, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(messages) == 1 {
+		return json.MarshalIndent(messages[0], "", "  ")
+	}
 
-	return c.SendWebhookMessage(destination, message)
+	return json.MarshalIndent(messages, "", "  ")
 }
 
 // Name implements the notifier.Notifier interface
@@ -0,0 +1,104 @@
+package slack_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/hazim1093/zeta-comms/pkg/models"
+	"github.com/hazim1093/zeta-comms/pkg/notifiers"
+	"github.com/hazim1093/zeta-comms/pkg/notifiers/slack"
+)
+
+func TestSlack(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Slack Suite")
+}
+
+var _ = Describe("Render", func() {
+	var notification models.Notification
+
+	BeforeEach(func() {
+		notification = models.Notification{
+			EventType:   models.EventProposal,
+			Network:     "mainnet",
+			ProposalId:  "42",
+			Title:       "Upgrade v30",
+			Status:      "PROPOSAL_STATUS_VOTING_PERIOD",
+			Summary:     strings.Repeat("The upgrade migrates the observer state. ", 30),
+			ProposalURL: "https://explorer.example.com/proposals/42",
+		}
+	})
+
+	It("should truncate the summary and link to the proposal", func() {
+		payload, err := slack.Render(notification, notifiers.RenderOptions{
+			Overflow: notifiers.Overflow{MaxLength: 500},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		var message slack.Message
+		Expect(json.Unmarshal(payload, &message)).To(Succeed())
+
+		blocks := message.Attachments[0].Blocks
+		Expect(blocks).To(HaveLen(3))
+		Expect(notifiers.TextLength(blocks[1].Text.Text)).To(BeNumerically("<=", 500))
+		Expect(blocks[1].Text.Text).To(HaveSuffix("… <https://explorer.example.com/proposals/42|Read more>"))
+		Expect(blocks[2].Type).To(Equal("actions"))
+	})
+
+	It("should send each part as its own message with the buttons below the last", func() {
+		payload, err := slack.Render(notification, notifiers.RenderOptions{
+			Overflow: notifiers.Overflow{Mode: notifiers.OverflowSplit, MaxLength: 500},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		var messages []slack.Message
+		Expect(json.Unmarshal(payload, &messages)).To(Succeed())
+		Expect(len(messages)).To(BeNumerically(">", 2))
+
+		var details string
+
+		for i, message := range messages {
+			Expect(message.Text).To(HaveSuffix(fmt.Sprintf("Part %d/%d", i+1, len(messages))))
+			Expect(message.Attachments).To(HaveLen(1))
+
+			blocks := message.Attachments[0].Blocks
+			Expect(len(blocks)).To(BeNumerically("<=", slack.MaxBlocks))
+
+			for _, block := range blocks {
+				if block.Type == "section" {
+					Expect(notifiers.TextLength(block.Text.Text)).To(BeNumerically("<=", 500))
+
+					details += block.Text.Text + " "
+				}
+			}
+
+			Expect(blocks[len(blocks)-1].Type == "actions").To(Equal(i == len(messages)-1))
+		}
+
+		Expect(strings.Count(details, "The upgrade migrates the observer state.")).To(Equal(30))
+	})
+
+	It("should escape mentions and links in values from the chain", func() {
+		notification.Title = "<!channel> Upgrade & migrate"
+		notification.Summary = "Details"
+		notification.Messages = []models.ProposalMessage{{
+			Type:   "/cosmos.distribution.v1beta1.MsgCommunityPoolSpend",
+			Fields: []models.MessageField{{Name: "Recipient", Value: "<https://evil.example.com|Click>"}},
+		}}
+
+		payload, err := slack.Render(notification, notifiers.RenderOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		var message slack.Message
+		Expect(json.Unmarshal(payload, &message)).To(Succeed())
+
+		blocks := message.Attachments[0].Blocks
+		Expect(blocks[0].Text.Text).To(HaveSuffix("42: &lt;!channel&gt; Upgrade &amp; migrate"))
+		Expect(blocks[1].Text.Text).To(ContainSubstring("◦ Recipient: `&lt;https://evil.example.com|Click&gt;`"))
+	})
+})
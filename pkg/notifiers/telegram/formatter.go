@@ -1,79 +1,53 @@
 package telegram
 
 import (
-	"fmt"
-	"time"
+	"embed"
+	"text/template"
 
 	"github.com/hazim1093/zeta-comms/pkg/models"
 	"github.com/hazim1093/zeta-comms/pkg/notifiers"
 )
 
-// formatNotification creates a formatted Telegram message for a notification
-func formatNotification(notification models.Notification) string {
-	formattedMessage := ""
+//go:embed templates/*.tmpl
+var templateFS embed.FS
 
-	if notification.Title != "" {
+var renderer = notifiers.NewTemplateRenderer("telegram", templateFS, nil)
 
-		formattedMessage = fmt.Sprintf("*[%s]* *Proposal* %s: %s\n\n", notification.Network, notification.ProposalId, notification.Title)
-	}
+// ParseTemplate parses a custom Telegram template, which renders the complete message text in
+// Telegram's HTML parse mode. Notification fields are escaped before they reach the template.
+func ParseTemplate(name string, text string) (*template.Template, error) {
+	return renderer.Parse(name, text)
+}
 
-	if notification.ProposalId != "" {
-		formattedMessage += fmt.Sprintf("*ID:* %s\n", notification.ProposalId)
-	}
+// MaxMessageLength is the maximum length of a Telegram message
+const MaxMessageLength = 4096
 
-	if notification.Status != "" {
-		formattedMessage += fmt.Sprintf("*Status:* %s\n\n", notifiers.FormatStatus(notification.Status))
+// formatNotification creates the formatted Telegram messages for a notification. It returns
+// several messages only if the notification is too long and the overflow mode is split.
+func formatNotification(notification models.Notification, options notifiers.RenderOptions) ([]string, error) {
+	render := func(notification models.Notification) (string, error) {
+		return renderer.Render(escapeNotification(notification), options)
 	}
 
-	// Add software upgrade info if available
-	if notification.UpgradeName != "" {
-		formattedMessage += fmt.Sprintf("*Upgrade:* %s\n*Target Height:* %s\n\n", notification.UpgradeName, notification.TargetHeight)
-	}
+	maxLength := options.Overflow.Limit(MaxMessageLength)
 
-	// Add deposit information if available
-	if len(notification.TotalDeposit) > 0 {
-		formattedMessage += "*Deposits:*\n"
-		for _, deposit := range notification.TotalDeposit {
-			formattedMessage += fmt.Sprintf("• %s %s\n", deposit.Amount, deposit.Denom)
+	if options.Overflow.Split() {
+		message, err := render(notification)
+		if err != nil {
+			return nil, err
 		}
 
-		formattedMessage += "\n"
-	}
-
-	if notification.TotalVotes != "" {
-		formattedMessage += "*Voting Results:*\n"
-		formattedMessage += fmt.Sprintf("• Yes: %s\n", notification.YesVotes)
-		formattedMessage += fmt.Sprintf("• No: %s\n", notification.NoVotes)
-		formattedMessage += fmt.Sprintf("• Abstain: %s\n", notification.AbstainVotes)
-		formattedMessage += fmt.Sprintf("• Veto: %s\n", notification.VetoVotes)
-		formattedMessage += "\n"
-		formattedMessage += "*Total Votes:* " + notification.TotalVotes + "\n"
-	}
-
-	formattedMessage += "\n"
-
-	// Add timeline information
-	if !notification.SubmitTime.IsZero() {
-		formattedMessage += fmt.Sprintf("*Submitted:* %s\n", notification.SubmitTime.Format(time.RFC1123))
-	}
-
-	if !notification.VotingEndTime.IsZero() {
-		formattedMessage += fmt.Sprintf("*Voting Ends:* %s\n", notification.VotingEndTime.Format(time.RFC1123))
-	}
+		if notifiers.TextLength(message) <= maxLength {
+			return []string{message}, nil
+		}
 
-	// Add expedited flag if true
-	if notification.Expedited {
-		formattedMessage += "*Expedited:* Yes\n"
+		return splitHTML(message, maxLength), nil
 	}
 
-	// Add failed reason if available
-	if notification.FailedReason != "" {
-		formattedMessage += fmt.Sprintf("*Failed Reason:* %s\n", notification.FailedReason)
+	message, err := notifiers.FitSummary(notification, maxLength, render)
+	if err != nil {
+		return nil, err
 	}
 
-	// Add summary with a separator
-	formattedMessage += "\n*Summary:*\n" + notification.Summary
-	formattedMessage += fmt.Sprintf("\n\n_Updated at: %s_", time.Now().Format(time.RFC1123))
-
-	return formattedMessage
+	return []string{message}, nil
 }
@@ -0,0 +1,209 @@
+package telegram
+
+import (
+	"html"
+	"regexp"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/hazim1093/zeta-comms/pkg/markdown"
+	"github.com/hazim1093/zeta-comms/pkg/models"
+	"github.com/hazim1093/zeta-comms/pkg/notifiers"
+	"github.com/hazim1093/zeta-comms/pkg/zetachain"
+)
+
+// parseMode is the parse mode of rendered messages
+const parseMode = tgbotapi.ModeHTML
+
+// htmlTagPattern matches the markup tags of a rendered message
+var htmlTagPattern = regexp.MustCompile(`<(/?)([a-zA-Z][a-zA-Z0-9-]*)[^<>]*>`)
+
+// escapeNotification returns a copy of the notification with every user controlled field escaped
+// for Telegram's HTML parse mode, so that templates only have to take care of their own markup.
+// The summary is Markdown and converted to HTML instead.
+func escapeNotification(notification models.Notification) models.Notification {
+	escaped := notification
+
+	escaped.Network = html.EscapeString(notification.Network)
+	escaped.ProposalId = html.EscapeString(notification.ProposalId)
+	escaped.Title = html.EscapeString(notification.Title)
+	escaped.Summary = markdown.ToTelegramHTML(notification.Summary)
+	escaped.ProposalURL = html.EscapeString(notification.ProposalURL)
+	escaped.VoteURL = html.EscapeString(notification.VoteURL)
+	escaped.UpgradeName = html.EscapeString(notification.UpgradeName)
+	escaped.TargetHeight = html.EscapeString(notification.TargetHeight)
+	escaped.BinaryURLs = escapeMap(notification.BinaryURLs)
+	escaped.Checksums = escapeMap(notification.Checksums)
+	escaped.YesVotes = html.EscapeString(notification.YesVotes)
+	escaped.NoVotes = html.EscapeString(notification.NoVotes)
+	escaped.AbstainVotes = html.EscapeString(notification.AbstainVotes)
+	escaped.VetoVotes = html.EscapeString(notification.VetoVotes)
+	escaped.TotalVotes = html.EscapeString(notification.TotalVotes)
+	escaped.FailedReason = html.EscapeString(notification.FailedReason)
+
+	if notification.TotalDeposit != nil {
+		escaped.TotalDeposit = make([]zetachain.Deposit, len(notification.TotalDeposit))
+		for i, deposit := range notification.TotalDeposit {
+			escaped.TotalDeposit[i] = zetachain.Deposit{
+				Denom:  html.EscapeString(deposit.Denom),
+				Amount: html.EscapeString(deposit.Amount),
+			}
+		}
+	}
+
+	if notification.Messages != nil {
+		escaped.Messages = make([]models.ProposalMessage, len(notification.Messages))
+		for i, message := range notification.Messages {
+			escaped.Messages[i] = models.ProposalMessage{
+				Type:              html.EscapeString(message.Type),
+				Fields:            make([]models.MessageField, len(message.Fields)),
+				ParamsCompared:    message.ParamsCompared,
+				Changes:           make([]models.ParamChange, len(message.Changes)),
+				ParamsUnavailable: message.ParamsUnavailable,
+			}
+
+			for j, field := range message.Fields {
+				escaped.Messages[i].Fields[j] = models.MessageField{
+					Name:  html.EscapeString(field.Name),
+					Value: html.EscapeString(field.Value),
+				}
+			}
+
+			for j, change := range message.Changes {
+				escaped.Messages[i].Changes[j] = models.ParamChange{
+					Name: html.EscapeString(change.Name),
+					Old:  html.EscapeString(change.Old),
+					New:  html.EscapeString(change.New),
+				}
+			}
+		}
+	}
+
+	if notification.Digest != nil {
+		escaped.Digest = escapeDigest(*notification.Digest)
+	}
+
+	return escaped
+}
+
+// escapeDigest returns a copy of a digest with the user controlled fields of its items escaped
+func escapeDigest(digest models.Digest) *models.Digest {
+	escapeItems := func(items []models.DigestItem) []models.DigestItem {
+		if items == nil {
+			return nil
+		}
+
+		escaped := make([]models.DigestItem, len(items))
+		for i, item := range items {
+			item.Network = html.EscapeString(item.Network)
+			item.ProposalId = html.EscapeString(item.ProposalId)
+			item.Title = html.EscapeString(item.Title)
+			item.UpgradeName = html.EscapeString(item.UpgradeName)
+			item.TargetHeight = html.EscapeString(item.TargetHeight)
+			item.ProposalURL = html.EscapeString(item.ProposalURL)
+			escaped[i] = item
+		}
+
+		return escaped
+	}
+
+	digest.NewProposals = escapeItems(digest.NewProposals)
+	digest.StatusChanges = escapeItems(digest.StatusChanges)
+	digest.Deadlines = escapeItems(digest.Deadlines)
+	digest.Upgrades = escapeItems(digest.Upgrades)
+
+	return &digest
+}
+
+func escapeMap(values map[string]string) map[string]string {
+	if values == nil {
+		return nil
+	}
+
+	escaped := make(map[string]string, len(values))
+	for key, value := range values {
+		escaped[html.EscapeString(key)] = html.EscapeString(value)
+	}
+
+	return escaped
+}
+
+// splitHTML splits a rendered message into parts of at most maxLength without cutting tags or
+// entities. Tags that are open at the end of a part are closed and opened again in the next one.
+func splitHTML(text string, maxLength int) []string {
+	// Leave room for the tags that have to be closed and reopened at the part boundaries
+	const tagReserve = 128
+
+	parts := notifiers.SplitText(text, max(maxLength-tagReserve, maxLength/2), outsideMarkup)
+
+	var open []string
+
+	for i, part := range parts {
+		prefix := strings.Join(open, "")
+		open = openTags(prefix + part)
+
+		parts[i] = prefix + part + closingTags(open)
+	}
+
+	return parts
+}
+
+// outsideMarkup reports whether position i of text is neither inside a tag nor inside an entity
+func outsideMarkup(text string, i int) bool {
+	before := text[:i]
+
+	if strings.LastIndex(before, "<") > strings.LastIndex(before, ">") {
+		return false
+	}
+
+	amp := strings.LastIndex(before, "&")
+
+	return amp < 0 || strings.ContainsAny(before[amp:], "; \n")
+}
+
+// openTags returns the opening tags that are not closed by the end of text, outermost first
+func openTags(text string) []string {
+	var open []string
+
+	for _, match := range htmlTagPattern.FindAllStringSubmatch(text, -1) {
+		if match[1] == "" {
+			open = append(open, match[0])
+
+			continue
+		}
+
+		for j := len(open) - 1; j >= 0; j-- {
+			if tagName(open[j]) == match[2] {
+				open = append(open[:j], open[j+1:]...)
+
+				break
+			}
+		}
+	}
+
+	return open
+}
+
+func closingTags(open []string) string {
+	var closing strings.Builder
+
+	for i := len(open) - 1; i >= 0; i-- {
+		closing.WriteString("</" + tagName(open[i]) + ">")
+	}
+
+	return closing.String()
+}
+
+func tagName(tag string) string {
+	return htmlTagPattern.FindStringSubmatch(tag)[2]
+}
+
+// stripHTML turns a rendered message into plain text by removing its tags and unescaping entities
+func stripHTML(text string) string {
+	return html.UnescapeString(htmlTagPattern.ReplaceAllString(text, ""))
+}
+
+// isParseError reports whether Telegram rejected a message because of its markup
+func isParseError(err error) bool {
+	return strings.Contains(err.Error(), "can't parse entities")
+}
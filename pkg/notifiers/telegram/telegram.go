@@ -42,16 +42,77 @@ func InitializeTelegramClient(logger *zerolog.Logger, botToken string) (*Telegra
 }
 
 // Send implements the notifier.Notifier interface
-func (c *TelegramClient) Send(destination string, notification models.Notification) error {
+func (c *TelegramClient) Send(destination string, notification models.Notification, options notifiers.RenderOptions) error {
 	if c.bot == nil {
 		return fmt.Errorf("telegram client not initialized")
 	}
 
 	c.log.Debug().Msg("Sending Telegram notification to chat: " + destination)
 
-	message := formatNotification(notification)
+	messages, err := formatNotification(notification, options)
+	if err != nil {
+		return err
+	}
+
+	for i, message := range messages {
+		// Link buttons go below the last part
+		var markup interface{}
+		if i == len(messages)-1 {
+			markup = inlineKeyboard(notification, options)
+		}
+
+		if err := c.sendHTML(destination, message, markup); err != nil {
+			return fmt.Errorf("failed to send message %d of %d: %w", i+1, len(messages), err)
+		}
+	}
+
+	return nil
+}
+
+// sendHTML sends an HTML message, falling back to plain text if Telegram rejects the markup
+func (c *TelegramClient) sendHTML(destination string, message string, replyMarkup interface{}) error {
+	err := c.sendMessage(destination, message, parseMode, replyMarkup)
+	if err != nil && isParseError(err) {
+		// Sending the text without markup beats losing the notification
+		c.log.Warn().Err(err).Str("chat_id", destination).Msg("Telegram rejected the message markup, sending it as plain text")
+
+		return c.sendMessage(destination, stripHTML(message), "", replyMarkup)
+	}
+
+	return err
+}
+
+// inlineKeyboard returns a keyboard with a button per link of the notification, or nil if it has none
+func inlineKeyboard(notification models.Notification, options notifiers.RenderOptions) interface{} {
+	links := notifiers.Links(notification, options)
+	if len(links) == 0 {
+		return nil
+	}
+
+	buttons := make([]tgbotapi.InlineKeyboardButton, 0, len(links))
+	for _, link := range links {
+		buttons = append(buttons, tgbotapi.NewInlineKeyboardButtonURL(link.Label, link.URL))
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(buttons)
+}
+
+// Render returns the message text that would be sent for a notification. Split messages are
+// separated by a marker line and link buttons are listed at the end.
+func Render(notification models.Notification, options notifiers.RenderOptions) ([]byte, error) {
+	messages, err := formatNotification(notification, options)
+	if err != nil {
+		return nil, err
+	}
+
+	text := strings.Join(messages, "\n\n"+notifiers.PartSeparator+"\n\n")
+
+	// Show the inline keyboard as text, it is not part of the message itself
+	for _, link := range notifiers.Links(notification, options) {
+		text += fmt.Sprintf("\n[%s: %s]", link.Label, link.URL)
+	}
 
-	return c.SendMessage(destination, message, "Markdown")
+	return []byte(text), nil
 }
 
 // Name implements the notifier.Notifier interface
@@ -71,6 +132,11 @@ func (c *TelegramClient) Connect() error {
 
 // SendMessage sends a message to a Telegram chat
 func (c *TelegramClient) SendMessage(chatID string, text string, parseMode string) error {
+	return c.sendMessage(chatID, text, parseMode, nil)
+}
+
+// sendMessage sends a message to a Telegram chat with optional reply markup such as an inline keyboard
+func (c *TelegramClient) sendMessage(chatID string, text string, parseMode string, replyMarkup interface{}) error {
 	// Convert chat ID from string to int64
 	chatIDInt, err := strconv.ParseInt(chatID, 10, 64)
 	if err != nil {
@@ -85,6 +151,10 @@ func (c *TelegramClient) SendMessage(chatID string, text string, parseMode strin
 		msg.ParseMode = parseMode
 	}
 
+	if replyMarkup != nil {
+		msg.ReplyMarkup = replyMarkup
+	}
+
 	// Send the message
 	_, err = c.bot.Send(msg)
 	if err != nil {
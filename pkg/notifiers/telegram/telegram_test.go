@@ -0,0 +1,288 @@
+package telegram_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/hazim1093/zeta-comms/pkg/models"
+	"github.com/hazim1093/zeta-comms/pkg/notifiers"
+	"github.com/hazim1093/zeta-comms/pkg/notifiers/telegram"
+)
+
+func TestTelegram(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Telegram Suite")
+}
+
+var _ = Describe("Render", func() {
+	It("should escape user controlled fields and convert the Markdown summary to HTML", func() {
+		payload, err := telegram.Render(models.Notification{
+			EventType:  models.EventProposal,
+			Network:    "mainnet",
+			ProposalId: "42",
+			Title:      "Raise max_gas <for> R&D",
+			Summary:    "Set *block_max_gas* to 10_000_000 & see <a href=\"x\">docs</a>",
+			Status:     "PROPOSAL_STATUS_VOTING_PERIOD",
+		}, notifiers.RenderOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		message := string(payload)
+		Expect(message).To(ContainSubstring("<b>[mainnet]</b> <b>Proposal</b> 42: Raise max_gas &lt;for&gt; R&amp;D"))
+		Expect(message).To(ContainSubstring("Set <i>block_max_gas</i> to 10_000_000 &amp; see &lt;a href=&#34;x&#34;&gt;docs&lt;/a&gt;"))
+		Expect(message).NotTo(ContainSubstring("<a "))
+	})
+
+	It("should list proposal messages with escaped fields", func() {
+		payload, err := telegram.Render(models.Notification{
+			EventType:  models.EventProposal,
+			Network:    "mainnet",
+			ProposalId: "42",
+			Title:      "Update observer params",
+			Status:     "PROPOSAL_STATUS_VOTING_PERIOD",
+			Messages: []models.ProposalMessage{{
+				Type:   "/zetachain.zetacore.observer.MsgUpdateChainParams",
+				Fields: []models.MessageField{{Name: "Chain params", Value: `{"gas_price_ticker":"<10>"}`}},
+			}},
+		}, notifiers.RenderOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(payload)).To(ContainSubstring("<b>Messages:</b>\n• /zetachain.zetacore.observer.MsgUpdateChainParams\n" +
+			"    ◦ Chain params: <code>{&#34;gas_price_ticker&#34;:&#34;&lt;10&gt;&#34;}</code>\n"))
+	})
+
+	It("should show the param changes of compared messages", func() {
+		payload, err := telegram.Render(models.Notification{
+			EventType:  models.EventProposal,
+			Network:    "mainnet",
+			ProposalId: "43",
+			Title:      "Update staking params",
+			Status:     "PROPOSAL_STATUS_VOTING_PERIOD",
+			Messages: []models.ProposalMessage{
+				{
+					Type:           "/cosmos.staking.v1beta1.MsgUpdateParams",
+					Fields:         []models.MessageField{{Name: "max_validators", Value: "150"}},
+					ParamsCompared: true,
+					Changes: []models.ParamChange{
+						{Name: "max_validators", Old: "100", New: "150"},
+						{Name: "min_commission_rate", New: "0.05"},
+					},
+				},
+				{Type: "/zetachain.zetacore.crosschain.MsgUpdateParams", ParamsCompared: true},
+				{Type: "/zetachain.zetacore.fungible.MsgUpdateParams", ParamsUnavailable: true},
+			},
+		}, notifiers.RenderOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		message := string(payload)
+		Expect(message).To(ContainSubstring("• /cosmos.staking.v1beta1.MsgUpdateParams\n" +
+			"    ◦ max_validators: <code>100</code> → <code>150</code>\n" +
+			"    ◦ min_commission_rate: not set → <code>0.05</code>\n" +
+			"• /zetachain.zetacore.crosschain.MsgUpdateParams\n" +
+			"    ◦ No changes to the current params\n" +
+			"• /zetachain.zetacore.fungible.MsgUpdateParams\n" +
+			"    ◦ Diff unavailable, the current params could not be fetched\n"))
+	})
+
+	It("should escape broadcast messages", func() {
+		payload, err := telegram.Render(models.Notification{
+			EventType: models.EventBroadcast,
+			Summary:   "Upgrade at block <5000000>",
+		}, notifiers.RenderOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(payload)).To(Equal("<b>Message from ZetaChain Governance</b>\n\nUpgrade at block &lt;5000000&gt;"))
+	})
+
+	It("should list the link buttons, offering to vote only during the voting period", func() {
+		notification := models.Notification{
+			EventType:   models.EventProposal,
+			Network:     "mainnet",
+			ProposalId:  "42",
+			Title:       "Upgrade v30",
+			Status:      "PROPOSAL_STATUS_VOTING_PERIOD",
+			ProposalURL: "https://explorer.zetachain.com/proposals/42",
+			VoteURL:     "https://hub.zetachain.com/governance/42",
+		}
+
+		payload, err := telegram.Render(notification, notifiers.RenderOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(payload)).To(HaveSuffix("\n[View proposal: https://explorer.zetachain.com/proposals/42]\n[Vote: https://hub.zetachain.com/governance/42]"))
+
+		notification.Status = "PROPOSAL_STATUS_PASSED"
+
+		payload, err = telegram.Render(notification, notifiers.RenderOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(payload)).To(HaveSuffix("</i>\n[View proposal: https://explorer.zetachain.com/proposals/42]"))
+	})
+
+	It("should show timestamps in the audience's timezone and format, relative to now", func() {
+		tokyo, err := time.LoadLocation("Asia/Tokyo")
+		Expect(err).NotTo(HaveOccurred())
+
+		payload, err := telegram.Render(models.Notification{
+			EventType:     models.EventProposal,
+			Network:       "mainnet",
+			ProposalId:    "42",
+			Title:         "Upgrade v30",
+			SubmitTime:    time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC),
+			VotingEndTime: time.Date(2025, 3, 6, 14, 30, 0, 0, time.UTC),
+		}, notifiers.RenderOptions{
+			Time: notifiers.TimeOptions{
+				Location: tokyo,
+				Layout:   "2006-01-02 15:04 MST",
+				Now: func() time.Time {
+					return time.Date(2025, 3, 4, 10, 0, 0, 0, time.UTC)
+				},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		message := string(payload)
+		Expect(message).To(ContainSubstring("<b>Submitted:</b> 2025-03-01 18:00 JST\n"))
+		Expect(message).To(ContainSubstring("<b>Voting Ends:</b> 2025-03-06 23:30 JST (in 2d 4h)\n"))
+		Expect(message).To(HaveSuffix("<i>Updated at: 2025-03-04 19:00 JST</i>"))
+	})
+
+	It("should render labels, statuses and link buttons in the audience's language", func() {
+		payload, err := telegram.Render(models.Notification{
+			EventType:     models.EventProposal,
+			Network:       "mainnet",
+			ProposalId:    "42",
+			Title:         "Upgrade v30",
+			Status:        "PROPOSAL_STATUS_VOTING_PERIOD",
+			TotalVotes:    "1.000M",
+			VotingEndTime: time.Date(2025, 3, 6, 14, 30, 0, 0, time.UTC),
+			VoteURL:       "https://hub.zetachain.com/governance/42",
+		}, notifiers.RenderOptions{
+			Language: "ko",
+			Time: notifiers.TimeOptions{
+				Layout: "2006-01-02 15:04",
+				Now: func() time.Time {
+					return time.Date(2025, 3, 6, 12, 0, 0, 0, time.UTC)
+				},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		message := string(payload)
+		Expect(message).To(HavePrefix("<b>[mainnet]</b> <b>제안</b> 42: Upgrade v30"))
+		Expect(message).To(ContainSubstring("<b>상태:</b> 🗳️ 투표 기간\n"))
+		Expect(message).To(ContainSubstring("<b>총 투표수:</b> 1.000M\n"))
+		Expect(message).To(ContainSubstring("<b>투표 마감:</b> 2025-03-06 14:30 (2h 30m 후)\n"))
+		Expect(message).To(HaveSuffix("\n[투표하기: https://hub.zetachain.com/governance/42]"))
+	})
+
+	It("should render operational alerts", func() {
+		payload, err := telegram.Render(models.Notification{
+			EventType: models.EventAlert,
+			Network:   "mainnet",
+			Title:     "⚠️ All LCD endpoints are unavailable",
+			Summary:   "Proposals are not polled.\n\n```\nhttps://lcd.example.com: status <503>\n```",
+		}, notifiers.RenderOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(payload)).To(Equal("<b>[mainnet] ⚠️ All LCD endpoints are unavailable</b>\n\nProposals are not polled.\n\n<pre>https://lcd.example.com: status &lt;503&gt;</pre>"))
+	})
+
+	It("should render digests with escaped items", func() {
+		votingEnd := time.Date(2025, 3, 6, 14, 30, 0, 0, time.UTC)
+		upgrade := models.DigestItem{
+			Network:       "mainnet",
+			ProposalId:    "42",
+			Title:         "Upgrade <v30>",
+			Status:        "PROPOSAL_STATUS_VOTING_PERIOD",
+			UpgradeName:   "v30",
+			TargetHeight:  "5000000",
+			VotingEndTime: votingEnd,
+			ProposalURL:   "https://explorer.zetachain.com/proposals/42",
+		}
+
+		payload, err := telegram.Render(models.Notification{
+			EventType: models.EventDigest,
+			Digest: &models.Digest{
+				NewProposals: []models.DigestItem{upgrade},
+				StatusChanges: []models.DigestItem{{
+					Network:        "testnet",
+					ProposalId:     "7",
+					Title:          "Raise gas limit",
+					PreviousStatus: "PROPOSAL_STATUS_VOTING_PERIOD",
+					Status:         "PROPOSAL_STATUS_PASSED",
+				}},
+				Deadlines: []models.DigestItem{upgrade},
+				Upgrades:  []models.DigestItem{upgrade},
+			},
+		}, notifiers.RenderOptions{
+			Time: notifiers.TimeOptions{
+				Now: func() time.Time {
+					return votingEnd.Add(-5 * time.Hour)
+				},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(payload)).To(Equal(`<b>Governance Digest</b>
+
+<b>New Proposals</b>
+• <b>[mainnet]</b> <a href="https://explorer.zetachain.com/proposals/42">#42</a>: Upgrade &lt;v30&gt; (🗳️ Voting Period)
+
+<b>Status Changes</b>
+• <b>[testnet]</b> #7: Raise gas limit: 🗳️ Voting Period → ✅ Passed
+
+<b>Voting Ends Soon</b>
+• <b>[mainnet]</b> <a href="https://explorer.zetachain.com/proposals/42">#42</a>: Upgrade &lt;v30&gt;: Thu, 06 Mar 2025 14:30:00 UTC (in 5h)
+
+<b>Upgrades</b>
+• <b>[mainnet]</b> v30 at height 5000000 (🗳️ Voting Period)
+`))
+	})
+
+	Context("with a summary over the length limit", func() {
+		var notification models.Notification
+
+		BeforeEach(func() {
+			notification = models.Notification{
+				EventType:   models.EventProposal,
+				Network:     "mainnet",
+				ProposalId:  "42",
+				Title:       "Upgrade v30",
+				Summary:     strings.Repeat("Release notes <v30> & more. ", 400),
+				Status:      "PROPOSAL_STATUS_VOTING_PERIOD",
+				ProposalURL: "https://explorer.zetachain.com/proposals/42",
+			}
+		})
+
+		It("should truncate the summary and link to the proposal", func() {
+			payload, err := telegram.Render(notification, notifiers.RenderOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			message := string(payload)
+			Expect(notifiers.TextLength(strings.Split(message, "\n[View proposal")[0])).To(BeNumerically("<=", telegram.MaxMessageLength))
+			Expect(message).To(ContainSubstring(`… <a href="https://explorer.zetachain.com/proposals/42">Read more</a>`))
+			Expect(message).To(ContainSubstring("<b>Summary:</b>\nRelease notes &lt;v30&gt; &amp; more."))
+			Expect(message).NotTo(MatchRegexp(`&[a-z]*… <a href`))
+			Expect(message).To(HaveSuffix("</i>\n[View proposal: https://explorer.zetachain.com/proposals/42]"))
+		})
+
+		It("should split into messages with balanced tags in split mode", func() {
+			notification.ProposalURL = ""
+
+			tmpl, err := telegram.ParseTemplate("test", "<b>{{ .Title }}</b>\n\n<i>{{ .Summary }}</i>")
+			Expect(err).NotTo(HaveOccurred())
+
+			payload, err := telegram.Render(notification, notifiers.RenderOptions{
+				Templates: notifiers.TemplateSet{models.EventProposal: tmpl},
+				Overflow:  notifiers.Overflow{Mode: notifiers.OverflowSplit, MaxLength: 2000},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			messages := strings.Split(string(payload), "\n\n"+notifiers.PartSeparator+"\n\n")
+			Expect(len(messages)).To(BeNumerically(">", 5))
+
+			for _, message := range messages {
+				Expect(notifiers.TextLength(message)).To(BeNumerically("<=", 2000))
+				Expect(message).To(MatchRegexp(`^(<b>Upgrade v30</b>\n\n)?<i>[^<>]+</i>$`))
+				Expect(message).NotTo(MatchRegexp(`&[a-z]*</i>$`))
+			}
+		})
+	})
+})
@@ -0,0 +1,174 @@
+package notifiers
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/hazim1093/zeta-comms/pkg/i18n"
+	"github.com/hazim1093/zeta-comms/pkg/models"
+	"github.com/hazim1093/zeta-comms/pkg/zetachain"
+)
+
+// titleTemplate is the optional sub-template used for message titles, e.g. Slack headers and Discord embed titles
+const titleTemplate = "title"
+
+// TemplateSet holds templates keyed by event type
+type TemplateSet map[string]*template.Template
+
+// RenderOptions controls how a notification is rendered for a specific audience
+type RenderOptions struct {
+	// Templates overrides the platform's default templates per event type
+	Templates TemplateSet
+
+	// Overflow controls how messages over the platform's length limit are handled
+	Overflow Overflow
+
+	// Time controls the timezone and format of timestamps
+	Time TimeOptions
+
+	// Language is the code of the language to render labels in, empty means i18n.DefaultLanguage
+	Language string
+}
+
+// T translates a message of the i18n catalog into the language of the options
+func (o RenderOptions) T(id string, args ...interface{}) string {
+	return i18n.Translate(o.Language, id, args...)
+}
+
+// TemplateFuncs returns the helper functions available to the templates of every platform
+func TemplateFuncs(options RenderOptions) template.FuncMap {
+	return template.FuncMap{
+		"t": options.T,
+		"status": func(status string) string {
+			return StatusText(options.Language, status)
+		},
+		"amount": func(deposit zetachain.Deposit) string {
+			return deposit.Amount + " " + deposit.Denom
+		},
+		"time": options.Time.Format,
+		"relative": func(t time.Time) string {
+			return RelativeTime(t, options.Time.CurrentTime(), options.Language)
+		},
+		"now":   options.Time.CurrentTime,
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+		"trim":  strings.TrimSpace,
+		"truncate": func(length int, s string) string {
+			runes := []rune(s)
+			if len(runes) <= length {
+				return s
+			}
+
+			return string(runes[:length]) + "…"
+		},
+	}
+}
+
+// TemplateRenderer renders notifications of one platform from its default templates,
+// which can be overridden per audience through RenderOptions
+type TemplateRenderer struct {
+	platform      string
+	defaults      TemplateSet
+	platformFuncs func(options RenderOptions) template.FuncMap
+}
+
+// NewTemplateRenderer loads the default templates of a platform from templates/<event>.tmpl
+// files. platformFuncs adds platform specific helpers and may be nil.
+func NewTemplateRenderer(platform string, fsys fs.FS, platformFuncs func(options RenderOptions) template.FuncMap) *TemplateRenderer {
+	r := &TemplateRenderer{
+		platform:      platform,
+		defaults:      make(TemplateSet),
+		platformFuncs: platformFuncs,
+	}
+
+	for _, event := range models.EventTypes {
+		name := "templates/" + event + ".tmpl"
+
+		text, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			panic(fmt.Sprintf("missing default %s template %s: %v", platform, name, err))
+		}
+
+		r.defaults[event] = template.Must(r.Parse(platform+"/"+event, string(text)))
+	}
+
+	return r
+}
+
+// Parse parses a template with all helper functions of the platform
+func (r *TemplateRenderer) Parse(name string, text string) (*template.Template, error) {
+	return template.New(name).Funcs(r.funcs(RenderOptions{})).Parse(text)
+}
+
+// Render renders the body of a notification
+func (r *TemplateRenderer) Render(notification models.Notification, options RenderOptions) (string, error) {
+	return r.execute(r.lookup(notification.Event(), options), "", notification, options)
+}
+
+// RenderTitle renders the title of a notification. The title sub-template of the audience's
+// template is used if it defines one, otherwise the one of the default template.
+func (r *TemplateRenderer) RenderTitle(notification models.Notification, options RenderOptions) (string, error) {
+	tmpl := r.lookup(notification.Event(), options)
+	if tmpl.Lookup(titleTemplate) == nil {
+		tmpl = r.defaults[notification.Event()]
+	}
+
+	if tmpl == nil || tmpl.Lookup(titleTemplate) == nil {
+		return "", nil
+	}
+
+	return r.execute(tmpl, titleTemplate, notification, options)
+}
+
+func (r *TemplateRenderer) lookup(event string, options RenderOptions) *template.Template {
+	if tmpl, ok := options.Templates[event]; ok && tmpl != nil {
+		return tmpl
+	}
+
+	if tmpl, ok := r.defaults[event]; ok {
+		return tmpl
+	}
+
+	return r.defaults[models.EventBroadcast]
+}
+
+// execute runs a template, or one of its sub-templates, with helpers bound to the render options
+func (r *TemplateRenderer) execute(tmpl *template.Template, name string, notification models.Notification, options RenderOptions) (string, error) {
+	// Clone so that binding option specific helpers does not race with other renders
+	clone, err := tmpl.Clone()
+	if err != nil {
+		return "", fmt.Errorf("failed to clone %s template: %w", r.platform, err)
+	}
+
+	clone = clone.Funcs(r.funcs(options))
+
+	var buf bytes.Buffer
+
+	if name == "" {
+		err = clone.Execute(&buf, notification)
+	} else {
+		err = clone.ExecuteTemplate(&buf, name, notification)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("failed to render %s template: %w", r.platform, err)
+	}
+
+	return buf.String(), nil
+}
+
+func (r *TemplateRenderer) funcs(options RenderOptions) template.FuncMap {
+	funcs := TemplateFuncs(options)
+
+	if r.platformFuncs != nil {
+		for name, fn := range r.platformFuncs(options) {
+			funcs[name] = fn
+		}
+	}
+
+	return funcs
+}
@@ -0,0 +1,92 @@
+package notifiers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hazim1093/zeta-comms/pkg/i18n"
+)
+
+// DefaultDateFormat is the layout of timestamps for audiences without a date format
+const DefaultDateFormat = time.RFC1123
+
+// TimeOptions controls how timestamps are shown to an audience
+type TimeOptions struct {
+	Location *time.Location   // Nil means UTC
+	Layout   string           // Go reference time layout, empty means DefaultDateFormat
+	Now      func() time.Time // Nil means time.Now
+}
+
+// Format formats a timestamp in the audience's timezone and layout
+func (o TimeOptions) Format(t time.Time) string {
+	location := o.Location
+	if location == nil {
+		location = time.UTC
+	}
+
+	layout := o.Layout
+	if layout == "" {
+		layout = DefaultDateFormat
+	}
+
+	return t.In(location).Format(layout)
+}
+
+// CurrentTime returns the current time in the audience's timezone
+func (o TimeOptions) CurrentTime() time.Time {
+	now := time.Now
+	if o.Now != nil {
+		now = o.Now
+	}
+
+	location := o.Location
+	if location == nil {
+		location = time.UTC
+	}
+
+	return now().In(location)
+}
+
+// RelativeTime describes t relative to now with the two most significant of days, hours
+// and minutes, e.g. "in 2d 4h", "5m ago" or "now" for less than a minute
+func RelativeTime(t time.Time, now time.Time, language string) string {
+	d := t.Sub(now)
+
+	future := d >= 0
+	if !future {
+		d = -d
+	}
+
+	if d < time.Minute {
+		return i18n.Translate(language, "relative_now")
+	}
+
+	units := []struct {
+		suffix string
+		value  int64
+	}{
+		{"d", int64(d / (24 * time.Hour))},
+		{"h", int64(d % (24 * time.Hour) / time.Hour)},
+		{"m", int64(d % time.Hour / time.Minute)},
+	}
+
+	var parts []string
+
+	for _, unit := range units {
+		// Stop at a zero unit after the first one, e.g. "2d" rather than "2d 0h"
+		if len(parts) == 2 || (unit.value == 0 && len(parts) > 0) {
+			break
+		}
+
+		if unit.value > 0 {
+			parts = append(parts, fmt.Sprintf("%d%s", unit.value, unit.suffix))
+		}
+	}
+
+	if future {
+		return i18n.Translate(language, "relative_future", strings.Join(parts, " "))
+	}
+
+	return i18n.Translate(language, "relative_past", strings.Join(parts, " "))
+}
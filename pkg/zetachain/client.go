@@ -0,0 +1,230 @@
+package zetachain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hazim1093/zeta-comms/internal/config"
+	"github.com/rs/zerolog"
+)
+
+// Client queries the governance state of the configured networks. RESTClient and GRPCClient
+// implement it over the LCD API and gRPC respectively.
+type Client interface {
+	GetProposals(network string, query ProposalsQuery) (*ProposalsResponse, error)
+	GetProposal(network string, proposalID string) (*Proposal, error)
+	GetTally(network string, proposalID string) (*TallyResult, error)
+	GetVotes(network string, proposalID string) ([]Vote, error)
+	GetParams(network string) (*Params, error)
+	GetLatestBlock(network string) (*BlockHeader, error)
+	GetDenomsMetadata(network string) ([]DenomMetadata, error)
+	GetModuleParams(network string, module string) (json.RawMessage, error)
+}
+
+var (
+	_ Client = (*RESTClient)(nil)
+	_ Client = (*GRPCClient)(nil)
+	_ Client = (*FakeClient)(nil)
+)
+
+// proposalsPageLimit is the number of proposals requested per page
+const proposalsPageLimit = 1000
+
+// ProposalsQuery selects the proposals returned by GetProposals, the zero value selects all of them
+type ProposalsQuery struct {
+	Status  string // Only proposals in this status, e.g. PROPOSAL_STATUS_VOTING_PERIOD
+	Reverse bool   // Newest proposals first
+	AfterID uint64 // Only proposals with a higher ID. In reverse order, paging stops at the first older proposal.
+}
+
+// collectProposals requests the pages of a proposals query with fetchPage, starting with an empty key,
+// until the last page or, in reverse order, until the proposals are no newer than query.AfterID
+func collectProposals(query ProposalsQuery, fetchPage func(key string) (*ProposalsResponse, error)) (*ProposalsResponse, error) {
+	var (
+		response ProposalsResponse
+		key      string
+	)
+
+	seen := make(map[string]bool)
+
+	for {
+		page, err := fetchPage(key)
+		if err != nil {
+			return nil, err
+		}
+
+		response.Pagination = page.Pagination
+
+		done := false
+
+		for _, proposal := range page.Proposals {
+			if query.AfterID == 0 || ProposalID(proposal) > query.AfterID {
+				response.Proposals = append(response.Proposals, proposal)
+			} else if query.Reverse {
+				done = true
+
+				break
+			}
+		}
+
+		// A node that hands out the same key again would otherwise be paged forever
+		key = page.Pagination.NextKey
+		if done || key == "" || seen[key] {
+			return &response, nil
+		}
+
+		seen[key] = true
+	}
+}
+
+// ProposalID returns the numeric ID of a proposal, 0 if it is not a number
+func ProposalID(proposal Proposal) uint64 {
+	id, _ := strconv.ParseUint(proposal.ProposalId, 10, 64)
+
+	return id
+}
+
+// TallyResponse is the response of the tally endpoint of a proposal
+type TallyResponse struct {
+	Tally TallyResult `json:"tally"`
+}
+
+// VotesResponse lists the votes cast on a proposal
+type VotesResponse struct {
+	Votes      []Vote     `json:"votes"`
+	Pagination Pagination `json:"pagination"`
+}
+
+// Vote is a vote on a proposal, which may be split over several options
+type Vote struct {
+	ProposalId string               `json:"proposal_id"`
+	Voter      string               `json:"voter"`
+	Options    []WeightedVoteOption `json:"options"`
+	Metadata   string               `json:"metadata"`
+}
+
+// WeightedVoteOption is the share of a vote given to an option
+type WeightedVoteOption struct {
+	Option string `json:"option"` // e.g. VOTE_OPTION_YES
+	Weight string `json:"weight"` // Decimal such as 1.000000000000000000
+}
+
+// ParamsResponse is the response of the gov params endpoint
+type ParamsResponse struct {
+	Params Params `json:"params"`
+}
+
+// Params are the parameters of the gov module
+type Params struct {
+	MinDeposit             []Deposit `json:"min_deposit"`
+	MaxDepositPeriod       Duration  `json:"max_deposit_period"`
+	VotingPeriod           Duration  `json:"voting_period"`
+	Quorum                 string    `json:"quorum"`
+	Threshold              string    `json:"threshold"`
+	VetoThreshold          string    `json:"veto_threshold"`
+	MinInitialDepositRatio string    `json:"min_initial_deposit_ratio"`
+	ExpeditedVotingPeriod  Duration  `json:"expedited_voting_period"`
+	ExpeditedThreshold     string    `json:"expedited_threshold"`
+	ExpeditedMinDeposit    []Deposit `json:"expedited_min_deposit"`
+}
+
+// Duration is a protobuf duration, which the LCD API encodes as a string such as "172800s"
+type Duration time.Duration
+
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+// MarshalJSON encodes the duration like the LCD API
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(fmt.Sprintf("%gs", time.Duration(d).Seconds()))
+}
+
+// UnmarshalJSON decodes a duration as encoded by the LCD API
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	if value == "" {
+		*d = 0
+
+		return nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("invalid duration %q: %w", value, err)
+	}
+
+	*d = Duration(duration)
+
+	return nil
+}
+
+// NewClient returns a client that queries each network over its configured transport
+func NewClient(cfg *config.Config, logger *zerolog.Logger) Client {
+	return &transportClient{
+		config: cfg,
+		rest:   NewRESTClient(cfg, logger),
+		grpc:   NewGRPCClient(cfg, logger),
+	}
+}
+
+// transportClient dispatches the queries of each network to the REST or the gRPC client
+type transportClient struct {
+	config *config.Config
+	rest   *RESTClient
+	grpc   *GRPCClient
+}
+
+func (c *transportClient) client(network string) Client {
+	if c.config.Networks[network].Transport == config.TransportGRPC {
+		return c.grpc
+	}
+
+	return c.rest
+}
+
+func (c *transportClient) GetProposals(network string, query ProposalsQuery) (*ProposalsResponse, error) {
+	return c.client(network).GetProposals(network, query)
+}
+
+func (c *transportClient) GetProposal(network string, proposalID string) (*Proposal, error) {
+	return c.client(network).GetProposal(network, proposalID)
+}
+
+func (c *transportClient) GetTally(network string, proposalID string) (*TallyResult, error) {
+	return c.client(network).GetTally(network, proposalID)
+}
+
+func (c *transportClient) GetVotes(network string, proposalID string) ([]Vote, error) {
+	return c.client(network).GetVotes(network, proposalID)
+}
+
+func (c *transportClient) GetParams(network string) (*Params, error) {
+	return c.client(network).GetParams(network)
+}
+
+func (c *transportClient) GetLatestBlock(network string) (*BlockHeader, error) {
+	return c.client(network).GetLatestBlock(network)
+}
+
+func (c *transportClient) GetDenomsMetadata(network string) ([]DenomMetadata, error) {
+	return c.client(network).GetDenomsMetadata(network)
+}
+
+// GetModuleParams queries the LCD API for the params of modules that the gRPC client does not
+// support, if the network has endpoints for it
+func (c *transportClient) GetModuleParams(network string, module string) (json.RawMessage, error) {
+	params, err := c.client(network).GetModuleParams(network, module)
+	if errors.Is(err, ErrUnsupported) && len(c.config.Networks[network].Endpoints()) > 0 {
+		return c.rest.GetModuleParams(network, module)
+	}
+
+	return params, err
+}
@@ -0,0 +1,170 @@
+package zetachain
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hazim1093/zeta-comms/internal/config"
+	"github.com/hazim1093/zeta-comms/internal/metrics"
+)
+
+// ErrEndpointsUnavailable is returned when no LCD or gRPC endpoint of a network could serve a request
+var ErrEndpointsUnavailable = errors.New("all endpoints are unavailable")
+
+const (
+	// latencyWeight is the weight of the latest request in the moving average latency of an endpoint
+	latencyWeight = 0.3
+
+	// maxCooldown caps the time a repeatedly failing endpoint is skipped
+	maxCooldown = 10 * time.Minute
+)
+
+// EndpointStatus describes the health of an LCD endpoint
+type EndpointStatus struct {
+	URL       string
+	Healthy   bool
+	Latency   time.Duration // Moving average of successful requests
+	Failures  int           // Consecutive failed requests
+	LastError string
+}
+
+// endpoint is an LCD endpoint together with its health
+type endpoint struct {
+	url            string
+	latency        time.Duration
+	failures       int
+	unhealthyUntil time.Time
+	lastError      error
+}
+
+func (e *endpoint) healthy(now time.Time) bool {
+	return !now.Before(e.unhealthyUntil)
+}
+
+// endpointPool tracks the health of the LCD endpoints of a network and decides which to use.
+// Failed endpoints are skipped for a cooldown that doubles with each consecutive failure.
+type endpointPool struct {
+	network   string
+	strategy  string
+	cooldown  time.Duration
+	mu        sync.Mutex
+	endpoints []*endpoint
+	next      int
+}
+
+func newEndpointPool(network string, networkConfig config.Network) *endpointPool {
+	pool := &endpointPool{
+		network:  network,
+		strategy: networkConfig.Failover.Strategy,
+		cooldown: networkConfig.Failover.Cooldown,
+	}
+
+	if pool.cooldown <= 0 {
+		pool.cooldown = config.DefaultCooldown
+	}
+
+	for _, endpointURL := range networkConfig.Endpoints() {
+		pool.endpoints = append(pool.endpoints, &endpoint{url: endpointURL.String()})
+		metrics.EndpointHealthy.WithLabelValues(network, endpointURL.String()).Set(1)
+	}
+
+	return pool
+}
+
+// order returns the endpoints in the order to try them: the healthy ones according to the
+// strategy, then the unhealthy ones, soonest to recover first, as a last resort
+func (p *endpointPool) order(now time.Time) []*endpoint {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var healthy, unhealthy []*endpoint
+
+	for _, e := range p.endpoints {
+		if e.healthy(now) {
+			healthy = append(healthy, e)
+		} else {
+			unhealthy = append(unhealthy, e)
+		}
+	}
+
+	if p.strategy == config.StrategyRoundRobin {
+		if len(healthy) > 0 {
+			start := p.next % len(healthy)
+			healthy = append(healthy[start:], healthy[:start]...)
+			p.next++
+		}
+	} else {
+		// Endpoints without a measured latency yet come first, so that all of them get measured
+		sort.SliceStable(healthy, func(i, j int) bool {
+			return healthy[i].latency < healthy[j].latency
+		})
+	}
+
+	sort.SliceStable(unhealthy, func(i, j int) bool {
+		return unhealthy[i].unhealthyUntil.Before(unhealthy[j].unhealthyUntil)
+	})
+
+	return append(healthy, unhealthy...)
+}
+
+// success records a request an endpoint served in the given time
+func (p *endpointPool) success(e *endpoint, latency time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if e.latency == 0 {
+		e.latency = latency
+	} else {
+		e.latency = time.Duration(latencyWeight*float64(latency) + (1-latencyWeight)*float64(e.latency))
+	}
+
+	e.failures = 0
+	e.unhealthyUntil = time.Time{}
+	e.lastError = nil
+
+	metrics.EndpointHealthy.WithLabelValues(p.network, e.url).Set(1)
+	metrics.EndpointLatency.WithLabelValues(p.network, e.url).Set(e.latency.Seconds())
+}
+
+// failure records a failed request and takes the endpoint out of rotation for a while
+func (p *endpointPool) failure(e *endpoint, err error, now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	cooldown := p.cooldown << min(e.failures, 16)
+	if cooldown > maxCooldown || cooldown <= 0 {
+		cooldown = maxCooldown
+	}
+
+	e.failures++
+	e.unhealthyUntil = now.Add(cooldown)
+	e.lastError = err
+
+	metrics.EndpointHealthy.WithLabelValues(p.network, e.url).Set(0)
+}
+
+// status returns the health of all endpoints
+func (p *endpointPool) status(now time.Time) []EndpointStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	statuses := make([]EndpointStatus, 0, len(p.endpoints))
+	for _, e := range p.endpoints {
+		status := EndpointStatus{
+			URL:      e.url,
+			Healthy:  e.healthy(now),
+			Latency:  e.latency,
+			Failures: e.failures,
+		}
+
+		if e.lastError != nil {
+			status.LastError = e.lastError.Error()
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses
+}
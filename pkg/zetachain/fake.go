@@ -0,0 +1,183 @@
+package zetachain
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sync"
+)
+
+// FakeClient is an in-memory Client for tests. Proposals, votes and the other query results are set
+// directly on it, and Err, if set, is returned by every query.
+type FakeClient struct {
+	mu sync.Mutex
+
+	Proposals      map[string][]Proposal // Keyed by network
+	Votes          map[string][]Vote     // Keyed by proposal ID
+	Params         Params
+	LatestBlock    BlockHeader
+	DenomsMetadata []DenomMetadata
+	ModuleParams   map[string]json.RawMessage // Keyed by module, e.g. zetachain.zetacore.crosschain
+	Err            error
+
+	// Calls counts the queries per method, e.g. "GetProposal", and ProposalsQueries lists the queries of GetProposals
+	Calls            map[string]int
+	ProposalsQueries []ProposalsQuery
+}
+
+// NewFakeClient returns a FakeClient without any proposals
+func NewFakeClient() *FakeClient {
+	return &FakeClient{
+		Proposals: make(map[string][]Proposal),
+		Votes:     make(map[string][]Vote),
+		Calls:     make(map[string]int),
+	}
+}
+
+// SetProposals replaces the proposals of a network
+func (f *FakeClient) SetProposals(network string, proposals ...Proposal) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.Proposals[network] = proposals
+}
+
+// GetProposals returns the proposals of a network selected by query, in the order they were set or in reverse
+func (f *FakeClient) GetProposals(network string, query ProposalsQuery) (*ProposalsResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.Calls["GetProposals"]++
+	f.ProposalsQueries = append(f.ProposalsQueries, query)
+
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	var proposals []Proposal
+
+	for _, proposal := range f.Proposals[network] {
+		if query.Status == "" || proposal.Status == query.Status {
+			proposals = append(proposals, proposal)
+		}
+	}
+
+	if query.Reverse {
+		slices.Reverse(proposals)
+	}
+
+	return collectProposals(query, func(string) (*ProposalsResponse, error) {
+		return &ProposalsResponse{Proposals: proposals}, nil
+	})
+}
+
+func (f *FakeClient) GetProposal(network string, proposalID string) (*Proposal, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.Calls["GetProposal"]++
+
+	return f.proposal(network, proposalID)
+}
+
+func (f *FakeClient) GetTally(network string, proposalID string) (*TallyResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.Calls["GetTally"]++
+
+	proposal, err := f.proposal(network, proposalID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proposal.FinalTallyResult, nil
+}
+
+func (f *FakeClient) GetVotes(_ string, proposalID string) ([]Vote, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.Calls["GetVotes"]++
+
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	return append([]Vote(nil), f.Votes[proposalID]...), nil
+}
+
+func (f *FakeClient) GetParams(_ string) (*Params, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.Calls["GetParams"]++
+
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	params := f.Params
+
+	return &params, nil
+}
+
+func (f *FakeClient) GetLatestBlock(_ string) (*BlockHeader, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.Calls["GetLatestBlock"]++
+
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	header := f.LatestBlock
+
+	return &header, nil
+}
+
+func (f *FakeClient) GetDenomsMetadata(_ string) ([]DenomMetadata, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.Calls["GetDenomsMetadata"]++
+
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	return append([]DenomMetadata(nil), f.DenomsMetadata...), nil
+}
+
+func (f *FakeClient) GetModuleParams(_ string, module string) (json.RawMessage, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.Calls["GetModuleParams"]++
+
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	params, ok := f.ModuleParams[module]
+	if !ok {
+		return nil, &StatusError{StatusCode: http.StatusNotImplemented}
+	}
+
+	return params, nil
+}
+
+func (f *FakeClient) proposal(network string, proposalID string) (*Proposal, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	for _, proposal := range f.Proposals[network] {
+		if proposal.ProposalId == proposalID {
+			return &proposal, nil
+		}
+	}
+
+	return nil, &StatusError{StatusCode: http.StatusNotFound}
+}
@@ -0,0 +1,229 @@
+package zetachain
+
+import (
+	"encoding/json"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/hazim1093/zeta-comms/internal/config"
+)
+
+// govVersions knows the gov API version of each network, configured or detected on first use
+type govVersions struct {
+	config *config.Config
+
+	mu       sync.Mutex
+	detected map[string]string
+}
+
+func newGovVersions(cfg *config.Config) *govVersions {
+	return &govVersions{
+		config:   cfg,
+		detected: make(map[string]string),
+	}
+}
+
+// query calls query with the gov API version of a network. If the version is neither configured nor
+// detected yet, v1 is tried first and v1beta1 if unsupported reports that the node does not serve v1.
+// The version that answered is remembered.
+func (v *govVersions) query(network string, unsupported func(err error) bool, query func(version string) error) error {
+	if version := v.config.Networks[network].GovVersion; version != "" {
+		return query(version)
+	}
+
+	v.mu.Lock()
+	version, ok := v.detected[network]
+	v.mu.Unlock()
+
+	if ok {
+		return query(version)
+	}
+
+	err := query(config.GovV1)
+	if err == nil {
+		v.remember(network, config.GovV1)
+
+		return nil
+	}
+
+	if !unsupported(err) {
+		return err
+	}
+
+	if err := query(config.GovV1Beta1); err != nil {
+		return err
+	}
+
+	v.remember(network, config.GovV1Beta1)
+
+	return nil
+}
+
+func (v *govVersions) remember(network string, version string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.detected[network] = version
+}
+
+// legacyContentTypes maps the v1beta1 proposal contents to the messages that replaced them in v1,
+// so that message type filters match proposals of both versions
+var legacyContentTypes = map[string]string{
+	"/cosmos.upgrade.v1beta1.SoftwareUpgradeProposal":       softwareUpgradeType,
+	"/cosmos.upgrade.v1beta1.CancelSoftwareUpgradeProposal": "/cosmos.upgrade.v1beta1.MsgCancelUpgrade",
+}
+
+// contentMessage returns the message of a proposal with v1beta1 content. raw is the JSON of the
+// content, if known.
+func contentMessage(contentType string, plan UpgradePlan, raw json.RawMessage) Message {
+	if messageType, ok := legacyContentTypes[contentType]; ok {
+		contentType = messageType
+	}
+
+	return Message{Type: contentType, Data: MessageData{Plan: plan}, Raw: raw}
+}
+
+// proposalsResponseV1Beta1 is the response of the v1beta1 proposals endpoint
+type proposalsResponseV1Beta1 struct {
+	Proposals  []proposalV1Beta1 `json:"proposals"`
+	Pagination Pagination        `json:"pagination"`
+}
+
+type proposalResponseV1Beta1 struct {
+	Proposal proposalV1Beta1 `json:"proposal"`
+}
+
+// proposalV1Beta1 is a proposal of the v1beta1 API, which holds a single content instead of messages
+type proposalV1Beta1 struct {
+	ProposalId       string         `json:"proposal_id"`
+	Content          contentV1Beta1 `json:"content"`
+	Status           string         `json:"status"`
+	FinalTallyResult tallyV1Beta1   `json:"final_tally_result"`
+	SubmitTime       time.Time      `json:"submit_time"`
+	DepositEndTime   time.Time      `json:"deposit_end_time"`
+	TotalDeposit     []Deposit      `json:"total_deposit"`
+	VotingStartTime  time.Time      `json:"voting_start_time"`
+	VotingEndTime    time.Time      `json:"voting_end_time"`
+}
+
+// normalize converts the proposal to the v1 model
+func (p proposalV1Beta1) normalize() Proposal {
+	return Proposal{
+		ProposalId:       p.ProposalId,
+		Status:           p.Status,
+		Title:            p.Content.Title,
+		Summary:          p.Content.Description,
+		Messages:         []Message{contentMessage(p.Content.Type, p.Content.Plan, p.Content.Raw)},
+		FinalTallyResult: p.FinalTallyResult.normalize(),
+		SubmitTime:       p.SubmitTime,
+		DepositEndTime:   p.DepositEndTime,
+		VotingStartTime:  p.VotingStartTime,
+		VotingEndTime:    p.VotingEndTime,
+		TotalDeposit:     p.TotalDeposit,
+	}
+}
+
+// contentV1Beta1 is the content of a v1beta1 proposal
+type contentV1Beta1 struct {
+	Type        string      `json:"@type"`
+	Title       string      `json:"title"`
+	Description string      `json:"description"`
+	Plan        UpgradePlan `json:"plan"`
+
+	// Raw is the JSON of the content, from which the fields of its type are decoded
+	Raw json.RawMessage `json:"-"`
+}
+
+func (c *contentV1Beta1) UnmarshalJSON(data []byte) error {
+	type content contentV1Beta1
+	if err := json.Unmarshal(data, (*content)(c)); err != nil {
+		return err
+	}
+
+	c.Raw = slices.Clone(data)
+
+	return nil
+}
+
+type tallyResponseV1Beta1 struct {
+	Tally tallyV1Beta1 `json:"tally"`
+}
+
+// tallyV1Beta1 is a tally of the v1beta1 API
+type tallyV1Beta1 struct {
+	Yes        string `json:"yes"`
+	Abstain    string `json:"abstain"`
+	No         string `json:"no"`
+	NoWithVeto string `json:"no_with_veto"`
+}
+
+func (t tallyV1Beta1) normalize() TallyResult {
+	return TallyResult{
+		YesCount:        t.Yes,
+		AbstainCount:    t.Abstain,
+		NoCount:         t.No,
+		NoWithVetoCount: t.NoWithVeto,
+	}
+}
+
+type votesResponseV1Beta1 struct {
+	Votes []voteV1Beta1 `json:"votes"`
+}
+
+// voteV1Beta1 is a vote of the v1beta1 API. Chains before weighted votes only set Option.
+type voteV1Beta1 struct {
+	ProposalId string               `json:"proposal_id"`
+	Voter      string               `json:"voter"`
+	Option     string               `json:"option"`
+	Options    []WeightedVoteOption `json:"options"`
+}
+
+func (v voteV1Beta1) normalize() Vote {
+	vote := Vote{
+		ProposalId: v.ProposalId,
+		Voter:      v.Voter,
+		Options:    v.Options,
+	}
+
+	if len(vote.Options) == 0 && v.Option != "" {
+		vote.Options = []WeightedVoteOption{{Option: v.Option, Weight: "1.000000000000000000"}}
+	}
+
+	return vote
+}
+
+// paramsResponseV1Beta1 is the response of the v1beta1 params endpoint, which only fills the
+// params of the requested type
+type paramsResponseV1Beta1 struct {
+	VotingParams struct {
+		VotingPeriod Duration `json:"voting_period"`
+	} `json:"voting_params"`
+	DepositParams struct {
+		MinDeposit       []Deposit `json:"min_deposit"`
+		MaxDepositPeriod Duration  `json:"max_deposit_period"`
+	} `json:"deposit_params"`
+	TallyParams struct {
+		Quorum        string `json:"quorum"`
+		Threshold     string `json:"threshold"`
+		VetoThreshold string `json:"veto_threshold"`
+	} `json:"tally_params"`
+}
+
+// paramsTypesV1Beta1 are the params types of the v1beta1 API, which are queried one by one
+var paramsTypesV1Beta1 = []string{"voting", "deposit", "tallying"}
+
+// merge copies the params of the given type into params
+func (r paramsResponseV1Beta1) merge(paramsType string, params *Params) {
+	switch paramsType {
+	case "voting":
+		params.VotingPeriod = r.VotingParams.VotingPeriod
+	case "deposit":
+		params.MinDeposit = r.DepositParams.MinDeposit
+		params.MaxDepositPeriod = r.DepositParams.MaxDepositPeriod
+	case "tallying":
+		params.Quorum = r.TallyParams.Quorum
+		params.Threshold = r.TallyParams.Threshold
+		params.VetoThreshold = r.TallyParams.VetoThreshold
+	}
+}
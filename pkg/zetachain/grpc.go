@@ -0,0 +1,425 @@
+package zetachain
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hazim1093/zeta-comms/internal/config"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Methods of the gov query service, see govMethod
+const (
+	methodProposals = "Proposals"
+	methodProposal  = "Proposal"
+	methodTally     = "TallyResult"
+	methodVotes     = "Votes"
+	methodParams    = "Params"
+)
+
+// gRPC methods of the other Cosmos SDK query services
+const (
+	methodDenomsMetadata = "/cosmos.bank.v1beta1.Query/DenomsMetadata"
+	methodLatestBlock    = "/cosmos.base.tendermint.v1beta1.Service/GetLatestBlock"
+)
+
+// govMethod returns the full name of a method of the gov query service in the given version
+func govMethod(version string, method string) string {
+	return "/cosmos.gov." + version + ".Query/" + method
+}
+
+// grpcTimeout bounds every gRPC query
+const grpcTimeout = 30 * time.Second
+
+// GRPCClient queries networks over the gRPC endpoint of a node
+type GRPCClient struct {
+	config   *config.Config
+	log      *zerolog.Logger
+	versions *govVersions
+
+	// Connections per network, created on first use
+	mu    sync.Mutex
+	conns map[string]*grpc.ClientConn
+}
+
+func NewGRPCClient(cfg *config.Config, logger *zerolog.Logger) *GRPCClient {
+	if logger == nil {
+		nop := zerolog.Nop()
+		logger = &nop
+	}
+
+	return &GRPCClient{
+		config:   cfg,
+		log:      logger,
+		versions: newGovVersions(cfg),
+		conns:    make(map[string]*grpc.ClientConn),
+	}
+}
+
+// GetProposals fetches the proposals selected by query, following the pagination to the last page
+func (g *GRPCClient) GetProposals(network string, query ProposalsQuery) (*ProposalsResponse, error) {
+	var status uint64
+	if query.Status != "" {
+		index := slices.Index(proposalStatuses, query.Status)
+		if index < 0 {
+			return nil, fmt.Errorf("unknown proposal status %q", query.Status)
+		}
+
+		status = uint64(index)
+	}
+
+	var response *ProposalsResponse
+
+	err := g.versions.query(network, unimplemented, func(version string) error {
+		var err error
+		response, err = collectProposals(query, func(key string) (*ProposalsResponse, error) {
+			pageKey, err := base64.StdEncoding.DecodeString(key)
+			if err != nil {
+				return nil, fmt.Errorf("invalid page key %q: %w", key, err)
+			}
+
+			var request protoEncoder
+			request.uint(1, status)
+			request.bytes(4, pageRequest(pageKey, proposalsPageLimit, query.Reverse))
+
+			var page ProposalsResponse
+
+			err = g.invoke(network, govMethod(version, methodProposals), request.b, func(num protowire.Number, _ uint64, b []byte) error {
+				var err error
+
+				switch num {
+				case 1:
+					var proposal Proposal
+					proposal, err = decodeProposalOf(version, b)
+					page.Proposals = append(page.Proposals, proposal)
+				case 2:
+					page.Pagination, err = decodePagination(b)
+				}
+
+				return err
+			})
+
+			if err != nil {
+				return nil, err
+			}
+
+			return &page, nil
+		})
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// GetProposal fetches a single proposal by ID
+func (g *GRPCClient) GetProposal(network string, proposalID string) (*Proposal, error) {
+	request, err := proposalRequest(proposalID)
+	if err != nil {
+		return nil, err
+	}
+
+	var proposal Proposal
+
+	err = g.versions.query(network, unimplemented, func(version string) error {
+		return g.invoke(network, govMethod(version, methodProposal), request, func(num protowire.Number, _ uint64, b []byte) error {
+			var err error
+			if num == 1 {
+				proposal, err = decodeProposalOf(version, b)
+			}
+
+			return err
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &proposal, nil
+}
+
+// GetTally fetches the current tally of a proposal
+func (g *GRPCClient) GetTally(network string, proposalID string) (*TallyResult, error) {
+	request, err := proposalRequest(proposalID)
+	if err != nil {
+		return nil, err
+	}
+
+	var tally TallyResult
+
+	// Both versions encode tallies alike
+	err = g.versions.query(network, unimplemented, func(version string) error {
+		return g.invoke(network, govMethod(version, methodTally), request, func(num protowire.Number, _ uint64, b []byte) error {
+			var err error
+			if num == 1 {
+				tally, err = decodeTally(b)
+			}
+
+			return err
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &tally, nil
+}
+
+// GetVotes fetches the votes cast on a proposal
+func (g *GRPCClient) GetVotes(network string, proposalID string) ([]Vote, error) {
+	id, err := strconv.ParseUint(proposalID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proposal ID %q", proposalID)
+	}
+
+	var request protoEncoder
+	request.uint(1, id)
+	request.bytes(2, pageRequest(nil, 1000, false))
+
+	var votes []Vote
+
+	err = g.versions.query(network, unimplemented, func(version string) error {
+		votes = nil
+
+		return g.invoke(network, govMethod(version, methodVotes), request.b, func(num protowire.Number, _ uint64, b []byte) error {
+			if num != 1 {
+				return nil
+			}
+
+			vote, err := decodeVote(b, version == config.GovV1Beta1)
+			votes = append(votes, vote)
+
+			return err
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return votes, nil
+}
+
+// GetParams fetches the parameters of the gov module. The v1beta1 API serves them in three parts.
+func (g *GRPCClient) GetParams(network string) (*Params, error) {
+	var params Params
+
+	err := g.versions.query(network, unimplemented, func(version string) error {
+		if version == config.GovV1Beta1 {
+			for _, paramsType := range paramsTypesV1Beta1 {
+				var request protoEncoder
+				request.string(1, paramsType)
+
+				err := g.invoke(network, govMethod(version, methodParams), request.b, func(num protowire.Number, _ uint64, b []byte) error {
+					return decodeLegacyParams(num, b, &params)
+				})
+
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}
+
+		var request protoEncoder
+		request.string(1, "tallying")
+
+		return g.invoke(network, govMethod(version, methodParams), request.b, func(num protowire.Number, _ uint64, b []byte) error {
+			var err error
+			if num == 4 {
+				params, err = decodeParams(b)
+			}
+
+			return err
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &params, nil
+}
+
+// GetLatestBlock fetches the header of the latest block
+func (g *GRPCClient) GetLatestBlock(network string) (*BlockHeader, error) {
+	var header BlockHeader
+
+	err := g.invoke(network, methodLatestBlock, nil, func(num protowire.Number, _ uint64, b []byte) error {
+		var err error
+
+		// The block in the SDK's format (3) replaces the deprecated CometBFT block (2) in newer versions
+		if num == 2 || num == 3 {
+			header, err = decodeBlockHeader(b)
+		}
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &header, nil
+}
+
+// GetDenomsMetadata fetches the metadata of all denominations registered in the bank module
+func (g *GRPCClient) GetDenomsMetadata(network string) ([]DenomMetadata, error) {
+	var request protoEncoder
+	request.bytes(1, pageRequest(nil, 1000, false))
+
+	var metadatas []DenomMetadata
+
+	err := g.invoke(network, methodDenomsMetadata, request.b, func(num protowire.Number, _ uint64, b []byte) error {
+		if num != 1 {
+			return nil
+		}
+
+		metadata, err := decodeDenomMetadata(b)
+		metadatas = append(metadatas, metadata)
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return metadatas, nil
+}
+
+// GetModuleParams fetches the params of a module, given by its protobuf package, as JSON. As the
+// params of each module are a message of their own, only the modules in moduleParamsSchemas are
+// supported.
+func (g *GRPCClient) GetModuleParams(network string, module string) (json.RawMessage, error) {
+	schema, ok := moduleParamsSchemas[module]
+	if !ok {
+		return nil, fmt.Errorf("params of module %s: %w", module, ErrUnsupported)
+	}
+
+	// The gov module expects a params type, but returns all params with it
+	var request protoEncoder
+	if module == "cosmos.gov."+config.GovV1 {
+		request.string(1, "tallying")
+	}
+
+	var params map[string]any
+	err := g.invoke(network, "/"+module+".Query/"+methodParams, request.b, func(num protowire.Number, _ uint64, b []byte) error {
+		if num != schema.field {
+			return nil
+		}
+
+		var err error
+		params, err = protoObject(schema.fields, b)
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if params == nil {
+		return nil, fmt.Errorf("no params in the response for module %s", module)
+	}
+
+	return json.Marshal(params)
+}
+
+// proposalRequest encodes a request that only holds a proposal ID
+func proposalRequest(proposalID string) ([]byte, error) {
+	id, err := strconv.ParseUint(proposalID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proposal ID %q", proposalID)
+	}
+
+	var request protoEncoder
+	request.uint(1, id)
+
+	return request.b, nil
+}
+
+// unimplemented reports whether an error means that the node does not serve the requested method
+func unimplemented(err error) bool {
+	return status.Code(err) == codes.Unimplemented
+}
+
+// invoke calls a gRPC method of a network's node and passes the fields of the response to field.
+// An unreachable node is reported as ErrEndpointsUnavailable.
+func (g *GRPCClient) invoke(network string, method string, request []byte, field func(num protowire.Number, v uint64, b []byte) error) error {
+	conn, err := g.conn(network)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), grpcTimeout)
+	defer cancel()
+
+	var response []byte
+
+	err = conn.Invoke(ctx, method, &request, &response, grpc.ForceCodec(rawCodec{}))
+	if err != nil {
+		switch status.Code(err) {
+		case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+			return fmt.Errorf("%w: %s: %w", ErrEndpointsUnavailable, conn.Target(), err)
+		default:
+			return fmt.Errorf("gRPC request %s failed: %w", method, err)
+		}
+	}
+
+	if err := protoFields(response, field); err != nil {
+		return fmt.Errorf("failed to decode the response of %s: %w", method, err)
+	}
+
+	return nil
+}
+
+// conn returns the connection to a network's node
+func (g *GRPCClient) conn(network string) (*grpc.ClientConn, error) {
+	networkConfig, ok := g.config.Networks[network]
+	if !ok {
+		return nil, fmt.Errorf("network %s not found in config", network)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if conn, ok := g.conns[network]; ok {
+		return conn, nil
+	}
+
+	creds := insecure.NewCredentials()
+	if networkConfig.GRPC.TLS {
+		creds = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+
+	conn, err := grpc.NewClient(networkConfig.GRPC.Address, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for %s: %w", networkConfig.GRPC.Address, err)
+	}
+
+	g.log.Info().Str("network", network).Str("address", networkConfig.GRPC.Address).Msg("Querying network over gRPC")
+	g.conns[network] = conn
+
+	return conn, nil
+}
@@ -0,0 +1,283 @@
+package zetachain_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/hazim1093/zeta-comms/internal/config"
+	"github.com/hazim1093/zeta-comms/pkg/zetachain"
+)
+
+// bytesCodec lets the test server read and write encoded protobuf messages
+type bytesCodec struct{}
+
+func (bytesCodec) Marshal(v any) ([]byte, error) { return *v.(*[]byte), nil }
+
+func (bytesCodec) Unmarshal(data []byte, v any) error {
+	*v.(*[]byte) = append([]byte(nil), data...)
+
+	return nil
+}
+
+func (bytesCodec) Name() string { return "proto" }
+
+// message encodes the fields of a protobuf message, given as field numbers followed by a
+// uint64 for varints, or a string or []byte for length-delimited fields
+func message(fields ...any) []byte {
+	var b []byte
+
+	for i := 0; i < len(fields); i += 2 {
+		num := protowire.Number(fields[i].(int))
+
+		switch value := fields[i+1].(type) {
+		case uint64:
+			b = protowire.AppendTag(b, num, protowire.VarintType)
+			b = protowire.AppendVarint(b, value)
+		case string:
+			b = protowire.AppendTag(b, num, protowire.BytesType)
+			b = protowire.AppendString(b, value)
+		case []byte:
+			b = protowire.AppendTag(b, num, protowire.BytesType)
+			b = protowire.AppendBytes(b, value)
+		}
+	}
+
+	return b
+}
+
+var _ = Describe("GRPCClient", func() {
+	var (
+		client   zetachain.Client
+		requests map[string][]byte
+	)
+
+	votingEnd := time.Date(2025, 3, 6, 14, 30, 0, 0, time.UTC)
+
+	responses := map[string][]byte{
+		"/cosmos.gov.v1.Query/Proposal": message(1, message(
+			1, uint64(42),
+			2, message(1, "/cosmos.upgrade.v1beta1.MsgSoftwareUpgrade", 2, message(
+				1, "zeta1authority",
+				2, message(1, "v30", 3, uint64(5000000), 4, "binaries"),
+			)),
+			2, message(1, "/cosmos.distribution.v1beta1.MsgCommunityPoolSpend", 2, message(
+				1, "zeta1authority",
+				2, "zeta1recipient",
+				3, message(1, "azeta", 2, "5000"),
+			)),
+			2, message(1, "/cosmos.gov.v1.MsgExecLegacyContent", 2, message(
+				1, message(1, "/cosmos.params.v1beta1.ParameterChangeProposal", 2, message(
+					1, "Raise max validators",
+					3, message(1, "staking", 2, "MaxValidators", 3, "150"),
+				)),
+			)),
+			2, message(1, "/cosmos.gov.v1.MsgUpdateParams", 2, message(
+				2, message(3, message(1, uint64(172800)), 4, "0.400000000000000000", 13, uint64(1)),
+			)),
+			3, uint64(2),
+			4, message(1, "600", 2, "100", 3, "300", 4, "0"),
+			7, message(1, "azeta", 2, "1000000000000000000000"),
+			9, message(1, uint64(votingEnd.Unix())),
+			11, "Upgrade v30",
+			14, uint64(1),
+		)),
+		"/cosmos.gov.v1.Query/Votes": message(1, message(
+			1, uint64(42),
+			2, "zeta1voter",
+			4, message(1, uint64(1), 2, "0.700000000000000000"),
+			4, message(1, uint64(4), 2, "0.300000000000000000"),
+		)),
+		"/cosmos.gov.v1.Query/Params": message(4, message(
+			1, message(1, "azeta", 2, "1000"),
+			3, message(1, uint64(172800)),
+			4, "0.334000000000000000",
+		)),
+		"/cosmos.gov.v1beta1.Query/Proposals": message(1, message(
+			1, uint64(7),
+			2, message(1, "/cosmos.upgrade.v1beta1.SoftwareUpgradeProposal", 2, message(
+				1, "Upgrade v12",
+				2, "Scheduled upgrade",
+				3, message(1, "v12", 3, uint64(1200000)),
+			)),
+			3, uint64(3),
+		)),
+		"/cosmos.gov.v1beta1.Query/Params": message(
+			1, message(1, message(1, uint64(172800))),
+			3, message(1, "334000000000000000", 2, "500000000000000000"),
+		),
+		"/cosmos.base.tendermint.v1beta1.Service/GetLatestBlock": message(3, message(
+			1, message(2, "zetachain_7000-1", 3, uint64(4990000), 4, message(1, uint64(votingEnd.Unix()))),
+		)),
+	}
+
+	BeforeEach(func() {
+		requests = make(map[string][]byte)
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+
+		server := grpc.NewServer(
+			grpc.ForceServerCodec(bytesCodec{}),
+			grpc.UnknownServiceHandler(func(_ any, stream grpc.ServerStream) error {
+				method, _ := grpc.MethodFromServerStream(stream)
+
+				var request []byte
+				if err := stream.RecvMsg(&request); err != nil {
+					return err
+				}
+
+				requests[method] = request
+
+				response, ok := responses[method]
+				if !ok {
+					return status.Errorf(codes.Unimplemented, "unknown method %s", method)
+				}
+
+				return stream.SendMsg(&response)
+			}),
+		)
+		DeferCleanup(server.Stop)
+
+		go func() {
+			_ = server.Serve(listener)
+		}()
+
+		client = zetachain.NewClient(&config.Config{
+			Networks: map[string]config.Network{
+				"mainnet": {Transport: config.TransportGRPC, GRPC: config.GRPC{Address: listener.Addr().String()}},
+				"devnet":  {Transport: config.TransportGRPC, GRPC: config.GRPC{Address: listener.Addr().String()}},
+			},
+		}, nil)
+	})
+
+	It("should decode proposals including their upgrade plan", func() {
+		proposal, err := client.GetProposal("mainnet", "42")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requests["/cosmos.gov.v1.Query/Proposal"]).To(Equal(message(1, uint64(42))))
+
+		Expect(proposal.ProposalId).To(Equal("42"))
+		Expect(proposal.Title).To(Equal("Upgrade v30"))
+		Expect(proposal.Status).To(Equal("PROPOSAL_STATUS_VOTING_PERIOD"))
+		Expect(proposal.Expedited).To(BeTrue())
+		Expect(proposal.VotingEndTime).To(Equal(votingEnd))
+		Expect(proposal.FinalTallyResult).To(Equal(zetachain.TallyResult{YesCount: "600", AbstainCount: "100", NoCount: "300", NoWithVetoCount: "0"}))
+		Expect(proposal.TotalDeposit).To(Equal([]zetachain.Deposit{{Denom: "azeta", Amount: "1000000000000000000000"}}))
+		Expect(proposal.Messages).To(HaveLen(4))
+		Expect(proposal.Messages[0].Type).To(Equal("/cosmos.upgrade.v1beta1.MsgSoftwareUpgrade"))
+		Expect(proposal.Messages[0].Data.Plan).To(Equal(zetachain.UpgradePlan{Name: "v30", Height: "5000000", Info: "binaries"}))
+	})
+
+	It("should decode the fields of messages with a schema as for the LCD API", func() {
+		proposal, err := client.GetProposal("mainnet", "42")
+		Expect(err).NotTo(HaveOccurred())
+
+		fields, err := proposal.Messages[1].Fields()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fields).To(Equal([]zetachain.MessageField{
+			{Name: "Recipient", Value: "zeta1recipient"},
+			{Name: "Amount", Value: "5000 azeta", Coins: []zetachain.Deposit{{Denom: "azeta", Amount: "5000"}}},
+		}))
+
+		fields, err = proposal.Messages[2].Fields()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fields).To(Equal([]zetachain.MessageField{
+			{Name: "Content", Value: "/cosmos.params.v1beta1.ParameterChangeProposal"},
+			{Name: "staking.MaxValidators", Value: "150"},
+		}))
+
+		fields, err = proposal.Messages[3].Fields()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fields).To(ContainElements(
+			zetachain.MessageField{Name: "voting_period", Value: "172800s"},
+			zetachain.MessageField{Name: "quorum", Value: "0.400000000000000000"},
+			zetachain.MessageField{Name: "burn_vote_quorum", Value: "true"},
+		))
+	})
+
+	It("should decode weighted votes, params and the latest block", func() {
+		votes, err := client.GetVotes("mainnet", "42")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(votes).To(Equal([]zetachain.Vote{{
+			ProposalId: "42",
+			Voter:      "zeta1voter",
+			Options: []zetachain.WeightedVoteOption{
+				{Option: "VOTE_OPTION_YES", Weight: "0.700000000000000000"},
+				{Option: "VOTE_OPTION_NO_WITH_VETO", Weight: "0.300000000000000000"},
+			},
+		}}))
+
+		params, err := client.GetParams("mainnet")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(params.MinDeposit).To(Equal([]zetachain.Deposit{{Denom: "azeta", Amount: "1000"}}))
+		Expect(params.VotingPeriod).To(Equal(zetachain.Duration(48 * time.Hour)))
+		Expect(params.Quorum).To(Equal("0.334000000000000000"))
+
+		header, err := client.GetLatestBlock("mainnet")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*header).To(Equal(zetachain.BlockHeader{ChainID: "zetachain_7000-1", Height: "4990000", Time: votingEnd}))
+	})
+
+	It("should fetch the params of supported modules as JSON like the LCD API", func() {
+		params, err := client.GetModuleParams("mainnet", "cosmos.gov.v1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requests["/cosmos.gov.v1.Query/Params"]).To(Equal(message(1, "tallying")))
+
+		var decoded map[string]any
+		Expect(json.Unmarshal(params, &decoded)).To(Succeed())
+		Expect(decoded).To(HaveKeyWithValue("voting_period", "172800s"))
+		Expect(decoded).To(HaveKeyWithValue("quorum", "0.334000000000000000"))
+		Expect(decoded).To(HaveKeyWithValue("min_deposit", []any{map[string]any{"denom": "azeta", "amount": "1000"}}))
+
+		_, err = client.GetModuleParams("mainnet", "zetachain.zetacore.observer")
+		Expect(errors.Is(err, zetachain.ErrUnsupported)).To(BeTrue())
+	})
+
+	It("should fall back to gov v1beta1 if the node does not implement v1", func() {
+		response, err := client.GetProposals("devnet", zetachain.ProposalsQuery{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requests).To(HaveKey("/cosmos.gov.v1.Query/Proposals"))
+
+		Expect(response.Proposals).To(HaveLen(1))
+		proposal := response.Proposals[0]
+		Expect(proposal.ProposalId).To(Equal("7"))
+		Expect(proposal.Title).To(Equal("Upgrade v12"))
+		Expect(proposal.Summary).To(Equal("Scheduled upgrade"))
+		Expect(proposal.Status).To(Equal("PROPOSAL_STATUS_PASSED"))
+		Expect(proposal.Messages).To(Equal([]zetachain.Message{{
+			Type: "/cosmos.upgrade.v1beta1.MsgSoftwareUpgrade",
+			Data: zetachain.MessageData{Plan: zetachain.UpgradePlan{Name: "v12", Height: "1200000"}},
+		}}))
+
+		params, err := client.GetParams("devnet")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requests).NotTo(HaveKey("/cosmos.gov.v1.Query/Params"))
+		Expect(params.VotingPeriod).To(Equal(zetachain.Duration(48 * time.Hour)))
+		Expect(params.Quorum).To(Equal("0.334000000000000000"))
+		Expect(params.Threshold).To(Equal("0.500000000000000000"))
+	})
+
+	It("should report an unreachable node as unavailable", func() {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		address := listener.Addr().String()
+		Expect(listener.Close()).To(Succeed())
+
+		client = zetachain.NewGRPCClient(&config.Config{
+			Networks: map[string]config.Network{
+				"mainnet": {Transport: config.TransportGRPC, GRPC: config.GRPC{Address: address}},
+			},
+		}, nil)
+
+		_, err = client.GetProposals("mainnet", zetachain.ProposalsQuery{})
+		Expect(errors.Is(err, zetachain.ErrEndpointsUnavailable)).To(BeTrue())
+	})
+})
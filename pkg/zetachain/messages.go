@@ -0,0 +1,363 @@
+package zetachain
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MessageField is a key field of a proposal message, prepared for display
+type MessageField struct {
+	Name  string
+	Value string
+
+	// Coins holds the value of amount fields, to be formatted in the display denomination
+	Coins []Deposit
+}
+
+// MessageDecoder extracts the key fields of a proposal message from its JSON
+type MessageDecoder func(raw json.RawMessage) ([]MessageField, error)
+
+// maxFieldLength bounds the length of field values, e.g. of contract bytecode or large params
+const maxFieldLength = 200
+
+var (
+	decodersMu sync.RWMutex
+
+	// messageDecoders holds the decoders by type URL. A key of the form "*.MsgName" matches
+	// messages of that name in any module, e.g. "*.MsgUpdateParams".
+	messageDecoders = map[string]MessageDecoder{
+		"*.MsgUpdateParams": decodeUpdateParams,
+		"/cosmos.distribution.v1beta1.MsgCommunityPoolSpend":      fields(field("Recipient", "recipient"), coinsField("Amount", "amount")),
+		"/cosmos.distribution.v1beta1.CommunityPoolSpendProposal": fields(field("Recipient", "recipient"), coinsField("Amount", "amount")),
+		"/cosmos.params.v1beta1.ParameterChangeProposal":          decodeParameterChanges,
+		"/ibc.core.client.v1.MsgIBCSoftwareUpgrade":               fields(field("Upgrade", "plan.name"), field("Height", "plan.height"), field("Upgraded client", "upgraded_client_state.@type"), field("Chain ID", "upgraded_client_state.chain_id")),
+		"/ibc.core.client.v1.UpgradeProposal":                     fields(field("Upgrade", "plan.name"), field("Height", "plan.height"), field("Upgraded client", "upgraded_client_state.@type"), field("Chain ID", "upgraded_client_state.chain_id")),
+		"/ibc.core.client.v1.MsgRecoverClient":                    fields(field("Subject client", "subject_client_id"), field("Substitute client", "substitute_client_id")),
+		"/ibc.core.client.v1.ClientUpdateProposal":                fields(field("Subject client", "subject_client_id"), field("Substitute client", "substitute_client_id")),
+
+		"/zetachain.zetacore.authority.MsgUpdatePolicies":      fields(field("Policies", "policies.items")),
+		"/zetachain.zetacore.authority.MsgUpdateChainInfo":     fields(field("Chain", "chain")),
+		"/zetachain.zetacore.authority.MsgAddAuthorization":    fields(field("Message", "msg_url"), field("Policy", "authorized_policy")),
+		"/zetachain.zetacore.authority.MsgRemoveAuthorization": fields(field("Message", "msg_url")),
+
+		"/zetachain.zetacore.observer.MsgUpdateChainParams":           fields(field("Chain ID", "chainParams.chain_id"), field("Chain params", "chainParams")),
+		"/zetachain.zetacore.observer.MsgRemoveChainParams":           fields(field("Chain ID", "chain_id")),
+		"/zetachain.zetacore.observer.MsgAddObserver":                 fields(field("Observer", "observer_address"), field("Node account only", "add_node_account_only")),
+		"/zetachain.zetacore.observer.MsgUpdateObserver":              fields(field("Old observer", "old_observer_address"), field("New observer", "new_observer_address"), field("Reason", "update_reason")),
+		"/zetachain.zetacore.observer.MsgUpdateKeygen":                fields(field("Keygen block", "block")),
+		"/zetachain.zetacore.observer.MsgResetChainNonces":            fields(field("Chain ID", "chain_id"), field("Nonce low", "chain_nonce_low"), field("Nonce high", "chain_nonce_high")),
+		"/zetachain.zetacore.observer.MsgEnableCCTX":                  fields(field("Inbound", "enableInbound"), field("Outbound", "enableOutbound")),
+		"/zetachain.zetacore.observer.MsgDisableCCTX":                 fields(field("Inbound", "disableInbound"), field("Outbound", "disableOutbound")),
+		"/zetachain.zetacore.observer.MsgUpdateGasPriceIncreaseFlags": fields(field("Flags", "gasPriceIncreaseFlags")),
+		"/zetachain.zetacore.observer.MsgUpdateOperationalFlags":      fields(field("Flags", "operational_flags")),
+
+		"/zetachain.zetacore.crosschain.MsgWhitelistERC20":         fields(field("Chain ID", "chain_id"), field("ERC20", "erc20_address"), field("Name", "name"), field("Symbol", "symbol"), field("Decimals", "decimals"), field("Liquidity cap", "liquidity_cap")),
+		"/zetachain.zetacore.crosschain.MsgMigrateTssFunds":        fields(field("Chain ID", "chain_id"), field("Amount", "amount")),
+		"/zetachain.zetacore.crosschain.MsgUpdateTssAddress":       fields(field("TSS public key", "tss_pubkey")),
+		"/zetachain.zetacore.crosschain.MsgRefundAbortedCCTX":      fields(field("CCTX", "cctx_index"), field("Refund address", "refund_address")),
+		"/zetachain.zetacore.crosschain.MsgUpdateRateLimiterFlags": fields(field("Flags", "rate_limiter_flags")),
+
+		"/zetachain.zetacore.fungible.MsgUpdateZRC20LiquidityCap": fields(field("ZRC20", "zrc20_address"), field("Liquidity cap", "liquidity_cap")),
+		"/zetachain.zetacore.fungible.MsgPauseZRC20":              fields(field("ZRC20", "zrc20_addresses")),
+		"/zetachain.zetacore.fungible.MsgUnpauseZRC20":            fields(field("ZRC20", "zrc20_addresses")),
+		"/zetachain.zetacore.fungible.MsgUpdateSystemContract":    fields(field("System contract", "new_system_contract_address")),
+	}
+)
+
+func init() {
+	// Registered here as it decodes the wrapped content through the registry
+	messageDecoders["/cosmos.gov.v1.MsgExecLegacyContent"] = decodeExecLegacyContent
+}
+
+// RegisterMessageDecoder registers the decoder of a message type, replacing any previous one.
+// typeURL is the full type URL, or "*.MsgName" to match a message name in any module.
+func RegisterMessageDecoder(typeURL string, decoder MessageDecoder) {
+	decodersMu.Lock()
+	defer decodersMu.Unlock()
+
+	messageDecoders[typeURL] = decoder
+}
+
+// messageDecoder returns the decoder of a message type, if any
+func messageDecoder(typeURL string) (MessageDecoder, bool) {
+	decodersMu.RLock()
+	defer decodersMu.RUnlock()
+
+	if decoder, ok := messageDecoders[typeURL]; ok {
+		return decoder, true
+	}
+
+	decoder, ok := messageDecoders["*."+MessageName(typeURL)]
+
+	return decoder, ok
+}
+
+// MessageName returns the name of a message type without its package, e.g. MsgUpdateParams
+func MessageName(typeURL string) string {
+	return typeURL[strings.LastIndex(typeURL, ".")+1:]
+}
+
+// MessageModule returns the module of a message type, e.g. gov for /cosmos.gov.v1.MsgUpdateParams
+func MessageModule(typeURL string) string {
+	parts := strings.Split(strings.TrimPrefix(typeURL, "/"), ".")
+	if len(parts) < 3 {
+		return ""
+	}
+
+	// Skip the version, e.g. v1beta1, and the message name
+	parts = parts[:len(parts)-1]
+	if last := parts[len(parts)-1]; len(last) > 1 && last[0] == 'v' && last[1] >= '0' && last[1] <= '9' {
+		parts = parts[:len(parts)-1]
+	}
+
+	return parts[len(parts)-1]
+}
+
+// Fields decodes the key fields of the message. Messages without a decoder, or whose JSON
+// is not known because they were fetched over gRPC without a schema in protoSchemas, have no fields.
+func (m Message) Fields() ([]MessageField, error) {
+	decoder, ok := messageDecoder(m.Type)
+	if !ok || len(m.Raw) == 0 {
+		return nil, nil
+	}
+
+	messageFields, err := decoder(m.Raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", m.Type, err)
+	}
+
+	return messageFields, nil
+}
+
+// fieldSpec selects a field of a message by its JSON path, e.g. plan.name
+type fieldSpec struct {
+	name  string
+	path  string
+	coins bool
+}
+
+func field(name string, path string) fieldSpec {
+	return fieldSpec{name: name, path: path}
+}
+
+// coinsField selects a list of coins, which is formatted in the display denomination
+func coinsField(name string, path string) fieldSpec {
+	return fieldSpec{name: name, path: path, coins: true}
+}
+
+// fields returns a decoder that extracts the given fields, skipping those that are not set
+func fields(specs ...fieldSpec) MessageDecoder {
+	return func(raw json.RawMessage) ([]MessageField, error) {
+		var message map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &message); err != nil {
+			return nil, err
+		}
+
+		var messageFields []MessageField
+
+		for _, spec := range specs {
+			value, ok := lookup(message, spec.path)
+			if !ok {
+				continue
+			}
+
+			if spec.coins {
+				var coins []Deposit
+				if err := json.Unmarshal(value, &coins); err != nil {
+					return nil, fmt.Errorf("%s: %w", spec.path, err)
+				}
+
+				messageFields = append(messageFields, MessageField{Name: spec.name, Value: FormatCoins(coins), Coins: coins})
+
+				continue
+			}
+
+			messageFields = append(messageFields, MessageField{Name: spec.name, Value: fieldValue(value)})
+		}
+
+		return messageFields, nil
+	}
+}
+
+// lookup returns the value at a dotted path of a JSON object, unless it is null or empty
+func lookup(object map[string]json.RawMessage, path string) (json.RawMessage, bool) {
+	key, rest, nested := strings.Cut(path, ".")
+
+	value, ok := object[key]
+	if !ok {
+		return nil, false
+	}
+
+	if nested {
+		var child map[string]json.RawMessage
+		if err := json.Unmarshal(value, &child); err != nil {
+			return nil, false
+		}
+
+		return lookup(child, rest)
+	}
+
+	switch string(bytes.TrimSpace(value)) {
+	case "null", `""`, "[]", "{}":
+		return nil, false
+	}
+
+	return value, true
+}
+
+// fieldValue formats a JSON value for display: strings without quotes, other values as compact JSON
+func fieldValue(value json.RawMessage) string {
+	return truncateField(jsonValue(value))
+}
+
+// jsonValue formats a JSON value in full: strings without quotes, other values as compact JSON
+func jsonValue(value json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		return s
+	}
+
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, value); err != nil {
+		return string(value)
+	}
+
+	return compact.String()
+}
+
+func truncateField(s string) string {
+	runes := []rune(s)
+	if len(runes) <= maxFieldLength {
+		return s
+	}
+
+	return string(runes[:maxFieldLength]) + "…"
+}
+
+// FormatCoins joins coins as the templates show deposits, e.g. "1,000 ZETA, 5 uatom"
+func FormatCoins(coins []Deposit) string {
+	formatted := make([]string, len(coins))
+	for i, coin := range coins {
+		formatted[i] = coin.Amount + " " + coin.Denom
+	}
+
+	return strings.Join(formatted, ", ")
+}
+
+// decodeUpdateParams lists the params of a MsgUpdateParams of any module, in the order of their names
+func decodeUpdateParams(raw json.RawMessage) ([]MessageField, error) {
+	var message struct {
+		Params map[string]json.RawMessage `json:"params"`
+	}
+
+	if err := json.Unmarshal(raw, &message); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(message.Params))
+	for name := range message.Params {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	messageFields := make([]MessageField, 0, len(names))
+	for _, name := range names {
+		messageFields = append(messageFields, MessageField{Name: name, Value: fieldValue(message.Params[name])})
+	}
+
+	return messageFields, nil
+}
+
+// decodeExecLegacyContent describes the v1beta1 content wrapped in a MsgExecLegacyContent, with the
+// fields of the content if its type has a decoder
+func decodeExecLegacyContent(raw json.RawMessage) ([]MessageField, error) {
+	var message struct {
+		Content json.RawMessage `json:"content"`
+	}
+
+	if err := json.Unmarshal(raw, &message); err != nil {
+		return nil, err
+	}
+
+	var content Message
+	if err := json.Unmarshal(message.Content, &content); err != nil {
+		return nil, err
+	}
+
+	messageFields := []MessageField{{Name: "Content", Value: content.Type}}
+
+	contentFields, err := content.Fields()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(messageFields, contentFields...), nil
+}
+
+// decodeParameterChanges lists the changes of a legacy ParameterChangeProposal as subspace.key
+func decodeParameterChanges(raw json.RawMessage) ([]MessageField, error) {
+	var proposal struct {
+		Changes []struct {
+			Subspace string `json:"subspace"`
+			Key      string `json:"key"`
+			Value    string `json:"value"`
+		} `json:"changes"`
+	}
+
+	if err := json.Unmarshal(raw, &proposal); err != nil {
+		return nil, err
+	}
+
+	messageFields := make([]MessageField, 0, len(proposal.Changes))
+	for _, change := range proposal.Changes {
+		messageFields = append(messageFields, MessageField{
+			Name:  change.Subspace + "." + change.Key,
+			Value: truncateField(change.Value),
+		})
+	}
+
+	return messageFields, nil
+}
+
+// UnmarshalJSON decodes a message of the LCD API, which holds its type in @type next to its
+// fields, and keeps the complete JSON for its decoder
+func (m *Message) UnmarshalJSON(data []byte) error {
+	var typed struct {
+		Type string `json:"@type"`
+	}
+
+	if err := json.Unmarshal(data, &typed); err != nil {
+		return err
+	}
+
+	var messageData MessageData
+	if err := json.Unmarshal(data, &messageData); err != nil {
+		// Fields of an unexpected shape, e.g. a plan of another message type, are only a loss
+		// for the upgrade details
+		messageData = MessageData{}
+	}
+
+	m.Type = typed.Type
+	m.Data = messageData
+	m.Raw = slices.Clone(data)
+
+	return nil
+}
+
+// MarshalJSON encodes the message as the LCD API does
+func (m Message) MarshalJSON() ([]byte, error) {
+	if len(m.Raw) > 0 {
+		return m.Raw, nil
+	}
+
+	return json.Marshal(struct {
+		Type string `json:"@type"`
+		MessageData
+	}{m.Type, m.Data})
+}
@@ -0,0 +1,132 @@
+package zetachain_test
+
+import (
+	"encoding/json"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/hazim1093/zeta-comms/pkg/zetachain"
+)
+
+var _ = Describe("Message", func() {
+	// decode decodes a message as the LCD API returns it
+	decode := func(raw string) zetachain.Message {
+		var message zetachain.Message
+		Expect(json.Unmarshal([]byte(raw), &message)).To(Succeed())
+
+		return message
+	}
+
+	It("should decode the type, the authority and the upgrade plan next to each other", func() {
+		message := decode(`{
+			"@type": "/cosmos.upgrade.v1beta1.MsgSoftwareUpgrade",
+			"authority": "zeta10d07y265gmmuvt4z0w9aw880jnsr700jvxasvr",
+			"plan": {"name": "v30", "height": "5000000", "info": "binaries"}
+		}`)
+
+		Expect(message.Type).To(Equal("/cosmos.upgrade.v1beta1.MsgSoftwareUpgrade"))
+		Expect(message.Data).To(Equal(zetachain.MessageData{
+			Authority: "zeta10d07y265gmmuvt4z0w9aw880jnsr700jvxasvr",
+			Plan:      zetachain.UpgradePlan{Name: "v30", Height: "5000000", Info: "binaries"},
+		}))
+	})
+
+	It("should list the params of a MsgUpdateParams of any module", func() {
+		message := decode(`{
+			"@type": "/zetachain.zetacore.crosschain.MsgUpdateParams",
+			"authority": "zeta10d07y265gmmuvt4z0w9aw880jnsr700jvxasvr",
+			"params": {"outbound_tx_schedule_interval": "30", "enabled": true, "limits": {"max": "10"}}
+		}`)
+
+		fields, err := message.Fields()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fields).To(Equal([]zetachain.MessageField{
+			{Name: "enabled", Value: "true"},
+			{Name: "limits", Value: `{"max":"10"}`},
+			{Name: "outbound_tx_schedule_interval", Value: "30"},
+		}))
+		Expect(zetachain.MessageModule(message.Type)).To(Equal("crosschain"))
+		Expect(zetachain.MessageModule("/cosmos.gov.v1.MsgUpdateParams")).To(Equal("gov"))
+	})
+
+	It("should keep the coins of a community pool spend for display", func() {
+		message := decode(`{
+			"@type": "/cosmos.distribution.v1beta1.MsgCommunityPoolSpend",
+			"recipient": "zeta1recipient",
+			"amount": [{"denom": "azeta", "amount": "1000000000000000000000"}]
+		}`)
+
+		fields, err := message.Fields()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fields).To(HaveLen(2))
+		Expect(fields[0]).To(Equal(zetachain.MessageField{Name: "Recipient", Value: "zeta1recipient"}))
+		Expect(fields[1].Name).To(Equal("Amount"))
+		Expect(fields[1].Coins).To(Equal([]zetachain.Deposit{{Denom: "azeta", Amount: "1000000000000000000000"}}))
+	})
+
+	It("should decode the content of a MsgExecLegacyContent", func() {
+		message := decode(`{
+			"@type": "/cosmos.gov.v1.MsgExecLegacyContent",
+			"content": {
+				"@type": "/cosmos.params.v1beta1.ParameterChangeProposal",
+				"title": "Raise max validators",
+				"changes": [{"subspace": "staking", "key": "MaxValidators", "value": "150"}]
+			},
+			"authority": "zeta10d07y265gmmuvt4z0w9aw880jnsr700jvxasvr"
+		}`)
+
+		fields, err := message.Fields()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fields).To(Equal([]zetachain.MessageField{
+			{Name: "Content", Value: "/cosmos.params.v1beta1.ParameterChangeProposal"},
+			{Name: "staking.MaxValidators", Value: "150"},
+		}))
+	})
+
+	It("should decode ZetaChain messages, skipping unset fields and cutting long values", func() {
+		message := decode(`{
+			"@type": "/zetachain.zetacore.observer.MsgUpdateChainParams",
+			"creator": "zeta1creator",
+			"chainParams": {"chain_id": "1", "confirmation_count": "14", "gateway_address": "` + strings.Repeat("f", 300) + `"}
+		}`)
+
+		fields, err := message.Fields()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fields).To(HaveLen(2))
+		Expect(fields[0]).To(Equal(zetachain.MessageField{Name: "Chain ID", Value: "1"}))
+		Expect(fields[1].Name).To(Equal("Chain params"))
+		Expect([]rune(fields[1].Value)).To(HaveLen(201))
+		Expect(fields[1].Value).To(HaveSuffix("…"))
+
+		message = decode(`{"@type": "/zetachain.zetacore.fungible.MsgPauseZRC20", "creator": "zeta1creator", "zrc20_addresses": []}`)
+
+		fields, err = message.Fields()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fields).To(BeEmpty())
+	})
+
+	It("should use registered decoders and list unknown messages without fields", func() {
+		message := decode(`{"@type": "/example.custom.v1.MsgSetLimit", "limit": "5"}`)
+
+		fields, err := message.Fields()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fields).To(BeEmpty())
+
+		zetachain.RegisterMessageDecoder("*.MsgSetLimit", func(raw json.RawMessage) ([]zetachain.MessageField, error) {
+			return []zetachain.MessageField{{Name: "Limit", Value: "custom"}}, nil
+		})
+
+		fields, err = message.Fields()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fields).To(Equal([]zetachain.MessageField{{Name: "Limit", Value: "custom"}}))
+	})
+
+	It("should report messages of an unexpected shape", func() {
+		message := decode(`{"@type": "/cosmos.distribution.v1beta1.MsgCommunityPoolSpend", "amount": "1000azeta"}`)
+
+		_, err := message.Fields()
+		Expect(err).To(MatchError(ContainSubstring("MsgCommunityPoolSpend")))
+	})
+})
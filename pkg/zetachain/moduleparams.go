@@ -0,0 +1,118 @@
+package zetachain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hazim1093/zeta-comms/internal/config"
+)
+
+// ErrUnsupported is returned for queries that a transport cannot serve
+var ErrUnsupported = errors.New("query not supported by the transport")
+
+// MessagePackage returns the protobuf package of a message type, e.g. cosmos.gov.v1 for
+// /cosmos.gov.v1.MsgUpdateParams
+func MessagePackage(typeURL string) string {
+	typeURL = strings.TrimPrefix(typeURL, "/")
+	if i := strings.LastIndex(typeURL, "."); i >= 0 {
+		return typeURL[:i]
+	}
+
+	return ""
+}
+
+// moduleParamsPath returns the LCD path of the params of a module, given by its protobuf package
+func moduleParamsPath(module string) string {
+	switch {
+	case strings.HasPrefix(module, "zetachain.zetacore."):
+		return "/zeta-chain/" + strings.TrimPrefix(module, "zetachain.zetacore.") + "/params"
+	case module == "cosmos.gov.v1":
+		// The gov v1 API serves all params for any params type
+		return govPath(config.GovV1, "/params/tallying")
+	default:
+		return "/" + strings.ReplaceAll(module, ".", "/") + "/params"
+	}
+}
+
+// moduleParamsResponse is the response of the params endpoint of a module
+type moduleParamsResponse struct {
+	Params json.RawMessage `json:"params"`
+}
+
+// ParamChange is a param that a MsgUpdateParams changes. Old is empty for params the module does
+// not have yet and New for params the message leaves out.
+type ParamChange struct {
+	Name string
+	Old  string
+	New  string
+}
+
+// DiffParams compares the current params of a module to the params of a MsgUpdateParams. Nested
+// params are compared one by one and named by their path, e.g. block.max_gas, lists as a whole.
+// Values are compared in full and cut for display afterwards.
+func DiffParams(current json.RawMessage, proposed json.RawMessage) ([]ParamChange, error) {
+	oldValues := make(map[string]string)
+	if err := flattenParams(current, "", oldValues); err != nil {
+		return nil, fmt.Errorf("current params: %w", err)
+	}
+
+	newValues := make(map[string]string)
+	if err := flattenParams(proposed, "", newValues); err != nil {
+		return nil, fmt.Errorf("proposed params: %w", err)
+	}
+
+	names := make([]string, 0, len(newValues))
+	for name := range newValues {
+		names = append(names, name)
+	}
+
+	for name := range oldValues {
+		if _, ok := newValues[name]; !ok {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	var changes []ParamChange
+
+	for _, name := range names {
+		if oldValues[name] != newValues[name] {
+			changes = append(changes, ParamChange{
+				Name: name,
+				Old:  truncateField(oldValues[name]),
+				New:  truncateField(newValues[name]),
+			})
+		}
+	}
+
+	return changes, nil
+}
+
+// flattenParams adds the values of a params object to values, keyed by their dotted path
+func flattenParams(raw json.RawMessage, prefix string, values map[string]string) error {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return err
+	}
+
+	for key, value := range object {
+		name := prefix + key
+
+		var nested map[string]json.RawMessage
+		if json.Unmarshal(value, &nested) == nil && len(nested) > 0 {
+			if err := flattenParams(value, name+".", values); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		values[name] = jsonValue(value)
+	}
+
+	return nil
+}
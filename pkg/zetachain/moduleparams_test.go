@@ -0,0 +1,86 @@
+package zetachain_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/hazim1093/zeta-comms/internal/config"
+	"github.com/hazim1093/zeta-comms/pkg/zetachain"
+)
+
+var _ = Describe("Module params", func() {
+	It("should diff nested params by path, including added and removed ones", func() {
+		current := json.RawMessage(`{
+			"max_validators": 100,
+			"bond_denom": "azeta",
+			"block": {"max_gas": "10000000", "max_bytes": "22020096"},
+			"allowed_clients": ["07-tendermint"],
+			"legacy_flag": true
+		}`)
+		proposed := json.RawMessage(`{
+			"max_validators": 150,
+			"bond_denom": "azeta",
+			"block": {"max_gas": "20000000", "max_bytes": "22020096"},
+			"allowed_clients": ["07-tendermint", "08-wasm"],
+			"min_commission_rate": "0.050000000000000000"
+		}`)
+
+		changes, err := zetachain.DiffParams(current, proposed)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changes).To(Equal([]zetachain.ParamChange{
+			{Name: "allowed_clients", Old: `["07-tendermint"]`, New: `["07-tendermint","08-wasm"]`},
+			{Name: "block.max_gas", Old: "10000000", New: "20000000"},
+			{Name: "legacy_flag", Old: "true", New: ""},
+			{Name: "max_validators", Old: "100", New: "150"},
+			{Name: "min_commission_rate", Old: "", New: "0.050000000000000000"},
+		}))
+	})
+
+	It("should query the params endpoint of the module over the LCD API", func() {
+		var paths []string
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			paths = append(paths, r.URL.Path)
+
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"params": {"enabled": true}}`))
+		}))
+		DeferCleanup(server.Close)
+
+		serverURL, _ := url.Parse(server.URL)
+		client := zetachain.NewClient(&config.Config{
+			Networks: map[string]config.Network{
+				"mainnet": {ApiUrl: *serverURL},
+				"devnet":  {Transport: config.TransportGRPC, GRPC: config.GRPC{Address: "127.0.0.1:1"}},
+				"testnet": {Transport: config.TransportGRPC, GRPC: config.GRPC{Address: "127.0.0.1:1"}, ApiUrl: *serverURL},
+			},
+		}, nil)
+
+		for _, module := range []string{"zetachain.zetacore.crosschain", "cosmos.staking.v1beta1", "cosmos.gov.v1"} {
+			params, err := client.GetModuleParams("mainnet", module)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(params)).To(Equal(`{"enabled": true}`))
+		}
+
+		Expect(paths).To(Equal([]string{
+			"/zeta-chain/crosschain/params",
+			"/cosmos/staking/v1beta1/params",
+			"/cosmos/gov/v1/params/tallying",
+		}))
+		Expect(zetachain.MessagePackage("/cosmos.staking.v1beta1.MsgUpdateParams")).To(Equal("cosmos.staking.v1beta1"))
+
+		_, err := client.GetModuleParams("devnet", "zetachain.zetacore.crosschain")
+		Expect(errors.Is(err, zetachain.ErrUnsupported)).To(BeTrue())
+
+		// gRPC networks fall back to their LCD endpoints for modules the gRPC client does not decode
+		params, err := client.GetModuleParams("testnet", "zetachain.zetacore.crosschain")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(params)).To(Equal(`{"enabled": true}`))
+	})
+})
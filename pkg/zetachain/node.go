@@ -0,0 +1,169 @@
+package zetachain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hazim1093/zeta-comms/internal/config"
+)
+
+// Paths of the node status endpoints of the LCD API and the CometBFT RPC
+const (
+	nodeInfoPath    = "/cosmos/base/tendermint/v1beta1/node_info"
+	syncingPath     = "/cosmos/base/tendermint/v1beta1/syncing"
+	rpcStatusPath   = "/status"
+	rpcABCIInfoPath = "/abci_info"
+)
+
+// NodeStatus is the version and sync state reported by a node
+type NodeStatus struct {
+	ChainID   string
+	Moniker   string
+	Version   string // Version of the application, e.g. v30.0.0
+	Height    int64
+	BlockTime time.Time
+	Syncing   bool // The node is catching up with the network
+}
+
+// NodeClient queries the status of single nodes, as opposed to the load balanced endpoints of a network
+type NodeClient struct {
+	restyClient *resty.Client
+}
+
+func NewNodeClient() *NodeClient {
+	return &NodeClient{
+		restyClient: resty.New().
+			SetHeader("Content-Type", "application/json").
+			SetTimeout(10 * time.Second),
+	}
+}
+
+// GetNodeStatus queries a node over its LCD API, or its CometBFT RPC if it has no LCD API
+func (c *NodeClient) GetNodeStatus(node config.Node) (*NodeStatus, error) {
+	if node.ApiUrl.String() != "" {
+		return c.lcdStatus(strings.TrimSuffix(node.ApiUrl.String(), "/"))
+	}
+
+	return c.rpcStatus(strings.TrimSuffix(node.RPCURL.String(), "/"))
+}
+
+type nodeInfoResponse struct {
+	DefaultNodeInfo struct {
+		Network string `json:"network"`
+		Moniker string `json:"moniker"`
+	} `json:"default_node_info"`
+	ApplicationVersion struct {
+		Version string `json:"version"`
+	} `json:"application_version"`
+}
+
+type syncingResponse struct {
+	Syncing bool `json:"syncing"`
+}
+
+func (c *NodeClient) lcdStatus(baseURL string) (*NodeStatus, error) {
+	var (
+		nodeInfo nodeInfoResponse
+		syncing  syncingResponse
+		block    LatestBlockResponse
+	)
+
+	queries := []struct {
+		path   string
+		result any
+	}{
+		{nodeInfoPath, &nodeInfo},
+		{syncingPath, &syncing},
+		{latestBlockPath, &block},
+	}
+
+	for _, query := range queries {
+		if err := c.get(baseURL+query.path, query.result); err != nil {
+			return nil, err
+		}
+	}
+
+	height, err := strconv.ParseInt(block.Block.Header.Height, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid block height %q", block.Block.Header.Height)
+	}
+
+	return &NodeStatus{
+		ChainID:   nodeInfo.DefaultNodeInfo.Network,
+		Moniker:   nodeInfo.DefaultNodeInfo.Moniker,
+		Version:   nodeInfo.ApplicationVersion.Version,
+		Height:    height,
+		BlockTime: block.Block.Header.Time,
+		Syncing:   syncing.Syncing,
+	}, nil
+}
+
+type rpcStatusResponse struct {
+	Result struct {
+		NodeInfo struct {
+			Network string `json:"network"`
+			Moniker string `json:"moniker"`
+		} `json:"node_info"`
+		SyncInfo struct {
+			LatestBlockHeight string    `json:"latest_block_height"`
+			LatestBlockTime   time.Time `json:"latest_block_time"`
+			CatchingUp        bool      `json:"catching_up"`
+		} `json:"sync_info"`
+	} `json:"result"`
+}
+
+type abciInfoResponse struct {
+	Result struct {
+		Response struct {
+			Version string `json:"version"`
+		} `json:"response"`
+	} `json:"result"`
+}
+
+// rpcStatus queries the CometBFT RPC, which reports the application version through ABCI info
+func (c *NodeClient) rpcStatus(baseURL string) (*NodeStatus, error) {
+	var (
+		status   rpcStatusResponse
+		abciInfo abciInfoResponse
+	)
+
+	if err := c.get(baseURL+rpcStatusPath, &status); err != nil {
+		return nil, err
+	}
+
+	if err := c.get(baseURL+rpcABCIInfoPath, &abciInfo); err != nil {
+		return nil, err
+	}
+
+	syncInfo := status.Result.SyncInfo
+
+	height, err := strconv.ParseInt(syncInfo.LatestBlockHeight, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid block height %q", syncInfo.LatestBlockHeight)
+	}
+
+	return &NodeStatus{
+		ChainID:   status.Result.NodeInfo.Network,
+		Moniker:   status.Result.NodeInfo.Moniker,
+		Version:   abciInfo.Result.Response.Version,
+		Height:    height,
+		BlockTime: syncInfo.LatestBlockTime,
+		Syncing:   syncInfo.CatchingUp,
+	}, nil
+}
+
+func (c *NodeClient) get(url string, result any) error {
+	resp, err := c.restyClient.R().SetResult(result).Get(url)
+	if err != nil {
+		return err
+	}
+
+	if resp.IsError() {
+		return &StatusError{StatusCode: resp.StatusCode()}
+	}
+
+	return nil
+}
@@ -0,0 +1,646 @@
+package zetachain
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hazim1093/zeta-comms/internal/config"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The gRPC client encodes and decodes the few Cosmos SDK messages it uses by hand, following the
+// field numbers of the .proto files, which spares the dependency on the SDK's generated code.
+
+// rawCodec passes encoded protobuf messages through unchanged. It is named "proto" so that
+// nodes accept the requests as regular protobuf requests.
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v any) ([]byte, error) {
+	message, ok := v.(*[]byte)
+	if !ok {
+		return nil, fmt.Errorf("unexpected message type %T", v)
+	}
+
+	return *message, nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v any) error {
+	message, ok := v.(*[]byte)
+	if !ok {
+		return fmt.Errorf("unexpected message type %T", v)
+	}
+
+	*message = append((*message)[:0], data...)
+
+	return nil
+}
+
+func (rawCodec) Name() string {
+	return "proto"
+}
+
+// protoEncoder builds an encoded protobuf message. Fields with zero values are left out, as in proto3.
+type protoEncoder struct {
+	b []byte
+}
+
+func (e *protoEncoder) uint(num protowire.Number, v uint64) {
+	if v == 0 {
+		return
+	}
+
+	e.b = protowire.AppendTag(e.b, num, protowire.VarintType)
+	e.b = protowire.AppendVarint(e.b, v)
+}
+
+func (e *protoEncoder) bool(num protowire.Number, v bool) {
+	if v {
+		e.uint(num, 1)
+	}
+}
+
+func (e *protoEncoder) bytes(num protowire.Number, v []byte) {
+	if len(v) == 0 {
+		return
+	}
+
+	e.b = protowire.AppendTag(e.b, num, protowire.BytesType)
+	e.b = protowire.AppendBytes(e.b, v)
+}
+
+func (e *protoEncoder) string(num protowire.Number, v string) {
+	e.bytes(num, []byte(v))
+}
+
+// pageRequest encodes a cosmos.base.query.v1beta1.PageRequest
+func pageRequest(key []byte, limit uint64, reverse bool) []byte {
+	var page protoEncoder
+	page.bytes(1, key)
+	page.uint(3, limit)
+	page.bool(5, reverse)
+
+	return page.b
+}
+
+// protoFields calls field for every varint and length-delimited field of an encoded message, with
+// the value of varint fields in v and the content of length-delimited fields in b. Fields of other
+// wire types are skipped.
+func protoFields(data []byte, field func(num protowire.Number, v uint64, b []byte) error) error {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+
+		data = data[n:]
+
+		var (
+			v uint64
+			b []byte
+		)
+
+		switch typ {
+		case protowire.VarintType:
+			v, n = protowire.ConsumeVarint(data)
+		case protowire.BytesType:
+			b, n = protowire.ConsumeBytes(data)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, data)
+		}
+
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+
+		data = data[n:]
+
+		if typ != protowire.VarintType && typ != protowire.BytesType {
+			continue
+		}
+
+		if err := field(num, v, b); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Names of the cosmos.gov.v1.ProposalStatus and VoteOption enum values, as used by the LCD API
+var (
+	proposalStatuses = []string{
+		"PROPOSAL_STATUS_UNSPECIFIED",
+		"PROPOSAL_STATUS_DEPOSIT_PERIOD",
+		"PROPOSAL_STATUS_VOTING_PERIOD",
+		"PROPOSAL_STATUS_PASSED",
+		"PROPOSAL_STATUS_REJECTED",
+		"PROPOSAL_STATUS_FAILED",
+	}
+
+	voteOptions = []string{
+		"VOTE_OPTION_UNSPECIFIED",
+		"VOTE_OPTION_YES",
+		"VOTE_OPTION_ABSTAIN",
+		"VOTE_OPTION_NO",
+		"VOTE_OPTION_NO_WITH_VETO",
+	}
+)
+
+func enumName(names []string, v uint64) string {
+	if v < uint64(len(names)) {
+		return names[v]
+	}
+
+	return strconv.FormatUint(v, 10)
+}
+
+// decodeTimestamp decodes a google.protobuf.Timestamp
+func decodeTimestamp(data []byte) (time.Time, error) {
+	var seconds, nanos uint64
+
+	err := protoFields(data, func(num protowire.Number, v uint64, _ []byte) error {
+		switch num {
+		case 1:
+			seconds = v
+		case 2:
+			nanos = v
+		}
+
+		return nil
+	})
+
+	return time.Unix(int64(seconds), int64(int32(nanos))).UTC(), err
+}
+
+// decodeDuration decodes a google.protobuf.Duration
+func decodeDuration(data []byte) (Duration, error) {
+	var seconds, nanos uint64
+
+	err := protoFields(data, func(num protowire.Number, v uint64, _ []byte) error {
+		switch num {
+		case 1:
+			seconds = v
+		case 2:
+			nanos = v
+		}
+
+		return nil
+	})
+
+	return Duration(time.Duration(int64(seconds))*time.Second + time.Duration(int32(nanos))), err
+}
+
+// decodeCoin decodes a cosmos.base.v1beta1.Coin
+func decodeCoin(data []byte) (Deposit, error) {
+	var coin Deposit
+
+	err := protoFields(data, func(num protowire.Number, _ uint64, b []byte) error {
+		switch num {
+		case 1:
+			coin.Denom = string(b)
+		case 2:
+			coin.Amount = string(b)
+		}
+
+		return nil
+	})
+
+	return coin, err
+}
+
+// decodePagination decodes a cosmos.base.query.v1beta1.PageResponse
+func decodePagination(data []byte) (Pagination, error) {
+	var pagination Pagination
+
+	err := protoFields(data, func(num protowire.Number, v uint64, b []byte) error {
+		switch num {
+		case 1:
+			pagination.NextKey = base64.StdEncoding.EncodeToString(b)
+		case 2:
+			pagination.Total = strconv.FormatUint(v, 10)
+		}
+
+		return nil
+	})
+
+	return pagination, err
+}
+
+// decodeTally decodes a cosmos.gov.v1.TallyResult
+func decodeTally(data []byte) (TallyResult, error) {
+	var tally TallyResult
+
+	err := protoFields(data, func(num protowire.Number, _ uint64, b []byte) error {
+		switch num {
+		case 1:
+			tally.YesCount = string(b)
+		case 2:
+			tally.AbstainCount = string(b)
+		case 3:
+			tally.NoCount = string(b)
+		case 4:
+			tally.NoWithVetoCount = string(b)
+		}
+
+		return nil
+	})
+
+	return tally, err
+}
+
+// decodeProposal decodes a cosmos.gov.v1.Proposal
+func decodeProposal(data []byte) (Proposal, error) {
+	var proposal Proposal
+
+	err := protoFields(data, func(num protowire.Number, v uint64, b []byte) error {
+		var err error
+
+		switch num {
+		case 1:
+			proposal.ProposalId = strconv.FormatUint(v, 10)
+		case 2:
+			var message Message
+			message, err = decodeAny(b)
+			proposal.Messages = append(proposal.Messages, message)
+		case 3:
+			proposal.Status = enumName(proposalStatuses, v)
+		case 4:
+			proposal.FinalTallyResult, err = decodeTally(b)
+		case 5:
+			proposal.SubmitTime, err = decodeTimestamp(b)
+		case 6:
+			proposal.DepositEndTime, err = decodeTimestamp(b)
+		case 7:
+			var coin Deposit
+			coin, err = decodeCoin(b)
+			proposal.TotalDeposit = append(proposal.TotalDeposit, coin)
+		case 8:
+			proposal.VotingStartTime, err = decodeTimestamp(b)
+		case 9:
+			proposal.VotingEndTime, err = decodeTimestamp(b)
+		case 10:
+			proposal.Metadata = string(b)
+		case 11:
+			proposal.Title = string(b)
+		case 12:
+			proposal.Summary = string(b)
+		case 14:
+			proposal.Expedited = v != 0
+		case 15:
+			proposal.FailedReason = string(b)
+		}
+
+		return err
+	})
+
+	return proposal, err
+}
+
+// decodeProposalOf decodes a proposal of the given gov API version
+func decodeProposalOf(version string, data []byte) (Proposal, error) {
+	if version == config.GovV1Beta1 {
+		return decodeProposalV1Beta1(data)
+	}
+
+	return decodeProposal(data)
+}
+
+// decodeProposalV1Beta1 decodes a cosmos.gov.v1beta1.Proposal. Apart from the content in field 2,
+// it numbers its fields like cosmos.gov.v1.Proposal.
+func decodeProposalV1Beta1(data []byte) (Proposal, error) {
+	proposal, err := decodeProposal(data)
+	if err != nil {
+		return proposal, err
+	}
+
+	proposal.Messages = nil
+
+	err = protoFields(data, func(num protowire.Number, _ uint64, b []byte) error {
+		if num != 2 {
+			return nil
+		}
+
+		var (
+			contentType string
+			value       []byte
+			plan        UpgradePlan
+		)
+
+		err := protoFields(b, func(num protowire.Number, _ uint64, b []byte) error {
+			switch num {
+			case 1:
+				contentType = string(b)
+			case 2:
+				value = b
+			}
+
+			return nil
+		})
+
+		if err != nil {
+			return err
+		}
+
+		// Contents start with a title and a description, software upgrades add a plan
+		err = protoFields(value, func(num protowire.Number, _ uint64, b []byte) error {
+			var err error
+
+			switch num {
+			case 1:
+				proposal.Title = string(b)
+			case 2:
+				proposal.Summary = string(b)
+			case 3:
+				plan, err = decodePlan(b)
+			}
+
+			return err
+		})
+
+		if err != nil {
+			return err
+		}
+
+		// Contents with a schema are converted to JSON, from which their fields are decoded
+		raw, _, err := protoJSON(contentType, value)
+		proposal.Messages = append(proposal.Messages, contentMessage(contentType, plan, raw))
+
+		return err
+	})
+
+	return proposal, err
+}
+
+// softwareUpgradeType is the type URL of the message that schedules an upgrade
+const softwareUpgradeType = "/cosmos.upgrade.v1beta1.MsgSoftwareUpgrade"
+
+// decodeAny decodes a proposal message packed in a google.protobuf.Any. Messages with a schema in
+// protoSchemas are converted to the JSON of the LCD API, other messages are identified by their type.
+func decodeAny(data []byte) (Message, error) {
+	var (
+		message Message
+		value   []byte
+	)
+
+	err := protoFields(data, func(num protowire.Number, _ uint64, b []byte) error {
+		switch num {
+		case 1:
+			message.Type = string(b)
+		case 2:
+			value = b
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return message, err
+	}
+
+	raw, ok, err := protoJSON(message.Type, value)
+	if !ok || err != nil {
+		return message, err
+	}
+
+	err = message.UnmarshalJSON(raw)
+
+	return message, err
+}
+
+// decodePlan decodes a cosmos.upgrade.v1beta1.Plan
+func decodePlan(data []byte) (UpgradePlan, error) {
+	var plan UpgradePlan
+
+	err := protoFields(data, func(num protowire.Number, v uint64, b []byte) error {
+		switch num {
+		case 1:
+			plan.Name = string(b)
+		case 3:
+			plan.Height = strconv.FormatInt(int64(v), 10)
+		case 4:
+			plan.Info = string(b)
+		}
+
+		return nil
+	})
+
+	return plan, err
+}
+
+// decodeVote decodes a cosmos.gov.v1.Vote, or a cosmos.gov.v1beta1.Vote if legacy is set
+func decodeVote(data []byte, legacy bool) (Vote, error) {
+	var (
+		vote   Vote
+		option string // The single option of v1beta1 votes before weighted votes
+	)
+
+	err := protoFields(data, func(num protowire.Number, v uint64, b []byte) error {
+		switch num {
+		case 1:
+			vote.ProposalId = strconv.FormatUint(v, 10)
+		case 2:
+			vote.Voter = string(b)
+		case 3:
+			if legacy {
+				option = enumName(voteOptions, v)
+			}
+		case 4:
+			var option WeightedVoteOption
+
+			err := protoFields(b, func(num protowire.Number, v uint64, b []byte) error {
+				switch num {
+				case 1:
+					option.Option = enumName(voteOptions, v)
+				case 2:
+					option.Weight = string(b)
+					if legacy {
+						option.Weight = legacyDec(b)
+					}
+				}
+
+				return nil
+			})
+
+			vote.Options = append(vote.Options, option)
+
+			return err
+		case 5:
+			vote.Metadata = string(b)
+		}
+
+		return nil
+	})
+
+	if len(vote.Options) == 0 && option != "" {
+		vote.Options = []WeightedVoteOption{{Option: option, Weight: "1.000000000000000000"}}
+	}
+
+	return vote, err
+}
+
+// decodeParams decodes a cosmos.gov.v1.Params
+func decodeParams(data []byte) (Params, error) {
+	var params Params
+
+	err := protoFields(data, func(num protowire.Number, _ uint64, b []byte) error {
+		var err error
+
+		switch num {
+		case 1:
+			var coin Deposit
+			coin, err = decodeCoin(b)
+			params.MinDeposit = append(params.MinDeposit, coin)
+		case 2:
+			params.MaxDepositPeriod, err = decodeDuration(b)
+		case 3:
+			params.VotingPeriod, err = decodeDuration(b)
+		case 4:
+			params.Quorum = string(b)
+		case 5:
+			params.Threshold = string(b)
+		case 6:
+			params.VetoThreshold = string(b)
+		case 7:
+			params.MinInitialDepositRatio = string(b)
+		case 10:
+			params.ExpeditedVotingPeriod, err = decodeDuration(b)
+		case 11:
+			params.ExpeditedThreshold = string(b)
+		case 12:
+			var coin Deposit
+			coin, err = decodeCoin(b)
+			params.ExpeditedMinDeposit = append(params.ExpeditedMinDeposit, coin)
+		}
+
+		return err
+	})
+
+	return params, err
+}
+
+// decodeLegacyParams decodes a field of a cosmos.gov.v1beta1.QueryParamsResponse into params. The
+// response holds the voting (1), deposit (2) or tally params (3), depending on the requested type.
+func decodeLegacyParams(num protowire.Number, data []byte, params *Params) error {
+	return protoFields(data, func(field protowire.Number, _ uint64, b []byte) error {
+		var err error
+
+		switch {
+		case num == 1 && field == 1:
+			params.VotingPeriod, err = decodeDuration(b)
+		case num == 2 && field == 1:
+			var coin Deposit
+			coin, err = decodeCoin(b)
+			params.MinDeposit = append(params.MinDeposit, coin)
+		case num == 2 && field == 2:
+			params.MaxDepositPeriod, err = decodeDuration(b)
+		case num == 3 && field == 1:
+			params.Quorum = legacyDec(b)
+		case num == 3 && field == 2:
+			params.Threshold = legacyDec(b)
+		case num == 3 && field == 3:
+			params.VetoThreshold = legacyDec(b)
+		}
+
+		return err
+	})
+}
+
+// legacyDecPrecision is the number of decimal places of the SDK's legacy decimals
+const legacyDecPrecision = 18
+
+// legacyDec formats a legacy decimal as encoded in protobuf, an integer scaled by 10^18, like
+// the LCD API does, e.g. 334000000000000000 as 0.334000000000000000
+func legacyDec(data []byte) string {
+	digits := strings.TrimPrefix(string(data), "-")
+	if digits == "" {
+		return ""
+	}
+
+	if len(digits) <= legacyDecPrecision {
+		digits = strings.Repeat("0", legacyDecPrecision-len(digits)+1) + digits
+	}
+
+	sign := ""
+	if strings.HasPrefix(string(data), "-") {
+		sign = "-"
+	}
+
+	point := len(digits) - legacyDecPrecision
+
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// decodeDenomMetadata decodes a cosmos.bank.v1beta1.Metadata
+func decodeDenomMetadata(data []byte) (DenomMetadata, error) {
+	var metadata DenomMetadata
+
+	err := protoFields(data, func(num protowire.Number, _ uint64, b []byte) error {
+		switch num {
+		case 1:
+			metadata.Description = string(b)
+		case 2:
+			var unit DenomUnit
+
+			err := protoFields(b, func(num protowire.Number, v uint64, b []byte) error {
+				switch num {
+				case 1:
+					unit.Denom = string(b)
+				case 2:
+					unit.Exponent = int(v)
+				case 3:
+					unit.Aliases = append(unit.Aliases, string(b))
+				}
+
+				return nil
+			})
+
+			metadata.DenomUnits = append(metadata.DenomUnits, unit)
+
+			return err
+		case 3:
+			metadata.Base = string(b)
+		case 4:
+			metadata.Display = string(b)
+		case 5:
+			metadata.Name = string(b)
+		case 6:
+			metadata.Symbol = string(b)
+		}
+
+		return nil
+	})
+
+	return metadata, err
+}
+
+// decodeBlockHeader decodes the header of a tendermint.types.Block or cosmos.base.tendermint.v1beta1.Block,
+// which share the field numbers used here
+func decodeBlockHeader(block []byte) (BlockHeader, error) {
+	var header BlockHeader
+
+	err := protoFields(block, func(num protowire.Number, _ uint64, b []byte) error {
+		if num != 1 {
+			return nil
+		}
+
+		return protoFields(b, func(num protowire.Number, v uint64, b []byte) error {
+			var err error
+
+			switch num {
+			case 2:
+				header.ChainID = string(b)
+			case 3:
+				header.Height = strconv.FormatInt(int64(v), 10)
+			case 4:
+				header.Time, err = decodeTimestamp(b)
+			}
+
+			return err
+		})
+	})
+
+	return header, err
+}
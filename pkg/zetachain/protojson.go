@@ -0,0 +1,393 @@
+package zetachain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The gRPC client converts the proposal messages it knows the schema of to the JSON of the LCD API,
+// so that their fields are decoded by the same decoders for both transports. Schemas follow the field
+// numbers of the .proto files and only list the fields worth showing.
+
+// protoKind is the type of a message field, which determines its JSON form
+type protoKind int
+
+const (
+	protoString    protoKind = iota
+	protoInt64               // JSON string, as the LCD API encodes 64-bit integers
+	protoUint64              // JSON string
+	protoUint32              // JSON number
+	protoBool                // JSON boolean
+	protoEnum                // Name of the value
+	protoLegacyDec           // Legacy decimal, an integer scaled by 10^18 in protobuf
+	protoDuration            // google.protobuf.Duration, e.g. "172800s"
+	protoMessage             // Nested message with the fields of the field
+	protoAny                 // google.protobuf.Any, converted if its type has a schema
+)
+
+// protoField describes a field of a message for its conversion to JSON
+type protoField struct {
+	num      protowire.Number
+	name     string
+	kind     protoKind
+	repeated bool
+	enum     []string     // Names of the values of enum fields
+	fields   []protoField // Fields of nested messages
+}
+
+func stringField(num protowire.Number, name string) protoField {
+	return protoField{num: num, name: name, kind: protoString}
+}
+
+func int64Field(num protowire.Number, name string) protoField {
+	return protoField{num: num, name: name, kind: protoInt64}
+}
+
+func uint64Field(num protowire.Number, name string) protoField {
+	return protoField{num: num, name: name, kind: protoUint64}
+}
+
+func uint32Field(num protowire.Number, name string) protoField {
+	return protoField{num: num, name: name, kind: protoUint32}
+}
+
+func boolField(num protowire.Number, name string) protoField {
+	return protoField{num: num, name: name, kind: protoBool}
+}
+
+func enumField(num protowire.Number, name string, values ...string) protoField {
+	return protoField{num: num, name: name, kind: protoEnum, enum: values}
+}
+
+func legacyDecField(num protowire.Number, name string) protoField {
+	return protoField{num: num, name: name, kind: protoLegacyDec}
+}
+
+func durationField(num protowire.Number, name string) protoField {
+	return protoField{num: num, name: name, kind: protoDuration}
+}
+
+func messageField(num protowire.Number, name string, fields ...protoField) protoField {
+	return protoField{num: num, name: name, kind: protoMessage, fields: fields}
+}
+
+func anyField(num protowire.Number, name string) protoField {
+	return protoField{num: num, name: name, kind: protoAny}
+}
+
+// repeated marks a field as a list
+func repeated(field protoField) protoField {
+	field.repeated = true
+
+	return field
+}
+
+var (
+	coinFields = []protoField{stringField(1, "denom"), stringField(2, "amount")}
+	planFields = []protoField{stringField(1, "name"), int64Field(3, "height"), stringField(4, "info")}
+
+	// govParamsFields are the fields of cosmos.gov.v1.Params
+	govParamsFields = []protoField{
+		repeated(messageField(1, "min_deposit", coinFields...)),
+		durationField(2, "max_deposit_period"),
+		durationField(3, "voting_period"),
+		stringField(4, "quorum"),
+		stringField(5, "threshold"),
+		stringField(6, "veto_threshold"),
+		stringField(7, "min_initial_deposit_ratio"),
+		stringField(8, "proposal_cancel_ratio"),
+		stringField(9, "proposal_cancel_dest"),
+		durationField(10, "expedited_voting_period"),
+		stringField(11, "expedited_threshold"),
+		repeated(messageField(12, "expedited_min_deposit", coinFields...)),
+		boolField(13, "burn_vote_quorum"),
+		boolField(14, "burn_proposal_deposit_prevote"),
+		boolField(15, "burn_vote_veto"),
+		stringField(16, "min_deposit_ratio"),
+	}
+
+	// stakingParamsFields are the fields of cosmos.staking.v1beta1.Params
+	stakingParamsFields = []protoField{
+		durationField(1, "unbonding_time"),
+		uint32Field(2, "max_validators"),
+		uint32Field(3, "max_entries"),
+		uint32Field(4, "historical_entries"),
+		stringField(5, "bond_denom"),
+		legacyDecField(6, "min_commission_rate"),
+	}
+
+	// distributionParamsFields are the fields of cosmos.distribution.v1beta1.Params
+	distributionParamsFields = []protoField{
+		legacyDecField(1, "community_tax"),
+		legacyDecField(2, "base_proposer_reward"),
+		legacyDecField(3, "bonus_proposer_reward"),
+		boolField(4, "withdraw_addr_enabled"),
+	}
+
+	// policyTypes are the names of the zetachain.zetacore.authority.PolicyType values
+	policyTypes = []string{"groupEmergency", "groupOperational", "groupAdmin", "groupEmpty"}
+
+	// protoSchemas holds the fields of the messages that are converted to JSON, by type URL
+	protoSchemas = map[string][]protoField{
+		softwareUpgradeType: {stringField(1, "authority"), messageField(2, "plan", planFields...)},
+
+		"/cosmos.gov.v1.MsgExecLegacyContent":          {anyField(1, "content"), stringField(2, "authority")},
+		"/cosmos.gov.v1.MsgUpdateParams":               {stringField(1, "authority"), messageField(2, "params", govParamsFields...)},
+		"/cosmos.staking.v1beta1.MsgUpdateParams":      {stringField(1, "authority"), messageField(2, "params", stakingParamsFields...)},
+		"/cosmos.distribution.v1beta1.MsgUpdateParams": {stringField(1, "authority"), messageField(2, "params", distributionParamsFields...)},
+
+		"/cosmos.distribution.v1beta1.MsgCommunityPoolSpend":      {stringField(1, "authority"), stringField(2, "recipient"), repeated(messageField(3, "amount", coinFields...))},
+		"/cosmos.distribution.v1beta1.CommunityPoolSpendProposal": {stringField(1, "title"), stringField(2, "description"), stringField(3, "recipient"), repeated(messageField(4, "amount", coinFields...))},
+		"/cosmos.params.v1beta1.ParameterChangeProposal": {
+			stringField(1, "title"),
+			stringField(2, "description"),
+			repeated(messageField(3, "changes", stringField(1, "subspace"), stringField(2, "key"), stringField(3, "value"))),
+		},
+
+		"/ibc.core.client.v1.MsgIBCSoftwareUpgrade":   {messageField(1, "plan", planFields...), anyField(2, "upgraded_client_state"), stringField(3, "signer")},
+		"/ibc.core.client.v1.UpgradeProposal":         {stringField(1, "title"), stringField(2, "description"), messageField(3, "plan", planFields...), anyField(4, "upgraded_client_state")},
+		"/ibc.core.client.v1.MsgRecoverClient":        {stringField(1, "subject_client_id"), stringField(2, "substitute_client_id"), stringField(3, "signer")},
+		"/ibc.core.client.v1.ClientUpdateProposal":    {stringField(1, "title"), stringField(2, "description"), stringField(3, "subject_client_id"), stringField(4, "substitute_client_id")},
+		"/ibc.lightclients.tendermint.v1.ClientState": {stringField(1, "chain_id")},
+
+		"/zetachain.zetacore.authority.MsgAddAuthorization":    {stringField(1, "creator"), stringField(2, "msg_url"), enumField(3, "authorized_policy", policyTypes...)},
+		"/zetachain.zetacore.authority.MsgRemoveAuthorization": {stringField(1, "creator"), stringField(2, "msg_url")},
+		"/zetachain.zetacore.authority.MsgUpdatePolicies": {
+			stringField(1, "creator"),
+			messageField(2, "policies", repeated(messageField(1, "items", enumField(1, "policy_type", policyTypes...), stringField(2, "address")))),
+		},
+		"/zetachain.zetacore.authority.MsgUpdateChainInfo": {
+			stringField(1, "signer"),
+			messageField(3, "chain", int64Field(2, "chain_id"), boolField(7, "is_external"), stringField(9, "name")),
+		},
+
+		"/zetachain.zetacore.observer.MsgUpdateChainParams": {
+			stringField(1, "creator"),
+			messageField(2, "chainParams",
+				int64Field(11, "chain_id"),
+				uint64Field(1, "confirmation_count"),
+				uint64Field(2, "gas_price_ticker"),
+				uint64Field(3, "inbound_ticker"),
+				uint64Field(4, "outbound_ticker"),
+				uint64Field(5, "watch_utxo_ticker"),
+				stringField(8, "zeta_token_contract_address"),
+				stringField(9, "connector_contract_address"),
+				stringField(10, "erc20_custody_contract_address"),
+				int64Field(12, "outbound_schedule_interval"),
+				int64Field(13, "outbound_schedule_lookahead"),
+				stringField(14, "ballot_threshold"),
+				stringField(15, "min_observer_delegation"),
+				boolField(16, "is_supported"),
+				stringField(17, "gateway_address"),
+			),
+		},
+		"/zetachain.zetacore.observer.MsgRemoveChainParams": {stringField(1, "creator"), int64Field(2, "chain_id")},
+		"/zetachain.zetacore.observer.MsgAddObserver": {
+			stringField(1, "creator"),
+			stringField(2, "observer_address"),
+			stringField(3, "zetaclient_grantee_pubkey"),
+			boolField(4, "add_node_account_only"),
+		},
+		"/zetachain.zetacore.observer.MsgUpdateObserver": {
+			stringField(1, "creator"),
+			stringField(2, "old_observer_address"),
+			stringField(3, "new_observer_address"),
+			enumField(4, "update_reason", "Undefined", "Tombstoned", "AdminUpdate"),
+		},
+		"/zetachain.zetacore.observer.MsgUpdateKeygen":     {stringField(1, "creator"), int64Field(2, "block")},
+		"/zetachain.zetacore.observer.MsgResetChainNonces": {stringField(1, "creator"), int64Field(2, "chain_id"), int64Field(3, "chain_nonce_low"), int64Field(4, "chain_nonce_high")},
+		"/zetachain.zetacore.observer.MsgEnableCCTX":       {stringField(1, "creator"), boolField(2, "enableInbound"), boolField(3, "enableOutbound")},
+		"/zetachain.zetacore.observer.MsgDisableCCTX":      {stringField(1, "creator"), boolField(2, "disableInbound"), boolField(3, "disableOutbound")},
+		"/zetachain.zetacore.observer.MsgUpdateGasPriceIncreaseFlags": {
+			stringField(1, "creator"),
+			messageField(2, "gasPriceIncreaseFlags",
+				int64Field(1, "epochLength"),
+				durationField(2, "retryInterval"),
+				uint32Field(3, "gasPriceIncreasePercent"),
+				uint32Field(4, "gasPriceIncreaseMax"),
+				uint32Field(5, "maxPendingCctxs"),
+			),
+		},
+		"/zetachain.zetacore.observer.MsgUpdateOperationalFlags": {
+			stringField(1, "creator"),
+			messageField(2, "operational_flags", int64Field(1, "restart_height"), durationField(2, "signer_block_time_offset"), stringField(3, "minimum_version")),
+		},
+
+		"/zetachain.zetacore.crosschain.MsgWhitelistERC20": {
+			stringField(1, "creator"),
+			stringField(2, "erc20_address"),
+			int64Field(3, "chain_id"),
+			stringField(4, "name"),
+			stringField(5, "symbol"),
+			uint32Field(6, "decimals"),
+			int64Field(7, "gas_limit"),
+			stringField(8, "liquidity_cap"),
+		},
+		"/zetachain.zetacore.crosschain.MsgMigrateTssFunds":   {stringField(1, "creator"), int64Field(2, "chain_id"), stringField(3, "amount")},
+		"/zetachain.zetacore.crosschain.MsgUpdateTssAddress":  {stringField(1, "creator"), stringField(2, "tss_pubkey")},
+		"/zetachain.zetacore.crosschain.MsgRefundAbortedCCTX": {stringField(1, "creator"), stringField(2, "cctx_index"), stringField(3, "refund_address")},
+		"/zetachain.zetacore.crosschain.MsgUpdateRateLimiterFlags": {
+			stringField(1, "creator"),
+			messageField(2, "rate_limiter_flags",
+				boolField(1, "enabled"),
+				int64Field(2, "window"),
+				stringField(3, "rate"),
+				repeated(messageField(4, "conversions", stringField(1, "zrc20"), stringField(2, "rate"))),
+			),
+		},
+
+		"/zetachain.zetacore.fungible.MsgUpdateZRC20LiquidityCap": {stringField(1, "creator"), stringField(2, "zrc20_address"), stringField(3, "liquidity_cap")},
+		"/zetachain.zetacore.fungible.MsgPauseZRC20":              {stringField(1, "creator"), repeated(stringField(2, "zrc20_addresses"))},
+		"/zetachain.zetacore.fungible.MsgUnpauseZRC20":            {stringField(1, "creator"), repeated(stringField(2, "zrc20_addresses"))},
+		"/zetachain.zetacore.fungible.MsgUpdateSystemContract":    {stringField(1, "creator"), stringField(2, "new_system_contract_address")},
+	}
+)
+
+// moduleParams describes the params of a module in the response of its Query/Params method
+type moduleParams struct {
+	field  protowire.Number // Field of the params in the response
+	fields []protoField
+}
+
+// moduleParamsSchemas holds the params that the gRPC client fetches, by protobuf package
+var moduleParamsSchemas = map[string]moduleParams{
+	"cosmos.gov.v1":               {field: 4, fields: govParamsFields},
+	"cosmos.staking.v1beta1":      {field: 1, fields: stakingParamsFields},
+	"cosmos.distribution.v1beta1": {field: 1, fields: distributionParamsFields},
+}
+
+// protoJSON converts an encoded message to the JSON of the LCD API, which holds the type URL in
+// @type next to the fields. ok is false for types without a schema.
+func protoJSON(typeURL string, data []byte) (raw json.RawMessage, ok bool, err error) {
+	fields, ok := protoSchemas[typeURL]
+	if !ok {
+		return nil, false, nil
+	}
+
+	object, err := protoObject(fields, data)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to decode %s: %w", typeURL, err)
+	}
+
+	object["@type"] = typeURL
+
+	raw, err = json.Marshal(object)
+
+	return raw, true, err
+}
+
+// protoObject converts an encoded message to a JSON object. Fields that are not set have their
+// default value, as in the responses of the LCD API, and fields missing from the schema are left out.
+func protoObject(fields []protoField, data []byte) (map[string]any, error) {
+	object := make(map[string]any, len(fields))
+	for _, field := range fields {
+		object[field.name] = field.defaultValue()
+	}
+
+	err := protoFields(data, func(num protowire.Number, v uint64, b []byte) error {
+		for _, field := range fields {
+			if field.num != num {
+				continue
+			}
+
+			value, err := field.value(v, b)
+			if err != nil {
+				return fmt.Errorf("%s: %w", field.name, err)
+			}
+
+			if field.repeated {
+				object[field.name] = append(object[field.name].([]any), value)
+			} else {
+				object[field.name] = value
+			}
+		}
+
+		return nil
+	})
+
+	return object, err
+}
+
+// defaultValue returns the JSON value of a field that is not set
+func (f protoField) defaultValue() any {
+	if f.repeated {
+		return []any{}
+	}
+
+	switch f.kind {
+	case protoString, protoLegacyDec:
+		return ""
+	case protoInt64, protoUint64:
+		return "0"
+	case protoUint32:
+		return 0
+	case protoBool:
+		return false
+	case protoEnum:
+		return enumName(f.enum, 0)
+	}
+
+	return nil
+}
+
+// value returns the JSON value of a field, given the value of a varint field in v and the content of
+// a length-delimited field in b
+func (f protoField) value(v uint64, b []byte) (any, error) {
+	switch f.kind {
+	case protoString:
+		return string(b), nil
+	case protoInt64:
+		return strconv.FormatInt(int64(v), 10), nil
+	case protoUint64:
+		return strconv.FormatUint(v, 10), nil
+	case protoUint32:
+		return uint32(v), nil
+	case protoBool:
+		return v != 0, nil
+	case protoEnum:
+		return enumName(f.enum, v), nil
+	case protoLegacyDec:
+		return legacyDec(b), nil
+	case protoDuration:
+		return decodeDuration(b)
+	case protoMessage:
+		return protoObject(f.fields, b)
+	case protoAny:
+		return anyJSON(b)
+	}
+
+	return nil, fmt.Errorf("unknown field kind %d", f.kind)
+}
+
+// anyJSON converts a google.protobuf.Any to JSON. Types without a schema only keep their @type.
+func anyJSON(data []byte) (json.RawMessage, error) {
+	var (
+		typeURL string
+		value   []byte
+	)
+
+	err := protoFields(data, func(num protowire.Number, _ uint64, b []byte) error {
+		switch num {
+		case 1:
+			typeURL = string(b)
+		case 2:
+			value = b
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	raw, ok, err := protoJSON(typeURL, value)
+	if ok {
+		return raw, err
+	}
+
+	return json.Marshal(map[string]string{"@type": typeURL})
+}
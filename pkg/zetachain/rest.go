@@ -1,7 +1,12 @@
 package zetachain
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/go-resty/resty/v2"
@@ -9,22 +14,52 @@ import (
 	"github.com/rs/zerolog"
 )
 
+// Paths of the gov API, below /cosmos/gov/{version}
 const (
-	proposalsPath = "/cosmos/gov/v1/proposals"
+	proposalsPath = "/proposals"
+	proposalPath  = proposalsPath + "/{proposalId}"
+	tallyPath     = proposalPath + "/tally"
+	votesPath     = proposalPath + "/votes"
+	paramsPath    = "/params/{paramsType}"
 )
 
+const (
+	denomsMetadataPath = "/cosmos/bank/v1beta1/denoms_metadata"
+	latestBlockPath    = "/cosmos/base/tendermint/v1beta1/blocks/latest"
+)
+
+// govPath returns the path of a gov API endpoint in the given version
+func govPath(version string, path string) string {
+	return "/cosmos/gov/" + version + path
+}
+
+// retriesPerEndpoint is how often a request is retried on connection errors before failing over
+const retriesPerEndpoint = 2
+
 type RESTClient struct {
 	config      *config.Config
 	restyClient *resty.Client
 	log         *zerolog.Logger
+	versions    *govVersions
+
+	// Endpoint health per network, created on first use
+	poolsMu sync.Mutex
+	pools   map[string]*endpointPool
 }
 
 type ProposalsResponse struct {
 	Proposals  []Proposal `json:"proposals"`
-	Pagination struct {
-		NextKey string `json:"next_key"`
-		Total   string `json:"total"`
-	} `json:"pagination"`
+	Pagination Pagination `json:"pagination"`
+}
+
+// Pagination describes the page of a list response
+type Pagination struct {
+	NextKey string `json:"next_key"` // Base64 key of the next page, empty on the last page
+	Total   string `json:"total"`
+}
+
+type ProposalResponse struct {
+	Proposal Proposal `json:"proposal"`
 }
 
 type Proposal struct {
@@ -56,9 +91,14 @@ type Deposit struct {
 	Amount string `json:"amount"`
 }
 
+// Message is a message of a proposal. Its JSON is decoded by UnmarshalJSON, as the LCD API
+// holds the fields next to the type.
 type Message struct {
 	Type string      `json:"@type"`
-	Data MessageData `json:",inline"`
+	Data MessageData `json:"-"`
+
+	// Raw is the JSON of the message, from which Fields decodes the key fields of its type
+	Raw json.RawMessage `json:"-"`
 }
 
 type MessageData struct {
@@ -72,41 +112,461 @@ type UpgradePlan struct {
 	Info   string `json:"info"`
 }
 
+// DenomsMetadataResponse lists the metadata registered in the bank module
+type DenomsMetadataResponse struct {
+	Metadatas []DenomMetadata `json:"metadatas"`
+}
+
+// DenomMetadata describes a denomination and its units
+type DenomMetadata struct {
+	Description string      `json:"description"`
+	DenomUnits  []DenomUnit `json:"denom_units"`
+	Base        string      `json:"base"`
+	Display     string      `json:"display"`
+	Name        string      `json:"name"`
+	Symbol      string      `json:"symbol"`
+}
+
+// DenomUnit is a unit of a denomination, 1 unit = 10^exponent base units
+type DenomUnit struct {
+	Denom    string   `json:"denom"`
+	Exponent int      `json:"exponent"`
+	Aliases  []string `json:"aliases"`
+}
+
+// DisplayExponent returns the exponent of the display unit
+func (m DenomMetadata) DisplayExponent() (int, bool) {
+	for _, unit := range m.DenomUnits {
+		if unit.Denom == m.Display {
+			return unit.Exponent, true
+		}
+	}
+
+	return 0, false
+}
+
+// LatestBlockResponse is the response of the latest block endpoint, reduced to the block header
+type LatestBlockResponse struct {
+	Block struct {
+		Header BlockHeader `json:"header"`
+	} `json:"block"`
+}
+
+// BlockHeader holds the height and time of a block
+type BlockHeader struct {
+	ChainID string    `json:"chain_id"`
+	Height  string    `json:"height"`
+	Time    time.Time `json:"time"`
+}
+
 func NewRESTClient(cfg *config.Config, logger *zerolog.Logger) *RESTClient {
 	client := resty.New().
 		SetHeader("Content-Type", "application/json").
-		SetRetryCount(10)
+		SetRetryCount(retriesPerEndpoint)
+
+	if logger == nil {
+		nop := zerolog.Nop()
+		logger = &nop
+	}
 
 	return &RESTClient{
 		config:      cfg,
 		restyClient: client,
 		log:         logger,
+		versions:    newGovVersions(cfg),
+		pools:       make(map[string]*endpointPool),
 	}
 }
 
-func (r *RESTClient) GetProposals(network string) (*ProposalsResponse, error) {
-	networkURL, ok := r.config.Networks[network]
-	if !ok {
-		return nil, fmt.Errorf("network %s not found in config", network)
+// GetProposals fetches the proposals selected by query, following the pagination to the last page
+func (r *RESTClient) GetProposals(network string, query ProposalsQuery) (*ProposalsResponse, error) {
+	var response *ProposalsResponse
+
+	err := r.versions.query(network, notServed, func(version string) error {
+		var err error
+		response, err = collectProposals(query, func(key string) (*ProposalsResponse, error) {
+			return r.getProposalsPage(network, version, query, key)
+		})
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (r *RESTClient) getProposalsPage(network string, version string, query ProposalsQuery, key string) (*ProposalsResponse, error) {
+	params := map[string]string{
+		"pagination.limit": strconv.Itoa(proposalsPageLimit),
+	}
+
+	if key != "" {
+		params["pagination.key"] = key
+	}
+
+	if query.Reverse {
+		params["pagination.reverse"] = "true"
+	}
+
+	if query.Status != "" {
+		params["proposal_status"] = query.Status
+	}
+
+	if version == config.GovV1Beta1 {
+		var response proposalsResponseV1Beta1
+		err := r.get(network, govPath(version, proposalsPath), func(request *resty.Request) {
+			request.
+				SetResult(&response).
+				SetQueryParams(params)
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		page := ProposalsResponse{Pagination: response.Pagination}
+		for _, proposal := range response.Proposals {
+			page.Proposals = append(page.Proposals, proposal.normalize())
+		}
+
+		return &page, nil
 	}
 
 	var response ProposalsResponse
-	resp, err := r.restyClient.R().
-		SetResult(&response).
-		SetQueryParams(map[string]string{
-			"pagination.limit": "1000",
-		}).
-		Get(networkURL.ApiUrl.String() + proposalsPath)
+	err := r.get(network, govPath(version, proposalsPath), func(request *resty.Request) {
+		request.
+			SetResult(&response).
+			SetQueryParams(params)
+	})
 
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.IsError() {
-		return nil, fmt.Errorf("API request failed with status %d", resp.StatusCode())
+	return &response, nil
+}
+
+// GetProposal fetches a single proposal by ID
+func (r *RESTClient) GetProposal(network string, proposalID string) (*Proposal, error) {
+	var proposal Proposal
+
+	err := r.versions.query(network, notServed, func(version string) error {
+		if version == config.GovV1Beta1 {
+			var response proposalResponseV1Beta1
+			err := r.get(network, govPath(version, proposalPath), func(request *resty.Request) {
+				request.
+					SetResult(&response).
+					SetPathParam("proposalId", proposalID)
+			})
+
+			proposal = response.Proposal.normalize()
+
+			return err
+		}
+
+		var response ProposalResponse
+		err := r.get(network, govPath(version, proposalPath), func(request *resty.Request) {
+			request.
+				SetResult(&response).
+				SetPathParam("proposalId", proposalID)
+		})
+
+		proposal = response.Proposal
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
-	return &response, nil
+	return &proposal, nil
+}
+
+// GetTally fetches the current tally of a proposal
+func (r *RESTClient) GetTally(network string, proposalID string) (*TallyResult, error) {
+	var tally TallyResult
+
+	err := r.versions.query(network, notServed, func(version string) error {
+		if version == config.GovV1Beta1 {
+			var response tallyResponseV1Beta1
+			err := r.get(network, govPath(version, tallyPath), func(request *resty.Request) {
+				request.
+					SetResult(&response).
+					SetPathParam("proposalId", proposalID)
+			})
+
+			tally = response.Tally.normalize()
+
+			return err
+		}
+
+		var response TallyResponse
+		err := r.get(network, govPath(version, tallyPath), func(request *resty.Request) {
+			request.
+				SetResult(&response).
+				SetPathParam("proposalId", proposalID)
+		})
+
+		tally = response.Tally
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &tally, nil
+}
+
+// GetVotes fetches the votes cast on a proposal
+func (r *RESTClient) GetVotes(network string, proposalID string) ([]Vote, error) {
+	var votes []Vote
+
+	err := r.versions.query(network, notServed, func(version string) error {
+		votes = nil
+
+		prepare := func(result any) func(request *resty.Request) {
+			return func(request *resty.Request) {
+				request.
+					SetResult(result).
+					SetPathParam("proposalId", proposalID).
+					SetQueryParams(map[string]string{
+						"pagination.limit": "1000",
+					})
+			}
+		}
+
+		if version == config.GovV1Beta1 {
+			var response votesResponseV1Beta1
+			err := r.get(network, govPath(version, votesPath), prepare(&response))
+
+			for _, vote := range response.Votes {
+				votes = append(votes, vote.normalize())
+			}
+
+			return err
+		}
+
+		var response VotesResponse
+		err := r.get(network, govPath(version, votesPath), prepare(&response))
+		votes = response.Votes
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return votes, nil
+}
+
+// GetParams fetches the parameters of the gov module. The v1beta1 API serves them in three parts.
+func (r *RESTClient) GetParams(network string) (*Params, error) {
+	var params Params
+
+	err := r.versions.query(network, notServed, func(version string) error {
+		if version == config.GovV1Beta1 {
+			for _, paramsType := range paramsTypesV1Beta1 {
+				var response paramsResponseV1Beta1
+				err := r.get(network, govPath(version, paramsPath), func(request *resty.Request) {
+					request.
+						SetResult(&response).
+						SetPathParam("paramsType", paramsType)
+				})
+
+				if err != nil {
+					return err
+				}
+
+				response.merge(paramsType, &params)
+			}
+
+			return nil
+		}
+
+		var response ParamsResponse
+		err := r.get(network, govPath(version, paramsPath), func(request *resty.Request) {
+			request.
+				SetResult(&response).
+				SetPathParam("paramsType", "tallying")
+		})
+
+		params = response.Params
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &params, nil
+}
+
+// GetDenomsMetadata fetches the metadata of all denominations registered in the bank module
+func (r *RESTClient) GetDenomsMetadata(network string) ([]DenomMetadata, error) {
+	var response DenomsMetadataResponse
+	err := r.get(network, denomsMetadataPath, func(request *resty.Request) {
+		request.
+			SetResult(&response).
+			SetQueryParams(map[string]string{
+				"pagination.limit": "1000",
+			})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Metadatas, nil
+}
+
+// GetModuleParams fetches the params of a module, given by its protobuf package, e.g.
+// zetachain.zetacore.crosschain, as JSON
+func (r *RESTClient) GetModuleParams(network string, module string) (json.RawMessage, error) {
+	var response moduleParamsResponse
+	err := r.get(network, moduleParamsPath(module), func(request *resty.Request) {
+		request.SetResult(&response)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(response.Params) == 0 {
+		return nil, fmt.Errorf("no params in the response for module %s", module)
+	}
+
+	return response.Params, nil
+}
+
+// GetLatestBlock fetches the header of the latest block
+func (r *RESTClient) GetLatestBlock(network string) (*BlockHeader, error) {
+	var response LatestBlockResponse
+	err := r.get(network, latestBlockPath, func(request *resty.Request) {
+		request.SetResult(&response)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Block.Header, nil
+}
+
+// EndpointStatus returns the health of the LCD endpoints of a network
+func (r *RESTClient) EndpointStatus(network string) ([]EndpointStatus, error) {
+	pool, err := r.pool(network)
+	if err != nil {
+		return nil, err
+	}
+
+	return pool.status(time.Now()), nil
+}
+
+// get sends a GET request for path to the endpoints of a network in turn until one of them
+// answers. Connection errors, rate limits and server errors fail over to the next endpoint,
+// other error responses such as an unknown proposal are returned right away.
+func (r *RESTClient) get(network string, path string, prepare func(request *resty.Request)) error {
+	pool, err := r.pool(network)
+	if err != nil {
+		return err
+	}
+
+	endpoints := pool.order(time.Now())
+	if len(endpoints) == 0 {
+		return fmt.Errorf("no LCD endpoints configured for network %s", network)
+	}
+
+	var errs []error
+
+	for _, endpoint := range endpoints {
+		request := r.restyClient.R()
+		prepare(request)
+
+		start := time.Now()
+		resp, err := request.Get(endpoint.url + path)
+
+		if err == nil && !failsOver(resp) {
+			pool.success(endpoint, time.Since(start))
+
+			if resp.IsError() {
+				return &StatusError{StatusCode: resp.StatusCode()}
+			}
+
+			return nil
+		}
+
+		if err == nil {
+			err = &StatusError{StatusCode: resp.StatusCode()}
+		}
+
+		pool.failure(endpoint, err, time.Now())
+
+		r.log.Warn().
+			Err(err).
+			Str("network", network).
+			Str("endpoint", endpoint.url).
+			Msg("LCD endpoint failed")
+
+		errs = append(errs, fmt.Errorf("%s: %w", endpoint.url, err))
+	}
+
+	return fmt.Errorf("%w: %w", ErrEndpointsUnavailable, errors.Join(errs...))
+}
+
+// failsOver reports whether a response means the endpoint cannot serve requests right now. Routes
+// that a node does not implement, such as the gov v1 API of older chains, are no reason to fail over.
+func failsOver(resp *resty.Response) bool {
+	switch resp.StatusCode() {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusNotImplemented:
+		return false
+	default:
+		return resp.StatusCode() >= http.StatusInternalServerError
+	}
+}
+
+// StatusError is an error response of the LCD API
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("API request failed with status %d", e.StatusCode)
+}
+
+// notServed reports whether an error means that the node does not serve the requested API
+func notServed(err error) bool {
+	var statusErr *StatusError
+
+	return errors.As(err, &statusErr) && (statusErr.StatusCode == http.StatusNotFound || statusErr.StatusCode == http.StatusNotImplemented)
+}
+
+// pool returns the endpoint pool of a network
+func (r *RESTClient) pool(network string) (*endpointPool, error) {
+	networkConfig, ok := r.config.Networks[network]
+	if !ok {
+		return nil, fmt.Errorf("network %s not found in config", network)
+	}
+
+	r.poolsMu.Lock()
+	defer r.poolsMu.Unlock()
+
+	pool, ok := r.pools[network]
+	if !ok {
+		pool = newEndpointPool(network, networkConfig)
+		r.pools[network] = pool
+	}
+
+	return pool, nil
 }
 
 // SetRestyClient allows setting a custom resty client for testing purposes
@@ -2,6 +2,7 @@ package zetachain_test
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -112,11 +113,7 @@ var _ = Describe("RESTClient", func() {
 				// Create test config
 				mockURL, _ := url.Parse(mockServer.URL)
 				testConfig = &config.Config{
-					Networks: map[string]struct {
-						ApiUrl       url.URL       `mapstructure:"api_url"`
-						PollInterval time.Duration `mapstructure:"poll_interval"`
-						Audiences    []string      `mapstructure:"audiences"`
-					}{
+					Networks: map[string]config.Network{
 						"testnet": {
 							ApiUrl:       *mockURL,
 							PollInterval: 0,
@@ -131,13 +128,13 @@ var _ = Describe("RESTClient", func() {
 			})
 
 			It("should successfully retrieve proposals", func() {
-				response, err := restClient.GetProposals("testnet")
+				response, err := restClient.GetProposals("testnet", zetachain.ProposalsQuery{})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(response).NotTo(BeNil())
 			})
 
 			It("should return proposals with MsgSoftwareUpgrade messages", func() {
-				response, err := restClient.GetProposals("testnet")
+				response, err := restClient.GetProposals("testnet", zetachain.ProposalsQuery{})
 				Expect(err).NotTo(HaveOccurred())
 
 				// Filter proposals to only include those with MsgSoftwareUpgrade
@@ -154,8 +151,23 @@ var _ = Describe("RESTClient", func() {
 				Expect(upgradeProposals).To(HaveLen(2))
 			})
 
+			It("should decode the upgrade plan next to the message type", func() {
+				mockResponse.Proposals[0].Messages[0].Data = zetachain.MessageData{
+					Authority: "zeta1authority",
+					Plan:      zetachain.UpgradePlan{Name: "v30", Height: "5000000"},
+				}
+
+				response, err := restClient.GetProposals("testnet", zetachain.ProposalsQuery{})
+				Expect(err).NotTo(HaveOccurred())
+
+				message := response.Proposals[0].Messages[0]
+				Expect(string(message.Raw)).To(ContainSubstring(`"plan":{"name":"v30"`))
+				Expect(message.Data.Authority).To(Equal("zeta1authority"))
+				Expect(message.Data.Plan).To(Equal(zetachain.UpgradePlan{Name: "v30", Height: "5000000"}))
+			})
+
 			It("should return proposals in the correct order", func() {
-				response, err := restClient.GetProposals("testnet")
+				response, err := restClient.GetProposals("testnet", zetachain.ProposalsQuery{})
 				Expect(err).NotTo(HaveOccurred())
 
 				// Filter for upgrade proposals
@@ -180,18 +192,14 @@ var _ = Describe("RESTClient", func() {
 		Context("when the network does not exist in config", func() {
 			BeforeEach(func() {
 				testConfig = &config.Config{
-					Networks: map[string]struct {
-						ApiUrl       url.URL       `mapstructure:"api_url"`
-						PollInterval time.Duration `mapstructure:"poll_interval"`
-						Audiences    []string      `mapstructure:"audiences"`
-					}{},
+					Networks: map[string]config.Network{},
 				}
 
 				restClient = zetachain.NewRESTClient(testConfig, nil)
 			})
 
 			It("should return an error", func() {
-				response, err := restClient.GetProposals("nonexistent")
+				response, err := restClient.GetProposals("nonexistent", zetachain.ProposalsQuery{})
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("network nonexistent not found in config"))
 				Expect(response).To(BeNil())
@@ -207,11 +215,7 @@ var _ = Describe("RESTClient", func() {
 
 				mockURL, _ := url.Parse(mockServer.URL)
 				testConfig = &config.Config{
-					Networks: map[string]struct {
-						ApiUrl       url.URL       `mapstructure:"api_url"`
-						PollInterval time.Duration `mapstructure:"poll_interval"`
-						Audiences    []string      `mapstructure:"audiences"`
-					}{
+					Networks: map[string]config.Network{
 						"testnet": {
 							ApiUrl:       *mockURL,
 							PollInterval: 0,
@@ -225,7 +229,7 @@ var _ = Describe("RESTClient", func() {
 			})
 
 			It("should return an error", func() {
-				response, err := restClient.GetProposals("testnet")
+				response, err := restClient.GetProposals("testnet", zetachain.ProposalsQuery{})
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("API request failed with status 500"))
 				Expect(response).To(BeNil())
@@ -242,11 +246,7 @@ var _ = Describe("RESTClient", func() {
 
 				mockURL, _ := url.Parse(mockServer.URL)
 				testConfig = &config.Config{
-					Networks: map[string]struct {
-						ApiUrl       url.URL       `mapstructure:"api_url"`
-						PollInterval time.Duration `mapstructure:"poll_interval"`
-						Audiences    []string      `mapstructure:"audiences"`
-					}{
+					Networks: map[string]config.Network{
 						"testnet": {
 							ApiUrl:       *mockURL,
 							PollInterval: 0,
@@ -260,10 +260,308 @@ var _ = Describe("RESTClient", func() {
 			})
 
 			It("should return an error", func() {
-				response, err := restClient.GetProposals("testnet")
+				response, err := restClient.GetProposals("testnet", zetachain.ProposalsQuery{})
 				Expect(err).To(HaveOccurred())
 				Expect(response).To(BeNil())
 			})
 		})
+
+		Context("with several pages", func() {
+			var queries []url.Values
+
+			BeforeEach(func() {
+				queries = nil
+
+				// Three pages of proposals, newest first
+				pages := map[string]string{
+					"":     `{"proposals": [{"id": "9"}, {"id": "8"}], "pagination": {"next_key": "AAc="}}`,
+					"AAc=": `{"proposals": [{"id": "7"}, {"id": "6"}], "pagination": {"next_key": "AAU="}}`,
+					"AAU=": `{"proposals": [{"id": "5"}], "pagination": {"next_key": null}}`,
+				}
+
+				mockServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					queries = append(queries, r.URL.Query())
+
+					w.Header().Set("Content-Type", "application/json")
+					_, err := w.Write([]byte(pages[r.URL.Query().Get("pagination.key")]))
+					Expect(err).NotTo(HaveOccurred())
+				}))
+
+				mockURL, _ := url.Parse(mockServer.URL)
+				restClient = zetachain.NewRESTClient(&config.Config{
+					Networks: map[string]config.Network{"testnet": {ApiUrl: *mockURL}},
+				}, nil)
+			})
+
+			It("should follow next_key to the last page", func() {
+				response, err := restClient.GetProposals("testnet", zetachain.ProposalsQuery{
+					Status:  "PROPOSAL_STATUS_VOTING_PERIOD",
+					Reverse: true,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.Proposals).To(HaveLen(5))
+				Expect(response.Proposals[4].ProposalId).To(Equal("5"))
+
+				Expect(queries).To(HaveLen(3))
+				Expect(queries[1].Get("pagination.key")).To(Equal("AAc="))
+				Expect(queries[2].Get("pagination.key")).To(Equal("AAU="))
+
+				for _, query := range queries {
+					Expect(query.Get("proposal_status")).To(Equal("PROPOSAL_STATUS_VOTING_PERIOD"))
+					Expect(query.Get("pagination.reverse")).To(Equal("true"))
+				}
+			})
+
+			It("should stop paging in reverse order at the first proposal that is not newer", func() {
+				response, err := restClient.GetProposals("testnet", zetachain.ProposalsQuery{Reverse: true, AfterID: 7})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.Proposals).To(HaveLen(2))
+				Expect(response.Proposals[1].ProposalId).To(Equal("8"))
+				Expect(queries).To(HaveLen(2))
+			})
+		})
+	})
+
+	Describe("GetDenomsMetadata", func() {
+		BeforeEach(func() {
+			mockServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.URL.Path).To(Equal("/cosmos/bank/v1beta1/denoms_metadata"))
+
+				w.Header().Set("Content-Type", "application/json")
+				_, err := w.Write([]byte(`{"metadatas": [{
+					"base": "uatom",
+					"display": "atom",
+					"symbol": "ATOM",
+					"denom_units": [{"denom": "uatom", "exponent": 0}, {"denom": "atom", "exponent": 6}]
+				}]}`))
+				Expect(err).NotTo(HaveOccurred())
+			}))
+
+			mockURL, _ := url.Parse(mockServer.URL)
+			testConfig = &config.Config{
+				Networks: map[string]config.Network{
+					"cosmoshub": {ApiUrl: *mockURL},
+				},
+			}
+
+			restClient = zetachain.NewRESTClient(testConfig, nil)
+			restClient.SetRestyClient(resty.New().SetBaseURL(mockServer.URL))
+		})
+
+		It("should return the metadata with the exponent of the display unit", func() {
+			metadatas, err := restClient.GetDenomsMetadata("cosmoshub")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(metadatas).To(HaveLen(1))
+			Expect(metadatas[0].Symbol).To(Equal("ATOM"))
+
+			exponent, ok := metadatas[0].DisplayExponent()
+			Expect(ok).To(BeTrue())
+			Expect(exponent).To(Equal(6))
+		})
+	})
+
+	Describe("gov v1beta1", func() {
+		var paths []string
+
+		BeforeEach(func() {
+			paths = nil
+
+			mockServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				paths = append(paths, r.URL.Path)
+				w.Header().Set("Content-Type", "application/json")
+
+				var body string
+
+				switch r.URL.Path {
+				case "/cosmos/gov/v1beta1/proposals":
+					body = `{"proposals": [{
+						"proposal_id": "7",
+						"content": {
+							"@type": "/cosmos.upgrade.v1beta1.SoftwareUpgradeProposal",
+							"title": "Upgrade v12",
+							"description": "Scheduled upgrade",
+							"plan": {"name": "v12", "height": "1200000", "info": "binaries"}
+						},
+						"status": "PROPOSAL_STATUS_VOTING_PERIOD",
+						"final_tally_result": {"yes": "600", "abstain": "100", "no": "300", "no_with_veto": "0"},
+						"voting_end_time": "2025-03-06T14:30:00Z"
+					}], "pagination": {"next_key": null}}`
+				case "/cosmos/gov/v1beta1/proposals/7/votes":
+					body = `{"votes": [{"proposal_id": "7", "voter": "zeta1voter", "option": "VOTE_OPTION_NO"}]}`
+				case "/cosmos/gov/v1beta1/params/voting":
+					body = `{"voting_params": {"voting_period": "172800s"}, "deposit_params": {"max_deposit_period": "0s"}}`
+				case "/cosmos/gov/v1beta1/params/deposit":
+					body = `{"deposit_params": {"min_deposit": [{"denom": "azeta", "amount": "1000"}], "max_deposit_period": "86400s"}}`
+				case "/cosmos/gov/v1beta1/params/tallying":
+					body = `{"tally_params": {"quorum": "0.334000000000000000", "threshold": "0.500000000000000000", "veto_threshold": "0.334000000000000000"}}`
+				default:
+					w.WriteHeader(http.StatusNotImplemented)
+					body = `{"code": 12, "message": "Not Implemented"}`
+				}
+
+				_, err := w.Write([]byte(body))
+				Expect(err).NotTo(HaveOccurred())
+			}))
+
+			mockURL, _ := url.Parse(mockServer.URL)
+			restClient = zetachain.NewRESTClient(&config.Config{
+				Networks: map[string]config.Network{"devnet": {ApiUrl: *mockURL}},
+			}, nil)
+		})
+
+		It("should detect a node without the v1 API and normalize its proposals", func() {
+			response, err := restClient.GetProposals("devnet", zetachain.ProposalsQuery{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(paths).To(Equal([]string{"/cosmos/gov/v1/proposals", "/cosmos/gov/v1beta1/proposals"}))
+
+			Expect(response.Proposals).To(HaveLen(1))
+			proposal := response.Proposals[0]
+			Expect(proposal.ProposalId).To(Equal("7"))
+			Expect(proposal.Title).To(Equal("Upgrade v12"))
+			Expect(proposal.Summary).To(Equal("Scheduled upgrade"))
+			Expect(proposal.FinalTallyResult).To(Equal(zetachain.TallyResult{YesCount: "600", AbstainCount: "100", NoCount: "300", NoWithVetoCount: "0"}))
+			Expect(proposal.Messages).To(HaveLen(1))
+			Expect(proposal.Messages[0].Type).To(Equal("/cosmos.upgrade.v1beta1.MsgSoftwareUpgrade"))
+			Expect(proposal.Messages[0].Data.Plan).To(Equal(zetachain.UpgradePlan{Name: "v12", Height: "1200000", Info: "binaries"}))
+
+			// The detected version is used right away from then on
+			paths = nil
+
+			votes, err := restClient.GetVotes("devnet", "7")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(votes).To(Equal([]zetachain.Vote{{
+				ProposalId: "7",
+				Voter:      "zeta1voter",
+				Options:    []zetachain.WeightedVoteOption{{Option: "VOTE_OPTION_NO", Weight: "1.000000000000000000"}},
+			}}))
+			Expect(paths).To(Equal([]string{"/cosmos/gov/v1beta1/proposals/7/votes"}))
+
+			endpoints, err := restClient.EndpointStatus("devnet")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(endpoints[0].Healthy).To(BeTrue())
+		})
+
+		It("should combine the params of the v1beta1 API", func() {
+			params, err := restClient.GetParams("devnet")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(params.VotingPeriod).To(Equal(zetachain.Duration(48 * time.Hour)))
+			Expect(params.MaxDepositPeriod).To(Equal(zetachain.Duration(24 * time.Hour)))
+			Expect(params.MinDeposit).To(Equal([]zetachain.Deposit{{Denom: "azeta", Amount: "1000"}}))
+			Expect(params.Quorum).To(Equal("0.334000000000000000"))
+			Expect(params.Threshold).To(Equal("0.500000000000000000"))
+		})
+	})
+
+	Describe("failover", func() {
+		var (
+			healthyURL   *url.URL
+			unhealthyURL *url.URL
+			requests     map[string]int
+		)
+
+		newServer := func(name string, status int) *url.URL {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests[name]++
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(status)
+				_, _ = w.Write([]byte(`{"proposal": {"id": "42"}}`))
+			}))
+			DeferCleanup(server.Close)
+
+			serverURL, _ := url.Parse(server.URL)
+
+			return serverURL
+		}
+
+		BeforeEach(func() {
+			requests = make(map[string]int)
+			unhealthyURL = newServer("unhealthy", http.StatusServiceUnavailable)
+			healthyURL = newServer("healthy", http.StatusOK)
+		})
+
+		It("should fail over to the next endpoint and skip the failed one afterwards", func() {
+			restClient = zetachain.NewRESTClient(&config.Config{
+				Networks: map[string]config.Network{
+					"mainnet": {ApiUrl: *unhealthyURL, ApiUrls: []url.URL{*healthyURL}},
+				},
+			}, nil)
+
+			for i := 0; i < 3; i++ {
+				proposal, err := restClient.GetProposal("mainnet", "42")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(proposal.ProposalId).To(Equal("42"))
+			}
+
+			Expect(requests).To(Equal(map[string]int{"unhealthy": 1, "healthy": 3}))
+
+			statuses, err := restClient.EndpointStatus("mainnet")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(statuses).To(HaveLen(2))
+			Expect(statuses[0].Healthy).To(BeFalse())
+			Expect(statuses[0].Failures).To(Equal(1))
+			Expect(statuses[0].LastError).To(ContainSubstring("status 503"))
+			Expect(statuses[1].Healthy).To(BeTrue())
+			Expect(statuses[1].Latency).To(BeNumerically(">", 0))
+		})
+
+		It("should not fail over on client errors", func() {
+			notFoundURL := newServer("not found", http.StatusNotFound)
+
+			restClient = zetachain.NewRESTClient(&config.Config{
+				Networks: map[string]config.Network{
+					"mainnet": {ApiUrl: *notFoundURL, ApiUrls: []url.URL{*healthyURL}, GovVersion: config.GovV1},
+				},
+			}, nil)
+
+			_, err := restClient.GetProposal("mainnet", "42")
+			Expect(err).To(MatchError(ContainSubstring("status 404")))
+			Expect(errors.Is(err, zetachain.ErrEndpointsUnavailable)).To(BeFalse())
+			Expect(requests).To(Equal(map[string]int{"not found": 1}))
+		})
+
+		It("should report when all endpoints are unavailable", func() {
+			restClient = zetachain.NewRESTClient(&config.Config{
+				Networks: map[string]config.Network{
+					"mainnet": {ApiUrl: *unhealthyURL},
+				},
+			}, nil)
+
+			_, err := restClient.GetProposal("mainnet", "42")
+			Expect(errors.Is(err, zetachain.ErrEndpointsUnavailable)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring(unhealthyURL.String() + ": API request failed with status 503"))
+		})
+
+		It("should report a network without endpoints", func() {
+			restClient = zetachain.NewRESTClient(&config.Config{
+				Networks: map[string]config.Network{
+					"mainnet": {},
+				},
+			}, nil)
+
+			_, err := restClient.GetProposal("mainnet", "42")
+			Expect(err).To(MatchError("no LCD endpoints configured for network mainnet"))
+		})
+
+		It("should take turns in round robin mode", func() {
+			otherURL := newServer("other", http.StatusOK)
+
+			restClient = zetachain.NewRESTClient(&config.Config{
+				Networks: map[string]config.Network{
+					"mainnet": {
+						ApiUrl:   *healthyURL,
+						ApiUrls:  []url.URL{*otherURL},
+						Failover: config.Failover{Strategy: config.StrategyRoundRobin},
+					},
+				},
+			}, nil)
+
+			for i := 0; i < 4; i++ {
+				_, err := restClient.GetProposal("mainnet", "42")
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			Expect(requests).To(Equal(map[string]int{"healthy": 2, "other": 2}))
+		})
 	})
 })
@@ -0,0 +1,213 @@
+package zetachain
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Governance events that affect a proposal, as emitted by the gov module
+const (
+	EventSubmitProposal = "submit_proposal" // A proposal was submitted
+	EventProposalVote   = "proposal_vote"   // A vote was cast
+	EventActiveProposal = "active_proposal" // The voting period of a proposal ended
+)
+
+// ProposalEventTypes lists the governance events a subscription receives
+var ProposalEventTypes = []string{EventSubmitProposal, EventProposalVote, EventActiveProposal}
+
+const (
+	// pingInterval is how often the connection is checked while no events arrive
+	pingInterval = 30 * time.Second
+
+	// readTimeout is how long the connection may stay silent, including pongs, before it is considered lost
+	readTimeout = 2 * pingInterval
+
+	writeTimeout = 10 * time.Second
+)
+
+// ProposalEvent is a governance event of a proposal observed on chain
+type ProposalEvent struct {
+	Type       string // One of ProposalEventTypes
+	ProposalID string
+}
+
+// rpcRequest is a JSON-RPC 2.0 request to a CometBFT node
+type rpcRequest struct {
+	JSONRPC string      `json:"jsonrpc"`
+	ID      int         `json:"id"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params"`
+}
+
+// rpcResponse is a JSON-RPC 2.0 response, or an event of a subscription
+type rpcResponse struct {
+	ID     int `json:"id"`
+	Result struct {
+		Query  string              `json:"query"`
+		Events map[string][]string `json:"events"`
+	} `json:"result"`
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Data    string `json:"data"`
+	} `json:"error"`
+}
+
+// WebsocketURL returns the /websocket endpoint of a CometBFT RPC node
+func WebsocketURL(rpcURL url.URL) string {
+	endpoint := rpcURL
+
+	switch endpoint.Scheme {
+	case "https":
+		endpoint.Scheme = "wss"
+	case "http":
+		endpoint.Scheme = "ws"
+	}
+
+	endpoint.Path = strings.TrimSuffix(endpoint.Path, "/") + "/websocket"
+
+	return endpoint.String()
+}
+
+// SubscribeProposalEvents subscribes to the governance events of a CometBFT RPC node over its
+// /websocket endpoint and calls handle for every event until the connection is lost or ctx is done.
+// connected is called once all subscriptions have been confirmed.
+func SubscribeProposalEvents(ctx context.Context, rpcURL url.URL, connected func(), handle func(ProposalEvent)) error {
+	dialer := websocket.Dialer{HandshakeTimeout: writeTimeout}
+
+	conn, _, err := dialer.DialContext(ctx, WebsocketURL(rpcURL), nil)
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer conn.Close()
+
+	// Closing the connection ends the read loop when ctx is done
+	done := make(chan struct{})
+	defer close(done)
+
+	var writeMu sync.Mutex
+
+	go func() {
+		ticker := time.NewTicker(pingInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				conn.Close()
+
+				return
+			case <-done:
+				return
+			case <-ticker.C:
+				writeMu.Lock()
+				err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeTimeout))
+				writeMu.Unlock()
+
+				if err != nil {
+					conn.Close()
+
+					return
+				}
+			}
+		}
+	}()
+
+	_ = conn.SetReadDeadline(time.Now().Add(readTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(readTimeout))
+	})
+
+	queries := proposalEventQueries()
+
+	writeMu.Lock()
+	for i, query := range queries {
+		err = conn.WriteJSON(rpcRequest{
+			JSONRPC: "2.0",
+			ID:      i + 1,
+			Method:  "subscribe",
+			Params:  map[string]string{"query": query},
+		})
+		if err != nil {
+			break
+		}
+	}
+	writeMu.Unlock()
+
+	if err != nil {
+		return fmt.Errorf("failed to subscribe: %w", err)
+	}
+
+	pending := len(queries)
+
+	for {
+		var response rpcResponse
+		if err := conn.ReadJSON(&response); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			return fmt.Errorf("connection lost: %w", err)
+		}
+
+		_ = conn.SetReadDeadline(time.Now().Add(readTimeout))
+
+		if response.Error != nil {
+			return fmt.Errorf("subscription failed: %s (%d): %s", response.Error.Message, response.Error.Code, response.Error.Data)
+		}
+
+		// The first response to each subscription confirms it, later ones carry events
+		if response.Result.Query == "" {
+			pending--
+			if pending == 0 && connected != nil {
+				connected()
+			}
+
+			continue
+		}
+
+		for _, event := range ProposalEventsFrom(response.Result.Events) {
+			handle(event)
+		}
+	}
+}
+
+// proposalEventQueries returns the CometBFT queries for the governance events. Proposals are submitted
+// and voted on in transactions, while voting periods end in the end blocker.
+func proposalEventQueries() []string {
+	return []string{
+		fmt.Sprintf("tm.event = 'Tx' AND %s.proposal_id EXISTS", EventSubmitProposal),
+		fmt.Sprintf("tm.event = 'Tx' AND %s.proposal_id EXISTS", EventProposalVote),
+		fmt.Sprintf("tm.event = 'NewBlock' AND %s.proposal_id EXISTS", EventActiveProposal),
+	}
+}
+
+// ProposalEventsFrom extracts the governance events from the flattened events of a CometBFT
+// event message, in which the values of each "type.attribute" key are listed. An event is
+// returned once per proposal, even if it occurs several times.
+func ProposalEventsFrom(events map[string][]string) []ProposalEvent {
+	var proposalEvents []ProposalEvent
+
+	for _, eventType := range ProposalEventTypes {
+		ids := slices.Clone(events[eventType+".proposal_id"])
+		sort.Strings(ids)
+
+		for i, id := range ids {
+			if i > 0 && id == ids[i-1] {
+				continue
+			}
+
+			proposalEvents = append(proposalEvents, ProposalEvent{Type: eventType, ProposalID: id})
+		}
+	}
+
+	return proposalEvents
+}
@@ -0,0 +1,109 @@
+package zetachain_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"github.com/gorilla/websocket"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/hazim1093/zeta-comms/pkg/zetachain"
+)
+
+var _ = Describe("SubscribeProposalEvents", func() {
+	It("should subscribe to governance events and report each affected proposal", func() {
+		var queries []string
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			Expect(r.URL.Path).To(Equal("/rpc/websocket"))
+
+			conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+			Expect(err).NotTo(HaveOccurred())
+			defer conn.Close()
+
+			for i := 0; i < 3; i++ {
+				var request struct {
+					ID     int               `json:"id"`
+					Method string            `json:"method"`
+					Params map[string]string `json:"params"`
+				}
+				Expect(conn.ReadJSON(&request)).To(Succeed())
+				Expect(request.Method).To(Equal("subscribe"))
+
+				queries = append(queries, request.Params["query"])
+				Expect(conn.WriteJSON(map[string]interface{}{"jsonrpc": "2.0", "id": request.ID, "result": map[string]interface{}{}})).To(Succeed())
+			}
+
+			Expect(conn.WriteJSON(map[string]interface{}{
+				"jsonrpc": "2.0",
+				"id":      3,
+				"result": map[string]interface{}{
+					"query": "tm.event = 'NewBlock' AND active_proposal.proposal_id EXISTS",
+					"events": map[string][]string{
+						"active_proposal.proposal_id":     {"42", "42"},
+						"active_proposal.proposal_result": {"proposal_passed"},
+						"tm.event":                        {"NewBlock"},
+					},
+				},
+			})).To(Succeed())
+		}))
+		defer server.Close()
+
+		rpcURL, _ := url.Parse(server.URL + "/rpc/")
+
+		connected := false
+
+		var events []zetachain.ProposalEvent
+
+		err := zetachain.SubscribeProposalEvents(context.Background(), *rpcURL,
+			func() { connected = true },
+			func(event zetachain.ProposalEvent) { events = append(events, event) },
+		)
+
+		Expect(err).To(MatchError(ContainSubstring("connection lost")))
+		Expect(connected).To(BeTrue())
+		Expect(queries).To(ConsistOf(
+			"tm.event = 'Tx' AND submit_proposal.proposal_id EXISTS",
+			"tm.event = 'Tx' AND proposal_vote.proposal_id EXISTS",
+			"tm.event = 'NewBlock' AND active_proposal.proposal_id EXISTS",
+		))
+		Expect(events).To(Equal([]zetachain.ProposalEvent{{Type: zetachain.EventActiveProposal, ProposalID: "42"}}))
+	})
+
+	It("should derive the websocket endpoint from the RPC URL", func() {
+		rpcURL, _ := url.Parse("https://rpc.example.com")
+		Expect(zetachain.WebsocketURL(*rpcURL)).To(Equal("wss://rpc.example.com/websocket"))
+
+		rpcURL, _ = url.Parse("http://localhost:26657/")
+		Expect(zetachain.WebsocketURL(*rpcURL)).To(Equal("ws://localhost:26657/websocket"))
+	})
+
+	It("should stop when the context is done", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					return
+				}
+			}
+		}))
+		defer server.Close()
+
+		rpcURL, _ := url.Parse(server.URL)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := zetachain.SubscribeProposalEvents(ctx, *rpcURL, nil, func(zetachain.ProposalEvent) {})
+		Expect(err).To(HaveOccurred())
+	})
+})